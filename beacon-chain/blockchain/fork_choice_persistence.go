@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+)
+
+// This saves the encoded fork choice store to the DB, so the nodes, votes and balances of the
+// store can be restored when the node restarts.
+func (s *Service) persistForkChoice(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.persistForkChoice")
+	defer span.End()
+
+	if s.forkChoiceStore == nil || s.beaconDB == nil {
+		return nil
+	}
+	enc, err := s.forkChoiceStore.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "could not encode fork choice store")
+	}
+	return s.beaconDB.SaveForkChoiceStore(ctx, enc)
+}
+
+// This periodically saves the fork choice store to the DB, once every epoch, until the service
+// is stopped.
+func (s *Service) persistForkChoiceRoutine() {
+	interval := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.persistForkChoice(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice store")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// This resumes the fork choice store from the one saved in the DB. A fresh store is started from
+// the finalized checkpoint instead when there is no saved store or when the saved store is not
+// consistent with the finalized checkpoint and the blocks in the DB. It returns true if the saved
+// store was restored.
+func (s *Service) loadForkChoice(ctx context.Context, justifiedCheckpoint *ethpb.Checkpoint, finalizedCheckpoint *ethpb.Checkpoint) bool {
+	store, err := s.restoreForkChoice(ctx, finalizedCheckpoint)
+	if err != nil {
+		log.WithError(err).Warn("Could not restore saved fork choice store, starting from finalized checkpoint")
+	}
+	if store == nil {
+		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		return false
+	}
+	s.forkChoiceStore = store
+	log.WithField("nodes", len(store.Nodes())).Info("Restored fork choice store from DB")
+	return true
+}
+
+// This decodes the fork choice store saved in the DB and checks it against the finalized
+// checkpoint and the saved blocks. A nil store is returned when no store was saved.
+func (s *Service) restoreForkChoice(ctx context.Context, finalizedCheckpoint *ethpb.Checkpoint) (*protoarray.ForkChoice, error) {
+	enc, err := s.beaconDB.ForkChoiceStore(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get saved fork choice store")
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	store, err := protoarray.Unmarshal(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalizedCheckpoint.Root))
+	if !store.HasNode(finalizedRoot) {
+		return nil, fmt.Errorf("finalized root %#x is not in the saved fork choice store", finalizedRoot)
+	}
+	for _, n := range store.Nodes() {
+		if !s.beaconDB.HasBlock(ctx, n.Root()) {
+			return nil, fmt.Errorf("block %#x of the saved fork choice store is not in db", n.Root())
+		}
+	}
+	// The store may have been saved before the latest finalization.
+	if err := store.Prune(ctx, finalizedRoot); err != nil {
+		return nil, errors.Wrap(err, "could not prune saved fork choice store")
+	}
+	return store, nil
+}
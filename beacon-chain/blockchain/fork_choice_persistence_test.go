@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestLoadForkChoice_RestoresSavedStore(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+	service, err := NewService(ctx, &Config{BeaconDB: db, ForkChoiceStore: protoarray.New(0, 0, [32]byte{})})
+	require.NoError(t, err)
+
+	var roots [][32]byte
+	parent := [32]byte{}
+	for slot := uint64(0); slot < 3; slot++ {
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		root, err := stateutil.BlockRoot(b.Block)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, b))
+		require.NoError(t, service.forkChoiceStore.ProcessBlock(ctx, slot, root, parent, [32]byte{}, 0, 0))
+		roots = append(roots, root)
+		parent = root
+	}
+	require.NoError(t, service.persistForkChoice(ctx))
+
+	cp := &ethpb.Checkpoint{Root: roots[0][:]}
+	service.forkChoiceStore = nil
+	assert.Equal(t, true, service.loadForkChoice(ctx, cp, cp), "Did not restore fork choice store")
+	require.Equal(t, 3, len(service.forkChoiceStore.Nodes()))
+	for _, r := range roots {
+		assert.Equal(t, true, service.forkChoiceStore.HasNode(r))
+	}
+
+	// A store holding a block which is not in the DB is not restored.
+	require.NoError(t, service.forkChoiceStore.ProcessBlock(ctx, 3, [32]byte{'a'}, parent, [32]byte{}, 0, 0))
+	require.NoError(t, service.persistForkChoice(ctx))
+	assert.Equal(t, false, service.loadForkChoice(ctx, cp, cp), "Restored inconsistent fork choice store")
+	assert.Equal(t, 0, len(service.forkChoiceStore.Nodes()))
+}
+
+func TestLoadForkChoice_UnknownFinalizedRoot(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+	service, err := NewService(ctx, &Config{BeaconDB: db, ForkChoiceStore: protoarray.New(0, 0, [32]byte{})})
+	require.NoError(t, err)
+
+	b := testutil.NewBeaconBlock()
+	root, err := stateutil.BlockRoot(b.Block)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, b))
+	require.NoError(t, service.forkChoiceStore.ProcessBlock(ctx, 0, root, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, service.persistForkChoice(ctx))
+
+	cp := &ethpb.Checkpoint{Epoch: 1, Root: []byte{'b'}}
+	assert.Equal(t, false, service.loadForkChoice(ctx, cp, cp), "Restored fork choice store without finalized root")
+	assert.Equal(t, false, service.forkChoiceStore.HasNode(root))
+}
@@ -2,8 +2,10 @@ package blockchain
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/emicklei/dot"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -34,10 +36,9 @@ const template = `<html>
 
 // TreeHandler is a handler to serve /tree page in metrics.
 func (s *Service) TreeHandler(w http.ResponseWriter, r *http.Request) {
-	if s.headState(r.Context()) == nil {
-		if _, err := w.Write([]byte("Unavailable during initial syncing")); err != nil {
-			log.WithError(err).Error("Failed to render p2p info page")
-		}
+	if s.forkChoiceStore == nil || !s.hasHeadState() {
+		http.Error(w, "Unavailable during initial syncing", http.StatusServiceUnavailable)
+		return
 	}
 
 	nodes := s.forkChoiceStore.Nodes()
@@ -85,6 +86,59 @@ func (s *Service) TreeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ForkChoiceHandler is a handler to serve the /fork-choice page in metrics. It renders the fork
+// choice tree with the weights, votes and checkpoints of its nodes as Graphviz DOT, or as JSON with
+// format=json. The nodes can be filtered with the start_slot and end_slot query parameters. This
+// is only served over HTTP as a view for humans and graph tools, the debug gRPC service already
+// exposes the same proto array nodes with GetProtoArrayForkChoice.
+func (s *Service) ForkChoiceHandler(w http.ResponseWriter, r *http.Request) {
+	if s.forkChoiceStore == nil {
+		http.Error(w, "Fork choice store is not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+	query := r.URL.Query()
+	startSlot, err := slotQueryParam(query.Get("start_slot"), 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	endSlot, err := slotQueryParam(query.Get("end_slot"), ^uint64(0))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	graph := s.forkChoiceStore.Graph(startSlot, endSlot)
+
+	switch query.Get("format") {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(graph); err != nil {
+			log.WithError(err).Error("Failed to render fork choice page")
+		}
+	case "", "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(graph.DOT())); err != nil {
+			log.WithError(err).Error("Failed to render fork choice page")
+		}
+	default:
+		http.Error(w, "Unknown format, expected dot or json", http.StatusBadRequest)
+	}
+}
+
+// This parses an optional slot query parameter, returning the default slot when it is not set.
+func slotQueryParam(value string, defaultSlot uint64) (uint64, error) {
+	if value == "" {
+		return defaultSlot, nil
+	}
+	slot, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slot %q", value)
+	}
+	return slot, nil
+}
+
 func averageBalance(balances []uint64) float64 {
 	total := uint64(0)
 	for i := 0; i < len(balances); i++ {
@@ -2,8 +2,10 @@ package blockchain
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -44,3 +46,51 @@ func TestService_TreeHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
+
+func TestService_ForkChoiceHandler(t *testing.T) {
+	ctx := context.Background()
+	db, sCache := testDB.SetupDB(t)
+	cfg := &Config{
+		BeaconDB:        db,
+		ForkChoiceStore: protoarray.New(0, 0, [32]byte{'a'}),
+		StateGen:        stategen.New(db, sCache),
+	}
+	s, err := NewService(ctx, cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.forkChoiceStore.ProcessBlock(ctx, 0, [32]byte{'a'}, [32]byte{'g'}, [32]byte{'c'}, 0, 0))
+	require.NoError(t, s.forkChoiceStore.ProcessBlock(ctx, 1, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'c'}, 0, 0))
+
+	req, err := http.NewRequest("GET", "/fork-choice?format=json&start_slot=1", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(s.ForkChoiceHandler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	graph := &protoarray.Graph{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), graph))
+	require.Equal(t, 1, len(graph.Nodes))
+	assert.Equal(t, uint64(1), graph.Nodes[0].Slot)
+
+	req, err = http.NewRequest("GET", "/fork-choice", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(s.ForkChoiceHandler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, true, strings.Contains(rr.Body.String(), "digraph"))
+
+	req, err = http.NewRequest("GET", "/fork-choice?end_slot=foo", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(s.ForkChoiceHandler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestService_ForkChoiceHandler_NotInitialized(t *testing.T) {
+	s := &Service{}
+	for _, handler := range []http.HandlerFunc{s.ForkChoiceHandler, s.TreeHandler} {
+		req, err := http.NewRequest("GET", "/fork-choice", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	}
+}
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"context"
 	"fmt"
+	"runtime"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -230,14 +231,17 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []*ethpb.SignedBeaconBl
 
 	jCheckpoints := make([]*ethpb.Checkpoint, len(blks))
 	fCheckpoints := make([]*ethpb.Checkpoint, len(blks))
-	sigSet := &bls.SignatureSet{
-		Signatures: []bls.Signature{},
-		PublicKeys: []bls.PublicKey{},
-		Messages:   [][32]byte{},
-	}
+	// Signatures are verified by a pool of workers while the state transition runs without
+	// verifying them, the resulting states are only saved if all the signatures are valid.
+	verifier := newSignatureVerifier(runtime.GOMAXPROCS(0))
+	defer verifier.stop()
 	set := new(bls.SignatureSet)
 	boundaries := make(map[[32]byte]*stateTrie.BeaconState)
 	for i, b := range blks {
+		// Stop early if any of the signatures collected so far is invalid.
+		if verifier.failed() {
+			break
+		}
 		set, preState, err = state.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
 		if err != nil {
 			return nil, nil, err
@@ -251,15 +255,11 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []*ethpb.SignedBeaconBl
 		}
 		jCheckpoints[i] = preState.CurrentJustifiedCheckpoint()
 		fCheckpoints[i] = preState.FinalizedCheckpoint()
-		sigSet.Join(set)
+		verifier.add(set)
 	}
-	verify, err := bls.VerifyMultipleSignatures(sigSet.Signatures, sigSet.Messages, sigSet.PublicKeys)
-	if err != nil {
+	if err := verifier.wait(); err != nil {
 		return nil, nil, err
 	}
-	if !verify {
-		return nil, nil, errors.New("batch block signature verification failed")
-	}
 	for r, st := range boundaries {
 		if err := s.stateGen.SaveState(ctx, r, st); err != nil {
 			return nil, nil, err
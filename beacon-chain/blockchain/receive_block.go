@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -30,6 +31,7 @@ func (s *Service) ReceiveBlock(ctx context.Context, block *ethpb.SignedBeaconBlo
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlock")
 	defer span.End()
 	blockCopy := stateTrie.CopySignedBeaconBlock(block)
+	prevFinalizedEpoch := s.FinalizedCheckpt().Epoch
 
 	// Apply state transition on the new block.
 	if err := s.onBlock(ctx, blockCopy, blockRoot); err != nil {
@@ -52,6 +54,7 @@ func (s *Service) ReceiveBlock(ctx context.Context, block *ethpb.SignedBeaconBlo
 			Verified:  true,
 		},
 	})
+	s.notifyNewFinalizedCheckpoint(prevFinalizedEpoch)
 
 	// Handle post block operations such as attestations and exits.
 	if err := s.handlePostBlockOperations(blockCopy.Block); err != nil {
@@ -76,6 +79,7 @@ func (s *Service) ReceiveBlockInitialSync(ctx context.Context, block *ethpb.Sign
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlockNoVerify")
 	defer span.End()
 	blockCopy := stateTrie.CopySignedBeaconBlock(block)
+	prevFinalizedEpoch := s.FinalizedCheckpt().Epoch
 
 	// Apply state transition on the new block.
 	if err := s.onBlockInitialSyncStateTransition(ctx, blockCopy, blockRoot); err != nil {
@@ -93,6 +97,7 @@ func (s *Service) ReceiveBlockInitialSync(ctx context.Context, block *ethpb.Sign
 			Verified:  true,
 		},
 	})
+	s.notifyNewFinalizedCheckpoint(prevFinalizedEpoch)
 
 	// Reports on blockCopy and fork choice metrics.
 	reportSlotMetrics(blockCopy.Block.Slot, s.headSlot(), s.CurrentSlot(), s.finalizedCheckpt)
@@ -113,6 +118,7 @@ func (s *Service) ReceiveBlockInitialSync(ctx context.Context, block *ethpb.Sign
 func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []*ethpb.SignedBeaconBlock, blkRoots [][32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlockBatch")
 	defer span.End()
+	prevFinalizedEpoch := s.FinalizedCheckpt().Epoch
 
 	// Apply state transition on the incoming newly received blockCopy without verifying its BLS contents.
 	fCheckpoints, jCheckpoints, err := s.onBlockBatch(ctx, blocks, blkRoots)
@@ -141,10 +147,27 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []*ethpb.SignedB
 		// Reports on blockCopy and fork choice metrics.
 		reportSlotMetrics(blockCopy.Block.Slot, s.headSlot(), s.CurrentSlot(), s.finalizedCheckpt)
 	}
+	s.notifyNewFinalizedCheckpoint(prevFinalizedEpoch)
 
 	return nil
 }
 
+// This sends a finalized checkpoint notification to the state feed when the finalized epoch moved
+// past the input epoch while processing blocks.
+func (s *Service) notifyNewFinalizedCheckpoint(prevFinalizedEpoch uint64) {
+	cp := s.FinalizedCheckpt()
+	if cp.Epoch <= prevFinalizedEpoch {
+		return
+	}
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalizedCheckpoint,
+		Data: &statefeed.FinalizedCheckpointData{
+			Epoch:     cp.Epoch,
+			BlockRoot: bytesutil.ToBytes32(cp.Root),
+		},
+	})
+}
+
 // HasInitSyncBlock returns true if the block of the input root exists in initial sync blocks cache.
 func (s *Service) HasInitSyncBlock(root [32]byte) bool {
 	return s.hasInitSyncBlock(root)
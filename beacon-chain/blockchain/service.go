@@ -161,6 +161,10 @@ func (s *Service) Start() {
 		if err != nil {
 			log.Fatalf("Could not retrieve genesis state: %v", err)
 		}
+		// Nodes started from a checkpoint state do not have a genesis state.
+		if gState == nil {
+			gState = beaconState
+		}
 		go slotutil.CountdownToGenesis(s.ctx, s.genesisTime, uint64(gState.NumValidators()))
 
 		justifiedCheckpoint, err := s.beaconDB.JustifiedCheckpoint(s.ctx)
@@ -181,7 +185,16 @@ func (s *Service) Start() {
 		s.bestJustifiedCheckpt = stateTrie.CopyCheckpoint(justifiedCheckpoint)
 		s.finalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.prevFinalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
-		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		restored := s.loadForkChoice(s.ctx, justifiedCheckpoint, finalizedCheckpoint)
+		if err := s.insertOriginToForkChoice(s.ctx, finalizedCheckpoint); err != nil {
+			log.Fatalf("Could not insert origin block to fork choice: %v", err)
+		}
+		// The head is set to the finalized block until the restored store gives a head.
+		if restored {
+			if err := s.updateHead(s.ctx, s.getJustifiedBalances()); err != nil {
+				log.WithError(err).Warn("Could not update head from restored fork choice store")
+			}
+		}
 
 		s.stateNotifier.StateFeed().Send(&feed.Event{
 			Type: statefeed.Initialized,
@@ -226,6 +239,7 @@ func (s *Service) Start() {
 	}
 
 	go s.processAttestation(attestationProcessorSubscribed)
+	go s.persistForkChoiceRoutine()
 }
 
 // processChainStartTime initializes a series of deposits from the ChainStart deposits in the eth1
@@ -294,6 +308,9 @@ func (s *Service) initializeBeaconChain(
 func (s *Service) Stop() error {
 	defer s.cancel()
 
+	if err := s.persistForkChoice(s.ctx); err != nil {
+		log.WithError(err).Error("Could not save fork choice store")
+	}
 	if s.stateGen != nil && s.head != nil && s.head.state != nil {
 		return s.stateGen.ForceCheckpoint(s.ctx, s.head.state.FinalizedCheckpoint().Root)
 	}
@@ -383,14 +400,22 @@ func (s *Service) initializeChainInfo(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "could not get genesis block from db")
 	}
-	if genesisBlock == nil {
-		return errors.New("no genesis block in db")
-	}
-	genesisBlkRoot, err := stateutil.BlockRoot(genesisBlock.Block)
-	if err != nil {
-		return errors.Wrap(err, "could not get signing root of genesis block")
+	if genesisBlock != nil {
+		genesisBlkRoot, err := stateutil.BlockRoot(genesisBlock.Block)
+		if err != nil {
+			return errors.Wrap(err, "could not get signing root of genesis block")
+		}
+		s.genesisRoot = genesisBlkRoot
+	} else {
+		// A node started from a checkpoint has no genesis block, the finalized checkpoint
+		// it was started from is the lowest point of the chain it knows of.
+		if _, err := s.beaconDB.OriginBlockRoot(ctx); err != nil {
+			if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+				return errors.New("no genesis block in db")
+			}
+			return errors.Wrap(err, "could not get origin block root from db")
+		}
 	}
-	s.genesisRoot = genesisBlkRoot
 
 	if flags.Get().UnsafeSync {
 		headBlock, err := s.beaconDB.HeadBlock(ctx)
@@ -450,6 +475,36 @@ func (s *Service) resumeForkChoice(justifiedCheckpoint *ethpb.Checkpoint, finali
 	s.forkChoiceStore = store
 }
 
+// This inserts the checkpoint origin block into the fork choice store when the node was started
+// from, and has not finalized past, its origin checkpoint. The origin block has no ancestors in
+// the DB so it has to be the root node of the fork choice tree.
+func (s *Service) insertOriginToForkChoice(ctx context.Context, finalizedCheckpoint *ethpb.Checkpoint) error {
+	originRoot, err := s.beaconDB.OriginBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if originRoot != bytesutil.ToBytes32(finalizedCheckpoint.Root) {
+		return nil
+	}
+	originBlock, err := s.beaconDB.Block(ctx, originRoot)
+	if err != nil {
+		return err
+	}
+	if originBlock == nil || originBlock.Block == nil {
+		return errors.New("origin block is not in db")
+	}
+	return s.forkChoiceStore.ProcessBlock(ctx,
+		originBlock.Block.Slot,
+		originRoot,
+		bytesutil.ToBytes32(originBlock.Block.ParentRoot),
+		bytesutil.ToBytes32(originBlock.Block.Body.Graffiti),
+		finalizedCheckpoint.Epoch,
+		finalizedCheckpoint.Epoch)
+}
+
 // This returns true if block has been processed before. Two ways to verify the block has been processed:
 // 1.) Check fork choice store.
 // 2.) Check DB.
@@ -268,6 +268,37 @@ func TestChainService_InitializeChainInfo(t *testing.T) {
 	assert.Equal(t, genesisRoot, c.genesisRoot, "Genesis block root incorrect")
 }
 
+func TestChainService_InitializeChainInfo_FromOrigin(t *testing.T) {
+	db, sc := testDB.SetupDB(t)
+	ctx := context.Background()
+
+	originSlot := params.BeaconConfig().SlotsPerEpoch * 2
+	originBlock := testutil.NewBeaconBlock()
+	originBlock.Block.Slot = originSlot
+	originBlock.Block.ParentRoot = bytesutil.PadTo([]byte{'a'}, 32)
+	originState := testutil.NewBeaconState()
+	require.NoError(t, originState.SetSlot(originSlot))
+	originRoot, err := stateutil.BlockRoot(originBlock.Block)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, originBlock))
+	require.NoError(t, db.SaveState(ctx, originState, originRoot))
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, originRoot))
+	cp := &ethpb.Checkpoint{
+		Epoch: helpers.SlotToEpoch(originSlot),
+		Root:  originRoot[:],
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, cp))
+
+	c := &Service{beaconDB: db, stateGen: stategen.New(db, sc), forkChoiceStore: protoarray.New(cp.Epoch, cp.Epoch, originRoot)}
+	require.NoError(t, c.initializeChainInfo(ctx))
+	r, err := c.HeadRoot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, originRoot[:], r, "Head root incorrect")
+
+	require.NoError(t, c.insertOriginToForkChoice(ctx, cp))
+	assert.Equal(t, true, c.forkChoiceStore.HasNode(originRoot), "Origin block not in fork choice store")
+}
+
 func TestChainService_SaveHeadNoDB(t *testing.T) {
 	db, sc := testDB.SetupDB(t)
 	ctx := context.Background()
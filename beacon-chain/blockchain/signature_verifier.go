@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+// sigVerifyChunkSize is the number of signatures verified together by a single worker. Batch
+// verification amortizes the cost of the final exponentiation, so signatures are not verified one by one.
+const sigVerifyChunkSize = 128
+
+var (
+	errSignatureVerifyFailed = errors.New("batch block signature verification failed")
+	errSignatureVerifyAbort  = errors.New("batch block signature verification aborted")
+)
+
+// signatureVerifier verifies signature sets with a pool of workers. Signature sets of a batch of blocks
+// are added as they are collected by the state transition, which allows them to be verified in parallel
+// across CPU cores while the state transition of the rest of the batch is still running.
+type signatureVerifier struct {
+	pending   *bls.SignatureSet
+	chunks    chan *bls.SignatureSet
+	done      chan struct{}
+	wg        sync.WaitGroup
+	errOnce   sync.Once
+	closeOnce sync.Once
+	err       error
+}
+
+// newSignatureVerifier starts a signature verifier with the given number of workers.
+func newSignatureVerifier(workers int) *signatureVerifier {
+	if workers < 1 {
+		workers = 1
+	}
+	v := &signatureVerifier{
+		pending: bls.NewSet(),
+		chunks:  make(chan *bls.SignatureSet, workers),
+		done:    make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		v.wg.Add(1)
+		go v.verify()
+	}
+	return v
+}
+
+// verify runs a worker, which verifies chunks of signatures until the verifier is closed. Once any
+// of the chunks fails verification, the remaining chunks are skipped.
+func (v *signatureVerifier) verify() {
+	defer v.wg.Done()
+	for set := range v.chunks {
+		if v.failed() {
+			continue
+		}
+		verified, err := set.Verify()
+		if err != nil {
+			v.fail(errors.Wrap(err, "could not verify signatures"))
+			continue
+		}
+		if !verified {
+			v.fail(errSignatureVerifyFailed)
+		}
+	}
+}
+
+// add queues a signature set for verification.
+func (v *signatureVerifier) add(set *bls.SignatureSet) {
+	v.pending.Join(set)
+	if len(v.pending.Signatures) >= sigVerifyChunkSize {
+		v.flush()
+	}
+}
+
+// flush hands the pending signatures over to the workers.
+func (v *signatureVerifier) flush() {
+	if len(v.pending.Signatures) == 0 {
+		return
+	}
+	v.chunks <- v.pending
+	v.pending = bls.NewSet()
+}
+
+// failed returns true once any of the signature sets has failed verification.
+func (v *signatureVerifier) failed() bool {
+	select {
+	case <-v.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *signatureVerifier) fail(err error) {
+	v.errOnce.Do(func() {
+		v.err = err
+		close(v.done)
+	})
+}
+
+// wait verifies the remaining signatures and blocks until all the workers are done. An error is
+// returned if any of the signature sets has failed verification.
+func (v *signatureVerifier) wait() error {
+	v.flush()
+	v.closeOnce.Do(func() {
+		close(v.chunks)
+	})
+	v.wg.Wait()
+	return v.err
+}
+
+// stop aborts the verification of the signatures which are still queued, and waits for the workers to
+// exit. It is a no-op once the verifier has been waited on.
+func (v *signatureVerifier) stop() {
+	v.fail(errSignatureVerifyAbort)
+	// The verification result, if any, has already been returned by wait.
+	_ = v.wait()
+}
@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func signatureSet(n int, valid bool) *bls.SignatureSet {
+	set := bls.NewSet()
+	for i := 0; i < n; i++ {
+		sk := bls.RandKey()
+		msg := [32]byte{byte(i), byte(i >> 8)}
+		sig := sk.Sign(msg[:])
+		if !valid {
+			// Sign a different message, so that the signature does not match.
+			sig = sk.Sign([]byte("invalid"))
+		}
+		set.Signatures = append(set.Signatures, sig)
+		set.PublicKeys = append(set.PublicKeys, sk.PublicKey())
+		set.Messages = append(set.Messages, msg)
+	}
+	return set
+}
+
+func TestSignatureVerifier_Valid(t *testing.T) {
+	v := newSignatureVerifier(4)
+	defer v.stop()
+	// Spans multiple chunks.
+	for i := 0; i < 3; i++ {
+		v.add(signatureSet(sigVerifyChunkSize/2+1, true))
+	}
+	require.NoError(t, v.wait())
+	assert.Equal(t, false, v.failed())
+}
+
+func TestSignatureVerifier_Invalid(t *testing.T) {
+	v := newSignatureVerifier(4)
+	defer v.stop()
+	v.add(signatureSet(sigVerifyChunkSize, true))
+	v.add(signatureSet(1, false))
+	v.add(signatureSet(2, true))
+	assert.ErrorContains(t, errSignatureVerifyFailed.Error(), v.wait())
+	assert.Equal(t, true, v.failed())
+}
+
+func TestSignatureVerifier_Stop(t *testing.T) {
+	v := newSignatureVerifier(1)
+	v.add(signatureSet(sigVerifyChunkSize, true))
+	v.add(signatureSet(1, true))
+	v.stop()
+	assert.Equal(t, true, v.failed())
+	assert.ErrorContains(t, errSignatureVerifyAbort.Error(), v.wait())
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+var (
+	// coldStateCacheSize defines the max number of cold states this can cache.
+	coldStateCacheSize = 16
+	// Metrics
+	coldStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cold_state_cache_hit",
+		Help: "The total number of cache hits on the cold state cache.",
+	})
+	coldStateCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cold_state_cache_miss",
+		Help: "The total number of cache misses on the cold state cache.",
+	})
+)
+
+// ColdStateCache is used to store the states regenerated before the finalized check point.
+// Those states are canonical, so they are keyed by slot.
+type ColdStateCache struct {
+	cache *lru.Cache
+	lock  sync.RWMutex
+}
+
+// NewColdStateCache initializes the underlying cache.
+func NewColdStateCache() *ColdStateCache {
+	cache, err := lru.New(coldStateCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &ColdStateCache{
+		cache: cache,
+	}
+}
+
+// HighestSlotBelow returns a copy of the cached state with the highest slot below the input slot,
+// if any.
+func (c *ColdStateCache) HighestSlotBelow(slot uint64) *stateTrie.BeaconState {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var best uint64
+	found := false
+	for _, k := range c.cache.Keys() {
+		s, ok := k.(uint64)
+		if ok && s < slot && (!found || s > best) {
+			best = s
+			found = true
+		}
+	}
+	if found {
+		if item, exists := c.cache.Get(best); exists && item != nil {
+			coldStateCacheHit.Inc()
+			return item.(*stateTrie.BeaconState).Copy()
+		}
+	}
+	coldStateCacheMiss.Inc()
+	return nil
+}
+
+// Put a copy of the state in the cache.
+func (c *ColdStateCache) Put(state *stateTrie.BeaconState) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.Add(state.Slot(), state.Copy())
+}
+
+// Has returns true if a state of the slot exists in the cache.
+func (c *ColdStateCache) Has(slot uint64) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.cache.Contains(slot)
+}
@@ -0,0 +1,37 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestColdStateCache_HighestSlotBelow(t *testing.T) {
+	c := cache.NewColdStateCache()
+	assert.Equal(t, (*stateTrie.BeaconState)(nil), c.HighestSlotBelow(100))
+
+	for _, slot := range []uint64{10, 30, 20} {
+		state, err := stateTrie.InitializeFromProto(&pb.BeaconState{Slot: slot})
+		require.NoError(t, err)
+		c.Put(state)
+	}
+	assert.Equal(t, true, c.Has(20))
+	assert.Equal(t, false, c.Has(25))
+
+	res := c.HighestSlotBelow(30)
+	require.NotNil(t, res)
+	assert.Equal(t, uint64(20), res.Slot())
+	res = c.HighestSlotBelow(31)
+	require.NotNil(t, res)
+	assert.Equal(t, uint64(30), res.Slot())
+	assert.Equal(t, (*stateTrie.BeaconState)(nil), c.HighestSlotBelow(10))
+
+	// The cached state is not modified through the returned copy.
+	require.NoError(t, res.SetSlot(40))
+	assert.Equal(t, false, c.Has(40))
+	assert.Equal(t, uint64(30), c.HighestSlotBelow(31).Slot())
+}
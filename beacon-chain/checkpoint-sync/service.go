@@ -0,0 +1,227 @@
+// Package checkpointsync allows a beacon node to start from a trusted, finalized
+// (weak subjectivity) checkpoint state and block instead of replaying the chain
+// from genesis.
+package checkpointsync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = shared.Service(&Service{})
+
+// Service seeds the beacon node database with a trusted finalized checkpoint state and
+// block, so the node syncs forward from that checkpoint rather than from genesis.
+type Service struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	beaconDB   db.HeadAccessDatabase
+	stateGen   *stategen.State
+	statePath  string
+	blockPath  string
+	originRoot [32]byte
+}
+
+// Config options for the checkpoint sync service.
+type Config struct {
+	BeaconDB  db.HeadAccessDatabase
+	StateGen  *stategen.State
+	StatePath string
+	BlockPath string
+}
+
+// NewService reads the checkpoint state and block from the configured files and saves them
+// into the database as the finalized and justified checkpoint of the node. This must run
+// before the blockchain service starts, which resumes the chain from the saved checkpoint.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		beaconDB:  cfg.BeaconDB,
+		stateGen:  cfg.StateGen,
+		statePath: cfg.StatePath,
+		blockPath: cfg.BlockPath,
+	}
+	if s.statePath == "" || s.blockPath == "" {
+		cancel()
+		return nil, errors.New("both a checkpoint state and a checkpoint block file are required")
+	}
+
+	st, blk, err := s.loadCheckpoint()
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "could not load checkpoint")
+	}
+	if err := s.saveCheckpoint(ctx, st, blk); err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "could not save checkpoint")
+	}
+	return s, nil
+}
+
+// Start does nothing, the checkpoint has already been saved on service creation.
+func (s *Service) Start() {
+}
+
+// Stop the checkpoint sync service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+// OriginRoot returns the block root of the checkpoint the node started from.
+func (s *Service) OriginRoot() [32]byte {
+	return s.originRoot
+}
+
+// Reads and decodes the SSZ encoded checkpoint state and block files.
+func (s *Service) loadCheckpoint() (*stateTrie.BeaconState, *ethpb.SignedBeaconBlock, error) {
+	stateData, err := ioutil.ReadFile(s.statePath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not read checkpoint state file")
+	}
+	protoState := &pb.BeaconState{}
+	if err := protoState.UnmarshalSSZ(stateData); err != nil {
+		return nil, nil, errors.Wrap(err, "could not unmarshal checkpoint state")
+	}
+	st, err := stateTrie.InitializeFromProto(protoState)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not initialize checkpoint state trie")
+	}
+
+	blockData, err := ioutil.ReadFile(s.blockPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not read checkpoint block file")
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := blk.UnmarshalSSZ(blockData); err != nil {
+		return nil, nil, errors.Wrap(err, "could not unmarshal checkpoint block")
+	}
+	if blk.Block == nil {
+		return nil, nil, errors.New("checkpoint block is nil")
+	}
+	return st, blk, nil
+}
+
+// This verifies the checkpoint state descends from the checkpoint block and saves both to the DB,
+// marking them as the finalized and justified checkpoint of the node.
+func (s *Service) saveCheckpoint(ctx context.Context, st *stateTrie.BeaconState, blk *ethpb.SignedBeaconBlock) error {
+	if err := verifyCheckpoint(ctx, st, blk); err != nil {
+		return err
+	}
+	blockRoot, err := stateutil.BlockRoot(blk.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not compute checkpoint block root")
+	}
+	s.originRoot = blockRoot
+
+	savedRoot, err := s.beaconDB.OriginBlockRoot(ctx)
+	switch {
+	case err == nil && savedRoot == blockRoot:
+		log.WithField("root", fmt.Sprintf("%#x", blockRoot)).Info("Checkpoint already saved in DB, resuming from database")
+		return nil
+	case err == nil:
+		return fmt.Errorf("database was started from checkpoint %#x, not %#x", savedRoot, blockRoot)
+	case !errors.Is(err, db.ErrNotFoundOriginBlockRoot):
+		return err
+	}
+	headBlock, err := s.beaconDB.HeadBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if headBlock != nil {
+		return errors.New("database already contains a chain, clear the database to start from a checkpoint")
+	}
+
+	if err := s.beaconDB.SaveBlock(ctx, blk); err != nil {
+		return errors.Wrap(err, "could not save checkpoint block")
+	}
+	if err := s.beaconDB.SaveStateSummary(ctx, &pb.StateSummary{
+		Slot: st.Slot(),
+		Root: blockRoot[:],
+	}); err != nil {
+		return err
+	}
+	if err := s.beaconDB.SaveState(ctx, st, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save checkpoint state")
+	}
+	if err := s.beaconDB.SaveOriginBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save origin block root")
+	}
+	if err := s.beaconDB.SaveHeadBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	cp := &ethpb.Checkpoint{
+		Epoch: helpers.SlotToEpoch(st.Slot()),
+		Root:  blockRoot[:],
+	}
+	if err := s.beaconDB.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := s.beaconDB.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	s.stateGen.SaveFinalizedState(st.Slot(), blockRoot, st)
+
+	log.WithFields(logrus.Fields{
+		"slot":  st.Slot(),
+		"epoch": cp.Epoch,
+		"root":  fmt.Sprintf("%#x", blockRoot),
+	}).Info("Saved checkpoint state and block in DB")
+	return nil
+}
+
+// The checkpoint state must be an epoch boundary state which results from applying the
+// checkpoint block, with possibly empty slots processed on top of it.
+func verifyCheckpoint(ctx context.Context, st *stateTrie.BeaconState, blk *ethpb.SignedBeaconBlock) error {
+	if st.Slot()%params.BeaconConfig().SlotsPerEpoch != 0 {
+		return fmt.Errorf("checkpoint state slot %d is not an epoch boundary", st.Slot())
+	}
+	if blk.Block.Slot > st.Slot() {
+		return fmt.Errorf("checkpoint block slot %d is higher than state slot %d", blk.Block.Slot, st.Slot())
+	}
+	header := st.LatestBlockHeader()
+	if header == nil {
+		return errors.New("checkpoint state has no latest block header")
+	}
+	// The state root of the latest block header is only filled in on the next slot processing.
+	if bytesutil.ToBytes32(header.StateRoot) == params.BeaconConfig().ZeroHash {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	headerRoot, err := stateutil.BlockHeaderRoot(header)
+	if err != nil {
+		return err
+	}
+	blockRoot, err := stateutil.BlockRoot(blk.Block)
+	if err != nil {
+		return err
+	}
+	if headerRoot != blockRoot {
+		return fmt.Errorf("checkpoint state latest block header root %#x does not match block root %#x", headerRoot, blockRoot)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package checkpointsync
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// Returns an epoch boundary state at the input slot with its latest block header set to the returned block.
+func checkpointStateAndBlock(t *testing.T, slot uint64) (*stateTrie.BeaconState, *ethpb.SignedBeaconBlock) {
+	st := testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(slot))
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte{'a'}, 32)
+	bodyRoot, err := stateutil.BlockBodyRoot(blk.Block.Body)
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  params.BeaconConfig().ZeroHash[:],
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	return st, blk
+}
+
+func writeCheckpointFiles(t *testing.T, st *stateTrie.BeaconState, blk *ethpb.SignedBeaconBlock) (string, string) {
+	dir := testutil.TempDir()
+	stateEnc, err := st.InnerStateUnsafe().MarshalSSZ()
+	require.NoError(t, err)
+	blockEnc, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	statePath := filepath.Join(dir, "checkpoint_state.ssz")
+	blockPath := filepath.Join(dir, "checkpoint_block.ssz")
+	require.NoError(t, ioutil.WriteFile(statePath, stateEnc, 0600))
+	require.NoError(t, ioutil.WriteFile(blockPath, blockEnc, 0600))
+	return statePath, blockPath
+}
+
+func TestNewService_SavesCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	db, sc := testDB.SetupDB(t)
+	slot := params.BeaconConfig().SlotsPerEpoch * 4
+	st, blk := checkpointStateAndBlock(t, slot)
+	statePath, blockPath := writeCheckpointFiles(t, st, blk)
+
+	s, err := NewService(ctx, &Config{
+		BeaconDB:  db,
+		StateGen:  stategen.New(db, sc),
+		StatePath: statePath,
+		BlockPath: blockPath,
+	})
+	require.NoError(t, err)
+
+	root, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	assert.Equal(t, root, s.OriginRoot())
+	originRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, originRoot)
+	assert.Equal(t, true, db.HasBlock(ctx, root))
+	assert.Equal(t, true, db.HasState(ctx, root))
+	assert.Equal(t, root, db.LastArchivedRoot(ctx))
+
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), finalized.Epoch)
+	assert.DeepEqual(t, root[:], finalized.Root)
+	justified, err := db.JustifiedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, finalized, justified)
+
+	// Restarting with the same checkpoint resumes from the database.
+	_, err = NewService(ctx, &Config{
+		BeaconDB:  db,
+		StateGen:  stategen.New(db, sc),
+		StatePath: statePath,
+		BlockPath: blockPath,
+	})
+	require.NoError(t, err)
+}
+
+func TestNewService_NonEmptyDB(t *testing.T) {
+	ctx := context.Background()
+	db, sc := testDB.SetupDB(t)
+	headBlock := testutil.NewBeaconBlock()
+	headRoot, err := stateutil.BlockRoot(headBlock.Block)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, headBlock))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, headRoot))
+
+	st, blk := checkpointStateAndBlock(t, params.BeaconConfig().SlotsPerEpoch)
+	statePath, blockPath := writeCheckpointFiles(t, st, blk)
+	_, err = NewService(ctx, &Config{
+		BeaconDB:  db,
+		StateGen:  stategen.New(db, sc),
+		StatePath: statePath,
+		BlockPath: blockPath,
+	})
+	assert.ErrorContains(t, "database already contains a chain", err)
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	st, blk := checkpointStateAndBlock(t, slotsPerEpoch)
+	require.NoError(t, verifyCheckpoint(ctx, st, blk))
+
+	// Empty slots processed after the checkpoint block are allowed.
+	st, blk = checkpointStateAndBlock(t, slotsPerEpoch-1)
+	header := st.LatestBlockHeader()
+	header.StateRoot = blk.Block.StateRoot
+	require.NoError(t, st.SetLatestBlockHeader(header))
+	require.NoError(t, st.SetSlot(slotsPerEpoch))
+	require.NoError(t, verifyCheckpoint(ctx, st, blk))
+
+	st, blk = checkpointStateAndBlock(t, slotsPerEpoch+1)
+	assert.ErrorContains(t, "is not an epoch boundary", verifyCheckpoint(ctx, st, blk))
+
+	st, blk = checkpointStateAndBlock(t, slotsPerEpoch)
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte{'b'}, 32)
+	assert.ErrorContains(t, "does not match block root", verifyCheckpoint(ctx, st, blk))
+}
@@ -17,6 +17,8 @@ const (
 	// Reorg is an event sent when the new head state's slot after a block
 	// transition is lower than its previous head state slot value.
 	Reorg
+	// FinalizedCheckpoint is sent when a processed block advanced the finalized checkpoint of the chain.
+	FinalizedCheckpoint
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -56,3 +58,11 @@ type ReorgData struct {
 	// OldSlot is the slot of the head state before the reorg.
 	OldSlot uint64
 }
+
+// FinalizedCheckpointData is the data sent with FinalizedCheckpoint events.
+type FinalizedCheckpointData struct {
+	// Epoch of the new finalized checkpoint.
+	Epoch uint64
+	// BlockRoot of the new finalized checkpoint.
+	BlockRoot [32]byte
+}
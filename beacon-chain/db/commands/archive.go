@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/urfave/cli/v2"
+)
+
+// beaconChainDBName is the directory of the beacon node database within the data directory.
+const beaconChainDBName = "beaconchaindata"
+
+// Export writes the database of the data directory to the archive file given by the --archive flag.
+func Export(cliCtx *cli.Context) error {
+	archivePath := cliCtx.String(flags.ArchivePathFlag.Name)
+	if archivePath == "" {
+		return errors.New("no archive path specified")
+	}
+	r := &kv.ArchiveRange{
+		StartSlot: cliCtx.Uint64(flags.ArchiveStartSlotFlag.Name),
+		EndSlot:   cliCtx.Uint64(flags.ArchiveEndSlotFlag.Name),
+	}
+	if r.EndSlot != 0 && r.EndSlot < r.StartSlot {
+		return errors.New("end slot is lower than start slot")
+	}
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	if _, err := os.Stat(dbPath); err != nil {
+		return errors.Wrap(err, "could not find database")
+	}
+	store, err := openStore(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not create archive file")
+	}
+	if err := store.Export(context.Background(), f, r); err != nil {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close archive file")
+		}
+		if err := os.Remove(archivePath); err != nil {
+			log.WithError(err).Error("Could not remove incomplete archive file")
+		}
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	log.WithField("archive", archivePath).Info("Exported database")
+	return nil
+}
+
+// Import verifies the archive file given by the --archive flag and loads it into the empty
+// database of the data directory.
+func Import(cliCtx *cli.Context) error {
+	archivePath := cliCtx.String(flags.ArchivePathFlag.Name)
+	if archivePath == "" {
+		return errors.New("no archive path specified")
+	}
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Wrap(err, "could not open archive file")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close archive file")
+		}
+	}()
+	if err := kv.VerifyArchive(f); err != nil {
+		return errors.Wrap(err, "invalid archive")
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+
+	store, err := openStore(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	if err := store.Import(context.Background(), f); err != nil {
+		return err
+	}
+	log.WithField("archive", archivePath).Info("Imported database")
+	return nil
+}
+
+func openStore(cliCtx *cli.Context) (*kv.Store, error) {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	return kv.NewKVStore(dbPath, cache.NewStateSummaryCache())
+}
@@ -0,0 +1,66 @@
+// Package commands defines the offline beacon-chain subcommands operating on the beacon node database.
+package commands
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var log = logrus.WithField("prefix", "db")
+
+// DatabaseCommands for managing the beacon node database while the node is not running.
+var DatabaseCommands = &cli.Command{
+	Name:     "db",
+	Category: "db",
+	Usage:    "defines commands for managing the beacon node database offline",
+	Subcommands: []*cli.Command{
+		{
+			Name: "export",
+			Usage: "exports the blocks, state summaries, saved states, checkpoints and deposit data " +
+				"of the database into a portable archive",
+			Flags: []cli.Flag{
+				cmd.DataDirFlag,
+				flags.ArchivePathFlag,
+				flags.ArchiveStartSlotFlag,
+				flags.ArchiveEndSlotFlag,
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := Export(cliCtx); err != nil {
+					log.Fatalf("Could not export database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "imports a database archive into an empty data directory",
+			Flags: []cli.Flag{
+				cmd.DataDirFlag,
+				flags.ArchivePathFlag,
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := Import(cliCtx); err != nil {
+					log.Fatalf("Could not import database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "verify",
+			Usage: "verifies the integrity of the database, printing a report of the broken invariants " +
+				"and optionally repairing the indices",
+			Flags: []cli.Flag{
+				cmd.DataDirFlag,
+				flags.RepairDBFlag,
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := Verify(cliCtx); err != nil {
+					log.Fatalf("Could not verify database: %v", err)
+				}
+				return nil
+			},
+		},
+	},
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Verify checks the integrity of the database of the data directory and prints the report as JSON.
+// The database is only written to when the --repair flag is set.
+func Verify(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	repair := cliCtx.Bool(flags.RepairDBFlag.Name)
+	report, err := kv.VerifyIntegrity(context.Background(), dbPath, repair)
+	if err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, string(enc))
+
+	repaired := 0
+	for _, issue := range report.Issues {
+		if issue.Repaired {
+			repaired++
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":         report.Blocks,
+		"stateSummaries": report.StateSummaries,
+		"archivedPoints": report.ArchivedPoints,
+		"stateDiffs":     report.StateDiffs,
+		"issues":         len(report.Issues),
+		"repaired":       repaired,
+	}).Info("Verified database")
+	if !report.OK() {
+		return errors.New("database has integrity issues")
+	}
+	return nil
+}
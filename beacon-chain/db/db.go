@@ -3,11 +3,20 @@
 package db
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/memory"
 )
 
 // NewDB initializes a new DB.
 func NewDB(dirPath string, stateSummaryCache *cache.StateSummaryCache) (Database, error) {
 	return kv.NewKVStore(dirPath, stateSummaryCache)
 }
+
+// NewInMemoryDB initializes a new in-memory DB, which is snapshotted to the directory path at
+// the given interval when it is non-zero.
+func NewInMemoryDB(dirPath string, snapshotInterval time.Duration, stateSummaryCache *cache.StateSummaryCache) (Database, error) {
+	return memory.NewStore(dirPath, snapshotInterval, stateSummaryCache)
+}
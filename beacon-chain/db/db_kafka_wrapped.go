@@ -3,9 +3,12 @@
 package db
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kafka"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/memory"
 )
 
 // NewDB initializes a new DB with kafka wrapper.
@@ -17,3 +20,14 @@ func NewDB(dirPath string, stateSummaryCache *cache.StateSummaryCache) (Database
 
 	return kafka.Wrap(db)
 }
+
+// NewInMemoryDB initializes a new in-memory DB with kafka wrapper, which is snapshotted to the
+// directory path at the given interval when it is non-zero.
+func NewInMemoryDB(dirPath string, snapshotInterval time.Duration, stateSummaryCache *cache.StateSummaryCache) (Database, error) {
+	db, err := memory.NewStore(dirPath, snapshotInterval, stateSummaryCache)
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.Wrap(db)
+}
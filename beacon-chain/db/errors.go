@@ -0,0 +1,9 @@
+package db
+
+import "github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+
+// ErrNotFoundOriginBlockRoot wraps the kv error for nodes which were not started from a checkpoint.
+var ErrNotFoundOriginBlockRoot = kv.ErrNotFoundOriginBlockRoot
+
+// ErrNotFoundBackfillBlockRoot wraps the kv error for nodes which have not backfilled any blocks.
+var ErrNotFoundBackfillBlockRoot = kv.ErrNotFoundBackfillBlockRoot
@@ -132,6 +132,26 @@ func (e Exporter) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte)
 	return e.db.SaveGenesisBlockRoot(ctx, blockRoot)
 }
 
+// OriginBlockRoot -- passthrough.
+func (e Exporter) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.OriginBlockRoot(ctx)
+}
+
+// SaveOriginBlockRoot -- passthrough.
+func (e Exporter) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveOriginBlockRoot(ctx, blockRoot)
+}
+
+// BackfillBlockRoot -- passthrough.
+func (e Exporter) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.BackfillBlockRoot(ctx)
+}
+
+// SaveBackfillBlockRoot -- passthrough.
+func (e Exporter) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveBackfillBlockRoot(ctx, blockRoot)
+}
+
 // SaveState -- passthrough.
 func (e Exporter) SaveState(ctx context.Context, state *state.BeaconState, blockRoot [32]byte) error {
 	return e.db.SaveState(ctx, state, blockRoot)
@@ -192,6 +212,41 @@ func (e Exporter) DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	return e.db.DeleteStates(ctx, blockRoots)
 }
 
+// DeleteBlocks -- passthrough.
+func (e Exporter) DeleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
+	return e.db.DeleteBlocks(ctx, blockRoots)
+}
+
+// DeleteStateSummaries -- passthrough.
+func (e Exporter) DeleteStateSummaries(ctx context.Context, blockRoots [][32]byte) error {
+	return e.db.DeleteStateSummaries(ctx, blockRoots)
+}
+
+// StateDiff -- passthrough.
+func (e Exporter) StateDiff(ctx context.Context, blockRoot [32]byte) ([]byte, error) {
+	return e.db.StateDiff(ctx, blockRoot)
+}
+
+// HasStateDiff -- passthrough.
+func (e Exporter) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	return e.db.HasStateDiff(ctx, blockRoot)
+}
+
+// HighestSlotStateDiffsBelow -- passthrough.
+func (e Exporter) HighestSlotStateDiffsBelow(ctx context.Context, slot uint64) ([][]byte, error) {
+	return e.db.HighestSlotStateDiffsBelow(ctx, slot)
+}
+
+// SaveStateDiff -- passthrough.
+func (e Exporter) SaveStateDiff(ctx context.Context, blockRoot [32]byte, slot, baseSlot uint64, diff []byte) error {
+	return e.db.SaveStateDiff(ctx, blockRoot, slot, baseSlot, diff)
+}
+
+// DeleteStateDiffs -- passthrough.
+func (e Exporter) DeleteStateDiffs(ctx context.Context, blockRoots [][32]byte) error {
+	return e.db.DeleteStateDiffs(ctx, blockRoots)
+}
+
 // HasState -- passthrough.
 func (e Exporter) HasState(ctx context.Context, blockRoot [32]byte) bool {
 	return e.db.HasState(ctx, blockRoot)
@@ -217,6 +272,16 @@ func (e Exporter) SavePowchainData(ctx context.Context, data *db.ETH1ChainData)
 	return e.db.SavePowchainData(ctx, data)
 }
 
+// ForkChoiceStore -- passthrough
+func (e Exporter) ForkChoiceStore(ctx context.Context) ([]byte, error) {
+	return e.db.ForkChoiceStore(ctx)
+}
+
+// SaveForkChoiceStore -- passthrough
+func (e Exporter) SaveForkChoiceStore(ctx context.Context, enc []byte) error {
+	return e.db.SaveForkChoiceStore(ctx, enc)
+}
+
 // ArchivedPointRoot -- passthrough
 func (e Exporter) ArchivedPointRoot(ctx context.Context, index uint64) [32]byte {
 	return e.db.ArchivedPointRoot(ctx, index)
@@ -0,0 +1,557 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// The database archive is a portable stream of the beacon chain data in the db. It begins with
+// the archive magic and a little endian uint32 version, followed by sections in a fixed order.
+// Every section begins with its identifier byte, followed by records which are each a 0x01 marker,
+// a little endian uint32 length and the payload. A section ends with a 0x00 marker, the little endian
+// uint64 number of records and the sha256 checksum of all the section bytes written before it.
+// Blocks and states are SSZ encoded, other objects which do not define SSZ use protobuf.
+// Version 2 added the state diffs section, archives of version 1 are still read without it.
+const (
+	// ArchiveVersion is the version of the database archive format written by Export.
+	ArchiveVersion = uint32(2)
+	// minArchiveVersion is the oldest version of the database archive format which can be read.
+	minArchiveVersion = uint32(1)
+	// maxArchiveRecordSize caps the size of a single archived object to guard against corrupted archives.
+	maxArchiveRecordSize = 1 << 30
+	// importBatchSize is the number of blocks or summaries saved to the db in a single transaction.
+	importBatchSize = 1000
+)
+
+var archiveMagic = []byte("prysmdb\x00")
+
+const (
+	blocksSection byte = iota + 1
+	stateSummariesSection
+	statesSection
+	chainMetadataSection
+	depositDataSection
+	stateDiffsSection
+)
+
+var archiveSections = []byte{
+	blocksSection,
+	stateSummariesSection,
+	statesSection,
+	stateDiffsSection,
+	chainMetadataSection,
+	depositDataSection,
+}
+
+// This returns true if the section is part of archives of the given version.
+func sectionInVersion(id byte, version uint32) bool {
+	return id != stateDiffsSection || version >= 2
+}
+
+var (
+	// ErrArchiveChecksumMismatch is returned when an archive section does not match its checksum.
+	ErrArchiveChecksumMismatch = errors.New("archive section checksum mismatch")
+	// ErrUnsupportedArchiveVersion is returned when importing an archive written in an unknown format.
+	ErrUnsupportedArchiveVersion = errors.New("unsupported archive version")
+	// ErrImportIntoNonEmptyDB is returned when importing an archive into a db which already has blocks.
+	ErrImportIntoNonEmptyDB = errors.New("cannot import archive into a non-empty database")
+)
+
+// ArchiveRange restricts an export to the objects within a range of slots, both ends included.
+// An end slot of zero means the range is unbounded.
+type ArchiveRange struct {
+	StartSlot uint64
+	EndSlot   uint64
+}
+
+func (r *ArchiveRange) contains(slot uint64) bool {
+	if r == nil {
+		return true
+	}
+	return slot >= r.StartSlot && (r.EndSlot == 0 || slot <= r.EndSlot)
+}
+
+// Export writes the blocks, state summaries, saved states and state diffs, checkpoints and deposit data of the db
+// to w as a versioned archive, which can be imported into an empty database with Import.
+func (kv *Store) Export(ctx context.Context, w io.Writer, r *ArchiveRange) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Export")
+	defer span.End()
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(archiveMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, ArchiveVersion); err != nil {
+		return err
+	}
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		for _, id := range archiveSections {
+			sw := newSectionWriter(bw, id)
+			if err := sw.begin(); err != nil {
+				return err
+			}
+			if err := exportSection(ctx, tx, sw, id, r); err != nil {
+				return errors.Wrapf(err, "could not export section %d", id)
+			}
+			if err := sw.end(); err != nil {
+				return err
+			}
+			logrus.WithField("prefix", "db").WithFields(logrus.Fields{
+				"section": id,
+				"records": sw.count,
+			}).Debug("Exported archive section")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func exportSection(ctx context.Context, tx *bolt.Tx, sw *sectionWriter, id byte, r *ArchiveRange) error {
+	switch id {
+	case blocksSection:
+		return tx.Bucket(blocksBucket).ForEach(func(k, v []byte) error {
+			// The blocks bucket also holds the chain metadata keys.
+			if len(k) != 32 {
+				return nil
+			}
+			blk := &ethpb.SignedBeaconBlock{}
+			if err := decode(ctx, v, blk); err != nil {
+				return err
+			}
+			if !r.contains(blk.Block.Slot) {
+				return nil
+			}
+			enc, err := blk.MarshalSSZ()
+			if err != nil {
+				return err
+			}
+			return sw.record(enc)
+		})
+	case stateSummariesSection:
+		return tx.Bucket(stateSummaryBucket).ForEach(func(k, v []byte) error {
+			summary := &pb.StateSummary{}
+			if err := decode(ctx, v, summary); err != nil {
+				return err
+			}
+			if !r.contains(summary.Slot) {
+				return nil
+			}
+			enc, err := proto.Marshal(summary)
+			if err != nil {
+				return err
+			}
+			return sw.record(enc)
+		})
+	case statesSection:
+		return tx.Bucket(stateBucket).ForEach(func(k, v []byte) error {
+			st, err := createState(ctx, v)
+			if err != nil {
+				return err
+			}
+			if !r.contains(st.Slot) {
+				return nil
+			}
+			enc, err := st.MarshalSSZ()
+			if err != nil {
+				return err
+			}
+			return sw.record(append(bytesutil.SafeCopyBytes(k), enc...))
+		})
+	case stateDiffsSection:
+		// State diffs are archived as saved, the block root followed by the slot, the base slot and
+		// the encoded diff.
+		return tx.Bucket(stateDiffBucket).ForEach(func(k, v []byte) error {
+			if len(v) < stateDiffHeaderLength {
+				return fmt.Errorf("state diff %#x of length %d is too short", k, len(v))
+			}
+			if !r.contains(bytesutil.BytesToUint64BigEndian(v[:stateDiffSlotLength])) {
+				return nil
+			}
+			return sw.record(append(bytesutil.SafeCopyBytes(k), v...))
+		})
+	case chainMetadataSection:
+		blocks := tx.Bucket(blocksBucket)
+		for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey, backfillBlockRootKey, headBlockRootKey} {
+			if err := sw.keyValue(key, blocks.Get(key)); err != nil {
+				return err
+			}
+		}
+		checkpoints := tx.Bucket(checkpointBucket)
+		for _, key := range [][]byte{justifiedCheckpointKey, finalizedCheckpointKey} {
+			enc := checkpoints.Get(key)
+			if enc == nil {
+				continue
+			}
+			cp := &ethpb.Checkpoint{}
+			if err := decode(ctx, enc, cp); err != nil {
+				return err
+			}
+			sszCp, err := cp.MarshalSSZ()
+			if err != nil {
+				return err
+			}
+			if err := sw.keyValue(key, sszCp); err != nil {
+				return err
+			}
+		}
+		return nil
+	case depositDataSection:
+		if err := sw.keyValue(depositContractAddressKey, tx.Bucket(chainMetadataBucket).Get(depositContractAddressKey)); err != nil {
+			return err
+		}
+		return sw.keyValue(powchainDataKey, tx.Bucket(powchainBucket).Get(powchainDataKey))
+	default:
+		return fmt.Errorf("unknown archive section %d", id)
+	}
+}
+
+// Import reads an archive written by Export and saves its content to the db. The db must not
+// contain any blocks. Checkpoints whose state was not part of the archive are skipped. As records
+// are saved while the archive is streamed, callers should check the archive with VerifyArchive first.
+func (kv *Store) Import(ctx context.Context, r io.Reader) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Import")
+	defer span.End()
+
+	empty := true
+	if err := kv.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(blockSlotIndicesBucket).Cursor().First()
+		empty = k == nil && tx.Bucket(blocksBucket).Get(headBlockRootKey) == nil
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !empty {
+		return ErrImportIntoNonEmptyDB
+	}
+
+	return ImportArchive(ctx, r, kv)
+}
+
+// ImportArchive reads an archive written by Export and saves its content to any database backend.
+// Unlike Import, it does not check that the target database is empty.
+func ImportArchive(ctx context.Context, r io.Reader, db iface.HeadAccessDatabase) error {
+	return readArchive(r, func(sr *sectionReader, id byte) error {
+		return importSection(ctx, sr, id, db)
+	})
+}
+
+// VerifyArchive reads a whole archive and checks its format, record counts and section checksums
+// without decoding the archived objects.
+func VerifyArchive(r io.Reader) error {
+	return readArchive(r, func(sr *sectionReader, _ byte) error {
+		return sr.forEach(func([]byte) error {
+			return nil
+		})
+	})
+}
+
+func readArchive(r io.Reader, readSection func(sr *sectionReader, id byte) error) error {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return errors.Wrap(err, "could not read archive header")
+	}
+	if !bytes.Equal(magic, archiveMagic) {
+		return errors.New("not a database archive")
+	}
+	var version uint32
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return errors.Wrap(err, "could not read archive version")
+	}
+	if version < minArchiveVersion || version > ArchiveVersion {
+		return errors.Wrapf(ErrUnsupportedArchiveVersion, "version %d", version)
+	}
+	for _, id := range archiveSections {
+		if !sectionInVersion(id, version) {
+			continue
+		}
+		sr := newSectionReader(br)
+		if err := sr.begin(id); err != nil {
+			return err
+		}
+		if err := readSection(sr, id); err != nil {
+			return errors.Wrapf(err, "could not read section %d", id)
+		}
+	}
+	return nil
+}
+
+func importSection(ctx context.Context, sr *sectionReader, id byte, db iface.HeadAccessDatabase) error {
+	switch id {
+	case blocksSection:
+		batch := make([]*ethpb.SignedBeaconBlock, 0, importBatchSize)
+		err := sr.forEach(func(enc []byte) error {
+			blk := &ethpb.SignedBeaconBlock{}
+			if err := blk.UnmarshalSSZ(enc); err != nil {
+				return err
+			}
+			batch = append(batch, blk)
+			if len(batch) == importBatchSize {
+				if err := db.SaveBlocks(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return db.SaveBlocks(ctx, batch)
+	case stateSummariesSection:
+		batch := make([]*pb.StateSummary, 0, importBatchSize)
+		err := sr.forEach(func(enc []byte) error {
+			summary := &pb.StateSummary{}
+			if err := proto.Unmarshal(enc, summary); err != nil {
+				return err
+			}
+			batch = append(batch, summary)
+			if len(batch) == importBatchSize {
+				if err := db.SaveStateSummaries(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return db.SaveStateSummaries(ctx, batch)
+	case statesSection:
+		return sr.forEach(func(enc []byte) error {
+			if len(enc) < 32 {
+				return errors.New("state record is too short")
+			}
+			pbState := &pb.BeaconState{}
+			if err := pbState.UnmarshalSSZ(enc[32:]); err != nil {
+				return err
+			}
+			st, err := state.InitializeFromProtoUnsafe(pbState)
+			if err != nil {
+				return err
+			}
+			return db.SaveState(ctx, st, bytesutil.ToBytes32(enc[:32]))
+		})
+	case stateDiffsSection:
+		return sr.forEach(func(enc []byte) error {
+			if len(enc) < 32+stateDiffHeaderLength {
+				return errors.New("state diff record is too short")
+			}
+			slot := bytesutil.BytesToUint64BigEndian(enc[32 : 32+stateDiffSlotLength])
+			baseSlot := bytesutil.BytesToUint64BigEndian(enc[32+stateDiffSlotLength : 32+stateDiffHeaderLength])
+			return db.SaveStateDiff(ctx, bytesutil.ToBytes32(enc[:32]), slot, baseSlot, enc[32+stateDiffHeaderLength:])
+		})
+	case chainMetadataSection:
+		return sr.forEachKeyValue(func(key, value []byte) error {
+			switch {
+			case bytes.Equal(key, genesisBlockRootKey):
+				return db.SaveGenesisBlockRoot(ctx, bytesutil.ToBytes32(value))
+			case bytes.Equal(key, originBlockRootKey):
+				return db.SaveOriginBlockRoot(ctx, bytesutil.ToBytes32(value))
+			case bytes.Equal(key, backfillBlockRootKey):
+				return db.SaveBackfillBlockRoot(ctx, bytesutil.ToBytes32(value))
+			case bytes.Equal(key, headBlockRootKey):
+				root := bytesutil.ToBytes32(value)
+				if !db.HasState(ctx, root) {
+					logrus.WithField("prefix", "db").Warn("Head state is not part of the archive, skipping head block root")
+					return nil
+				}
+				return db.SaveHeadBlockRoot(ctx, root)
+			case bytes.Equal(key, justifiedCheckpointKey), bytes.Equal(key, finalizedCheckpointKey):
+				cp := &ethpb.Checkpoint{}
+				if err := cp.UnmarshalSSZ(value); err != nil {
+					return err
+				}
+				root := bytesutil.ToBytes32(cp.Root)
+				if !db.HasState(ctx, root) && !db.HasStateSummary(ctx, root) {
+					logrus.WithField("prefix", "db").WithField("key", string(key)).Warn("Checkpoint state is not part of the archive, skipping checkpoint")
+					return nil
+				}
+				if bytes.Equal(key, justifiedCheckpointKey) {
+					return db.SaveJustifiedCheckpoint(ctx, cp)
+				}
+				return db.SaveFinalizedCheckpoint(ctx, cp)
+			default:
+				return fmt.Errorf("unknown chain metadata key %q", key)
+			}
+		})
+	case depositDataSection:
+		return sr.forEachKeyValue(func(key, value []byte) error {
+			switch {
+			case bytes.Equal(key, depositContractAddressKey):
+				return db.SaveDepositContractAddress(ctx, common.BytesToAddress(value))
+			case bytes.Equal(key, powchainDataKey):
+				data := &dbpb.ETH1ChainData{}
+				if err := proto.Unmarshal(value, data); err != nil {
+					return err
+				}
+				return db.SavePowchainData(ctx, data)
+			default:
+				return fmt.Errorf("unknown deposit data key %q", key)
+			}
+		})
+	default:
+		return fmt.Errorf("unknown archive section %d", id)
+	}
+}
+
+// sectionWriter writes the records of an archive section while computing its checksum.
+type sectionWriter struct {
+	w     io.Writer
+	h     hash.Hash
+	id    byte
+	count uint64
+}
+
+func newSectionWriter(w io.Writer, id byte) *sectionWriter {
+	h := sha256.New()
+	return &sectionWriter{w: io.MultiWriter(w, h), h: h, id: id}
+}
+
+func (sw *sectionWriter) begin() error {
+	_, err := sw.w.Write([]byte{sw.id})
+	return err
+}
+
+func (sw *sectionWriter) record(payload []byte) error {
+	if len(payload) > maxArchiveRecordSize {
+		return fmt.Errorf("record of size %d exceeds the maximum archive record size", len(payload))
+	}
+	header := make([]byte, 5)
+	header[0] = 1
+	binary.LittleEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := sw.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := sw.w.Write(payload); err != nil {
+		return err
+	}
+	sw.count++
+	return nil
+}
+
+// keyValue writes a record holding a one byte key length, the key and the value. Empty values are skipped.
+func (sw *sectionWriter) keyValue(key, value []byte) error {
+	if len(value) == 0 {
+		return nil
+	}
+	payload := make([]byte, 0, 1+len(key)+len(value))
+	payload = append(payload, byte(len(key)))
+	payload = append(payload, key...)
+	return sw.record(append(payload, value...))
+}
+
+func (sw *sectionWriter) end() error {
+	trailer := make([]byte, 9)
+	binary.LittleEndian.PutUint64(trailer[1:], sw.count)
+	if _, err := sw.w.Write(trailer); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(sw.h.Sum(nil))
+	return err
+}
+
+// sectionReader reads the records of an archive section and verifies its checksum.
+type sectionReader struct {
+	raw io.Reader
+	r   io.Reader
+	h   hash.Hash
+}
+
+func newSectionReader(r io.Reader) *sectionReader {
+	h := sha256.New()
+	return &sectionReader{raw: r, r: io.TeeReader(r, h), h: h}
+}
+
+func (sr *sectionReader) begin(id byte) error {
+	b := make([]byte, 1)
+	if _, err := io.ReadFull(sr.r, b); err != nil {
+		return errors.Wrap(err, "could not read section header")
+	}
+	if b[0] != id {
+		return fmt.Errorf("expected archive section %d, got %d", id, b[0])
+	}
+	return nil
+}
+
+// forEach calls f with the payload of every record in the section, then verifies the section
+// count and checksum.
+func (sr *sectionReader) forEach(f func(payload []byte) error) error {
+	var count uint64
+	header := make([]byte, 5)
+	for {
+		if _, err := io.ReadFull(sr.r, header[:1]); err != nil {
+			return errors.Wrap(err, "could not read record marker")
+		}
+		if header[0] == 0 {
+			break
+		}
+		if header[0] != 1 {
+			return fmt.Errorf("invalid record marker %d", header[0])
+		}
+		if _, err := io.ReadFull(sr.r, header[1:]); err != nil {
+			return errors.Wrap(err, "could not read record length")
+		}
+		size := binary.LittleEndian.Uint32(header[1:])
+		if size > maxArchiveRecordSize {
+			return fmt.Errorf("record of size %d exceeds the maximum archive record size", size)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(sr.r, payload); err != nil {
+			return errors.Wrap(err, "could not read record")
+		}
+		if err := f(payload); err != nil {
+			return err
+		}
+		count++
+	}
+	var expectedCount uint64
+	if err := binary.Read(sr.r, binary.LittleEndian, &expectedCount); err != nil {
+		return errors.Wrap(err, "could not read section record count")
+	}
+	if count != expectedCount {
+		return fmt.Errorf("section has %d records, expected %d", count, expectedCount)
+	}
+	sum := sr.h.Sum(nil)
+	checksum := make([]byte, len(sum))
+	if _, err := io.ReadFull(sr.raw, checksum); err != nil {
+		return errors.Wrap(err, "could not read section checksum")
+	}
+	if !bytes.Equal(sum, checksum) {
+		return ErrArchiveChecksumMismatch
+	}
+	return nil
+}
+
+func (sr *sectionReader) forEachKeyValue(f func(key, value []byte) error) error {
+	return sr.forEach(func(payload []byte) error {
+		if len(payload) == 0 || len(payload) < 1+int(payload[0]) {
+			return errors.New("key value record is too short")
+		}
+		keyLen := int(payload[0])
+		return f(payload[1:1+keyLen], payload[1+keyLen:])
+	})
+}
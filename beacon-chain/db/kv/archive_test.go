@@ -0,0 +1,135 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// This fills the db with 10 blocks, their state summaries, a state at slot 8, a state diff at slot
+// 10 and chain metadata.
+func populateArchiveDB(t *testing.T, db *Store) [][32]byte {
+	ctx := context.Background()
+	blks := makeBlocks(t, 0, 10, [32]byte{})
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		r, err := stateutil.BlockRoot(blk.Block)
+		require.NoError(t, err)
+		roots[i] = r
+		require.NoError(t, db.SaveStateSummary(ctx, &pb.StateSummary{Slot: blk.Block.Slot, Root: r[:]}))
+	}
+	st := testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(8))
+	require.NoError(t, db.SaveState(ctx, st, roots[7]))
+	require.NoError(t, db.SaveStateDiff(ctx, roots[9], 10, 8, []byte("diff 10")))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, roots[7]))
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[7][:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[7][:]}))
+	require.NoError(t, db.SaveDepositContractAddress(ctx, common.Address{'A'}))
+	require.NoError(t, db.SavePowchainData(ctx, &dbpb.ETH1ChainData{
+		CurrentEth1Data: &dbpb.LatestETH1Data{BlockHeight: 100},
+	}))
+	return roots
+}
+
+func TestStore_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	roots := populateArchiveDB(t, db)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, db.Export(ctx, buf, nil))
+	require.NoError(t, VerifyArchive(bytes.NewReader(buf.Bytes())))
+
+	imported := setupDB(t)
+	require.NoError(t, imported.Import(ctx, buf))
+	for _, r := range roots {
+		assert.Equal(t, true, imported.HasBlock(ctx, r))
+		assert.Equal(t, true, imported.HasStateSummary(ctx, r))
+	}
+	blks, err := imported.Blocks(ctx, filters.NewFilter().SetStartSlot(1).SetEndSlot(10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, len(blks))
+	st, err := imported.HeadState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), st.Slot())
+	diff, err := imported.StateDiff(ctx, roots[9])
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("diff 10"), diff)
+	genesisBlk, err := imported.GenesisBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), genesisBlk.Block.Slot)
+	cp, err := imported.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[7][:], cp.Root)
+	assert.Equal(t, true, imported.IsFinalizedBlock(ctx, roots[7]))
+	addr, err := imported.DepositContractAddress(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, common.Address{'A'}.Bytes(), addr)
+	data, err := imported.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), data.CurrentEth1Data.BlockHeight)
+
+	assert.ErrorContains(t, ErrImportIntoNonEmptyDB.Error(), imported.Import(ctx, bytes.NewReader(buf.Bytes())))
+}
+
+func TestStore_Export_SlotRange(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	roots := populateArchiveDB(t, db)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, db.Export(ctx, buf, &ArchiveRange{StartSlot: 3, EndSlot: 6}))
+
+	imported := setupDB(t)
+	require.NoError(t, imported.Import(ctx, buf))
+	for i, r := range roots {
+		// Blocks are at slots 1 to 10.
+		inRange := i >= 2 && i <= 5
+		assert.Equal(t, inRange, imported.HasBlock(ctx, r))
+		assert.Equal(t, inRange, imported.HasStateSummary(ctx, r))
+	}
+	// The head and checkpoint states are outside of the range.
+	assert.Equal(t, false, imported.HasState(ctx, roots[7]))
+	assert.Equal(t, false, imported.HasStateDiff(ctx, roots[9]))
+	cp, err := imported.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), cp.Epoch)
+}
+
+func TestVerifyArchive_Corrupted(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	populateArchiveDB(t, db)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, db.Export(ctx, buf, nil))
+	enc := buf.Bytes()
+
+	corrupted := make([]byte, len(enc))
+	copy(corrupted, enc)
+	// Flip a byte of the first block record, right after the header and the section identifier.
+	corrupted[len(archiveMagic)+4+1+5] ^= 0xff
+	err := VerifyArchive(bytes.NewReader(corrupted))
+	assert.Equal(t, true, errors.Is(err, ErrArchiveChecksumMismatch))
+
+	copy(corrupted, enc)
+	corrupted[len(archiveMagic)] = byte(ArchiveVersion + 1)
+	err = VerifyArchive(bytes.NewReader(corrupted))
+	assert.Equal(t, true, errors.Is(err, ErrUnsupportedArchiveVersion))
+
+	assert.ErrorContains(t, "could not read", VerifyArchive(bytes.NewReader(enc[:len(enc)-10])))
+}
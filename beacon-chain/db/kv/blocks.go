@@ -148,17 +148,31 @@ func (kv *Store) deleteBlock(ctx context.Context, blockRoot [32]byte) error {
 	})
 }
 
-// deleteBlocks by block roots.
-func (kv *Store) deleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
-	ctx, span := trace.StartSpan(ctx, "BeaconDB.deleteBlocks")
+// DeleteBlocks by block roots. Roots of blocks which are not in the db are ignored. The genesis,
+// finalized and head blocks cannot be deleted.
+func (kv *Store) DeleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteBlocks")
 	defer span.End()
 
 	return kv.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
+		genesisBlockRoot := bkt.Get(genesisBlockRootKey)
+		headBlkRoot := bkt.Get(headBlockRootKey)
+		checkpoint := &ethpb.Checkpoint{Root: genesisBlockRoot}
+		if enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+			if err := decode(ctx, enc, checkpoint); err != nil {
+				return err
+			}
+		}
+
 		for _, blockRoot := range blockRoots {
 			enc := bkt.Get(blockRoot[:])
 			if enc == nil {
-				return nil
+				continue
+			}
+			// Safe guard against deleting genesis, finalized, head block.
+			if bytes.Equal(blockRoot[:], checkpoint.Root) || bytes.Equal(blockRoot[:], genesisBlockRoot) || bytes.Equal(blockRoot[:], headBlkRoot) {
+				return errors.New("cannot delete genesis, finalized, or head block")
 			}
 			block := &ethpb.SignedBeaconBlock{}
 			if err := decode(ctx, enc, block); err != nil {
@@ -271,6 +285,69 @@ func (kv *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) e
 	})
 }
 
+// OriginBlockRoot returns the block root of the trusted checkpoint block the node was started from.
+// ErrNotFoundOriginBlockRoot is returned if the node was started from genesis.
+func (kv *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.OriginBlockRoot")
+	defer span.End()
+	var root [32]byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		enc := bkt.Get(originBlockRootKey)
+		if enc == nil {
+			return ErrNotFoundOriginBlockRoot
+		}
+		if len(enc) != 32 {
+			return fmt.Errorf("origin block root has length %d, wanted 32", len(enc))
+		}
+		copy(root[:], enc)
+		return nil
+	})
+	return root, err
+}
+
+// SaveOriginBlockRoot to the db. This marks the block which a checkpoint synced node started from.
+func (kv *Store) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOriginBlockRoot")
+	defer span.End()
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(originBlockRootKey, blockRoot[:])
+	})
+}
+
+// BackfillBlockRoot returns the root of the lowest block saved by the backfill service, which is
+// where backfilling resumes from on restart.
+// ErrNotFoundBackfillBlockRoot is returned if no block has been backfilled yet.
+func (kv *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
+	defer span.End()
+	var root [32]byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		enc := bkt.Get(backfillBlockRootKey)
+		if enc == nil {
+			return ErrNotFoundBackfillBlockRoot
+		}
+		if len(enc) != 32 {
+			return fmt.Errorf("backfill block root has length %d, wanted 32", len(enc))
+		}
+		copy(root[:], enc)
+		return nil
+	})
+	return root, err
+}
+
+// SaveBackfillBlockRoot to the db.
+func (kv *Store) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlockRoot")
+	defer span.End()
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(backfillBlockRootKey, blockRoot[:])
+	})
+}
+
 // HighestSlotBlocksBelow returns the block with the highest slot below the input slot from the db.
 func (kv *Store) HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*ethpb.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotBlocksBelow")
@@ -96,7 +96,7 @@ func TestStore_BlocksBatchDelete(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, numBlocks, len(retrieved), "Unexpected number of blocks received")
 	// We delete all even indexed blocks.
-	require.NoError(t, db.deleteBlocks(ctx, blockRoots))
+	require.NoError(t, db.DeleteBlocks(ctx, blockRoots))
 	// When we retrieve the data, only the odd indexed blocks should remain.
 	retrieved, err = db.Blocks(ctx, filters.NewFilter().SetParentRoot(bytesutil.PadTo([]byte("parent"), 32)))
 	require.NoError(t, err)
@@ -122,6 +122,35 @@ func TestStore_GenesisBlock(t *testing.T) {
 	assert.Equal(t, true, proto.Equal(genesisBlock, retrievedBlock), "Wanted: %v, received: %v", genesisBlock, retrievedBlock)
 }
 
+func TestStore_OriginBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.OriginBlockRoot(ctx)
+	assert.Equal(t, ErrNotFoundOriginBlockRoot, err)
+
+	originBlock := testutil.NewBeaconBlock()
+	originBlock.Block.Slot = 64
+	blockRoot, err := stateutil.BlockRoot(originBlock.Block)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, blockRoot))
+	retrievedRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, retrievedRoot)
+}
+
+func TestStore_BackfillBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.BackfillBlockRoot(ctx)
+	assert.Equal(t, ErrNotFoundBackfillBlockRoot, err)
+
+	blockRoot := bytesutil.ToBytes32([]byte{'b', 'a', 'c', 'k'})
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, blockRoot))
+	retrievedRoot, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, retrievedRoot)
+}
+
 func TestStore_BlocksCRUD_NoCache(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
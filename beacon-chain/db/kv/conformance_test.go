@@ -0,0 +1,15 @@
+package kv_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+)
+
+func TestStore_DatabaseTests(t *testing.T) {
+	dbtest.RunDatabaseTests(t, func(t *testing.T) db.Database {
+		d, _ := dbtest.SetupDB(t)
+		return d
+	})
+}
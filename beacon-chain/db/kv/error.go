@@ -0,0 +1,10 @@
+package kv
+
+import "github.com/pkg/errors"
+
+// ErrNotFoundOriginBlockRoot is returned when the node was not started from a checkpoint
+// and therefore has no origin block root saved in the database.
+var ErrNotFoundOriginBlockRoot = errors.New("origin block root not found in db")
+
+// ErrNotFoundBackfillBlockRoot is returned when no historical blocks have been backfilled yet.
+var ErrNotFoundBackfillBlockRoot = errors.New("backfill block root not found in db")
@@ -51,6 +51,35 @@ func TestStore_IsFinalizedBlock(t *testing.T) {
 	}
 }
 
+func TestStore_IsFinalizedBlock_FromOrigin(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	db := setupDB(t)
+	ctx := context.Background()
+
+	// The parent of the first block is never saved, as is the case for a checkpoint synced node.
+	blks := makeBlocks(t, slotsPerEpoch*2, slotsPerEpoch*3, bytesutil.ToBytes32([]byte{'P', 'A', 'R', 'E', 'N', 'T'}))
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+
+	originRoot, err := stateutil.BlockRoot(blks[0].Block)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, originRoot))
+
+	root, err := stateutil.BlockRoot(blks[slotsPerEpoch].Block)
+	require.NoError(t, err)
+	cp := &ethpb.Checkpoint{
+		Epoch: 3,
+		Root:  root[:],
+	}
+	require.NoError(t, db.SaveState(ctx, testutil.NewBeaconState(), root))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, cp))
+
+	for i := uint64(0); i <= slotsPerEpoch; i++ {
+		root, err := stateutil.BlockRoot(blks[i].Block)
+		require.NoError(t, err)
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+	}
+}
+
 func TestStore_IsFinalizedBlockGenesis(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
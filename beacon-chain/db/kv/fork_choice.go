@@ -0,0 +1,32 @@
+package kv
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// ForkChoiceStore returns the last saved encoding of the fork choice store, or nil if there is none.
+func (kv *Store) ForkChoiceStore(ctx context.Context) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceStore")
+	defer span.End()
+	var enc []byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(chainMetadataBucket).Get(forkChoiceStoreKey); v != nil {
+			enc = make([]byte, len(v))
+			copy(enc, v)
+		}
+		return nil
+	})
+	return enc, err
+}
+
+// SaveForkChoiceStore saves the encoding of the fork choice store, replacing the previous one.
+func (kv *Store) SaveForkChoiceStore(ctx context.Context, enc []byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceStore")
+	defer span.End()
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkChoiceStoreKey, enc)
+	})
+}
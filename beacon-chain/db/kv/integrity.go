@@ -0,0 +1,378 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Integrity checks reported by VerifyIntegrity.
+const (
+	MissingParentCheck      = "missing-parent"
+	BlockSlotIndexCheck     = "block-slot-index"
+	ParentRootIndexCheck    = "parent-root-index"
+	StateSummaryBlockCheck  = "state-summary-block"
+	ArchivedPointStateCheck = "archived-point-state"
+	StateDiffBaseCheck      = "state-diff-base"
+	ChainMetadataCheck      = "chain-metadata"
+)
+
+// IntegrityIssue describes a single broken invariant of the database.
+type IntegrityIssue struct {
+	Check    string `json:"check"`
+	Key      string `json:"key"`
+	Detail   string `json:"detail"`
+	Repaired bool   `json:"repaired"`
+}
+
+// IntegrityReport is the result of a database integrity verification.
+type IntegrityReport struct {
+	Blocks         int               `json:"blocks"`
+	StateSummaries int               `json:"state_summaries"`
+	ArchivedPoints int               `json:"archived_points"`
+	StateDiffs     int               `json:"state_diffs"`
+	Issues         []*IntegrityIssue `json:"issues"`
+}
+
+// OK returns true if the verification found no issue which was left unrepaired.
+func (r *IntegrityReport) OK() bool {
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *IntegrityReport) addIssue(check string, key []byte, repaired bool, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, &IntegrityIssue{
+		Check:    check,
+		Key:      fmt.Sprintf("%#x", key),
+		Detail:   fmt.Sprintf(format, args...),
+		Repaired: repaired,
+	})
+}
+
+// VerifyIntegrity opens the database in dirPath and verifies that blocks link to their parents,
+// that the block indices match the saved blocks, that state summaries and archived points refer
+// to saved blocks and states, that the base state of every state diff is saved, and that the head, justified and finalized roots are saved. The
+// database is opened read-only, unless repair is set, in which case the block slot, parent root
+// and archived point indices are rewritten to match the saved objects.
+func VerifyIntegrity(ctx context.Context, dirPath string, repair bool) (*IntegrityReport, error) {
+	datafile := path.Join(dirPath, databaseFileName)
+	if _, err := os.Stat(datafile); err != nil {
+		return nil, errors.Wrap(err, "could not find database")
+	}
+	boltDB, err := bolt.Open(datafile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout:  params.BeaconIoConfig().BoltTimeout,
+		ReadOnly: !repair,
+	})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	report, err := verifyIntegrity(ctx, boltDB, repair)
+	if closeErr := boltDB.Close(); closeErr != nil && err == nil {
+		return nil, closeErr
+	}
+	return report, err
+}
+
+func verifyIntegrity(ctx context.Context, db *bolt.DB, repair bool) (*IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.verifyIntegrity")
+	defer span.End()
+
+	report := &IntegrityReport{}
+	check := func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{
+			blocksBucket, stateBucket, stateSummaryBucket, checkpointBucket,
+			blockSlotIndicesBucket, blockParentRootIndicesBucket, stateSlotIndicesBucket,
+		} {
+			if tx.Bucket(name) == nil {
+				return fmt.Errorf("missing bucket %s", name)
+			}
+		}
+		blks, err := blocksByRoot(ctx, tx)
+		if err != nil {
+			return err
+		}
+		report.Blocks = len(blks)
+		if err := verifyChainMetadata(ctx, tx, blks, report); err != nil {
+			return err
+		}
+		if err := verifyParents(ctx, tx, blks, report); err != nil {
+			return err
+		}
+		slotIndex := make(map[string][][]byte)
+		parentIndex := make(map[string][][]byte)
+		for root, blk := range blks {
+			slotKey := string(bytesutil.Uint64ToBytesBigEndian(blk.Slot))
+			slotIndex[slotKey] = append(slotIndex[slotKey], []byte(root))
+			if len(blk.ParentRoot) > 0 {
+				parentIndex[string(blk.ParentRoot)] = append(parentIndex[string(blk.ParentRoot)], []byte(root))
+			}
+		}
+		if err := verifyIndex(tx.Bucket(blockSlotIndicesBucket), BlockSlotIndexCheck, slotIndex, repair, report); err != nil {
+			return err
+		}
+		if err := verifyIndex(tx.Bucket(blockParentRootIndicesBucket), ParentRootIndexCheck, parentIndex, repair, report); err != nil {
+			return err
+		}
+		if err := verifyStateSummaries(ctx, tx, blks, report); err != nil {
+			return err
+		}
+		if err := verifyArchivedPoints(tx, repair, report); err != nil {
+			return err
+		}
+		return verifyStateDiffBases(tx, report)
+	}
+	var err error
+	if repair {
+		err = db.Update(check)
+	} else {
+		err = db.View(check)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// This decodes every block of the db, indexed by block root.
+func blocksByRoot(ctx context.Context, tx *bolt.Tx) (map[string]*ethpb.BeaconBlock, error) {
+	blks := make(map[string]*ethpb.BeaconBlock)
+	err := tx.Bucket(blocksBucket).ForEach(func(k, v []byte) error {
+		// The blocks bucket also holds the chain metadata keys.
+		if len(k) != 32 {
+			return nil
+		}
+		blk := &ethpb.SignedBeaconBlock{}
+		if err := decode(ctx, v, blk); err != nil {
+			return errors.Wrapf(err, "could not decode block %#x", k)
+		}
+		if blk.Block == nil {
+			return fmt.Errorf("nil block %#x", k)
+		}
+		blks[string(k)] = blk.Block
+		return nil
+	})
+	return blks, err
+}
+
+func verifyChainMetadata(ctx context.Context, tx *bolt.Tx, blks map[string]*ethpb.BeaconBlock, report *IntegrityReport) error {
+	for _, key := range [][]byte{headBlockRootKey, genesisBlockRootKey, originBlockRootKey} {
+		root := tx.Bucket(blocksBucket).Get(key)
+		if root == nil {
+			continue
+		}
+		if _, ok := blks[string(root)]; !ok {
+			report.addIssue(ChainMetadataCheck, root, false, "%s block is not saved", key)
+		}
+	}
+	for _, key := range [][]byte{justifiedCheckpointKey, finalizedCheckpointKey} {
+		enc := tx.Bucket(checkpointBucket).Get(key)
+		if enc == nil {
+			continue
+		}
+		cp := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, cp); err != nil {
+			return errors.Wrapf(err, "could not decode %s", key)
+		}
+		// A zero root refers to the genesis block.
+		if bytes.Equal(cp.Root, params.BeaconConfig().ZeroHash[:]) {
+			continue
+		}
+		if _, ok := blks[string(cp.Root)]; !ok {
+			report.addIssue(ChainMetadataCheck, cp.Root, false, "%s block at epoch %d is not saved", key, cp.Epoch)
+		}
+	}
+	return nil
+}
+
+// Blocks may have no saved parent if they are the first block of the chain, the lowest block of a
+// node started from a checkpoint, or history at or below the finalized slot which was pruned.
+func verifyParents(ctx context.Context, tx *bolt.Tx, blks map[string]*ethpb.BeaconBlock, report *IntegrityReport) error {
+	var finalizedSlot uint64
+	if enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+		cp := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, cp); err != nil {
+			return errors.Wrap(err, "could not decode finalized checkpoint")
+		}
+		finalizedSlot = helpers.StartSlot(cp.Epoch)
+	}
+	bkt := tx.Bucket(blocksBucket)
+	lowestRoots := [][]byte{bkt.Get(originBlockRootKey), bkt.Get(backfillBlockRootKey)}
+	for root, blk := range blks {
+		if _, ok := blks[string(blk.ParentRoot)]; ok {
+			continue
+		}
+		if blk.Slot == 0 || bytes.Equal(blk.ParentRoot, params.BeaconConfig().ZeroHash[:]) || blk.Slot <= finalizedSlot {
+			continue
+		}
+		isLowest := false
+		for _, lowest := range lowestRoots {
+			isLowest = isLowest || bytes.Equal(lowest, []byte(root))
+		}
+		if isLowest {
+			continue
+		}
+		report.addIssue(MissingParentCheck, []byte(root), false, "parent %#x of block at slot %d is not saved", blk.ParentRoot, blk.Slot)
+	}
+	return nil
+}
+
+// This compares an index bucket to the expected roots for each index key. When repairing,
+// each mismatching key is rewritten to the expected roots, or deleted if none are expected.
+func verifyIndex(bkt *bolt.Bucket, check string, expected map[string][][]byte, repair bool, report *IntegrityReport) error {
+	mismatched := make(map[string]bool)
+	if err := bkt.ForEach(func(k, v []byte) error {
+		want := expected[string(k)]
+		have := make([][]byte, 0, len(v)/32)
+		for i := 0; i+32 <= len(v); i += 32 {
+			have = append(have, v[i:i+32])
+		}
+		for _, root := range have {
+			if !containsRoot(want, root) {
+				report.addIssue(check, k, repair, "index refers to block %#x which is not saved with this key", root)
+				mismatched[string(k)] = true
+			}
+		}
+		for _, root := range want {
+			if !containsRoot(have, root) {
+				report.addIssue(check, k, repair, "index is missing block %#x", root)
+				mismatched[string(k)] = true
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for k, want := range expected {
+		if bkt.Get([]byte(k)) == nil {
+			for _, root := range want {
+				report.addIssue(check, []byte(k), repair, "index is missing block %#x", root)
+			}
+			mismatched[k] = true
+		}
+	}
+	if !repair {
+		return nil
+	}
+	for k := range mismatched {
+		want := expected[k]
+		if len(want) == 0 {
+			if err := bkt.Delete([]byte(k)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := bkt.Put([]byte(k), bytes.Join(want, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyStateSummaries(ctx context.Context, tx *bolt.Tx, blks map[string]*ethpb.BeaconBlock, report *IntegrityReport) error {
+	return tx.Bucket(stateSummaryBucket).ForEach(func(k, v []byte) error {
+		report.StateSummaries++
+		summary := &pb.StateSummary{}
+		if err := decode(ctx, v, summary); err != nil {
+			return errors.Wrapf(err, "could not decode state summary %#x", k)
+		}
+		if _, ok := blks[string(summary.Root)]; !ok {
+			report.addIssue(StateSummaryBlockCheck, summary.Root, false, "state summary at slot %d refers to a block which is not saved", summary.Slot)
+		}
+		return nil
+	})
+}
+
+// This checks that every root of the archived point index refers to a saved state. When repairing,
+// roots without a state are removed from the index.
+func verifyArchivedPoints(tx *bolt.Tx, repair bool, report *IntegrityReport) error {
+	states := tx.Bucket(stateBucket)
+	bkt := tx.Bucket(stateSlotIndicesBucket)
+	repaired := make(map[string][]byte)
+	if err := bkt.ForEach(func(k, v []byte) error {
+		report.ArchivedPoints++
+		kept := make([]byte, 0, len(v))
+		for i := 0; i+32 <= len(v); i += 32 {
+			if states.Get(v[i:i+32]) == nil {
+				report.addIssue(ArchivedPointStateCheck, v[i:i+32], repair, "state at slot %d is not saved", bytesutil.BytesToUint64BigEndian(k))
+				continue
+			}
+			kept = append(kept, v[i:i+32]...)
+		}
+		if len(kept) != len(v) {
+			repaired[string(k)] = kept
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !repair {
+		return nil
+	}
+	for k, v := range repaired {
+		if len(v) == 0 {
+			if err := bkt.Delete([]byte(k)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := bkt.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// This checks that the full state each state diff applies to is saved, as a diff cannot be loaded
+// without it. Databases created before state diffs have no state diff bucket.
+func verifyStateDiffBases(tx *bolt.Tx, report *IntegrityReport) error {
+	bkt := tx.Bucket(stateDiffBucket)
+	if bkt == nil {
+		return nil
+	}
+	states := tx.Bucket(stateBucket)
+	slotIndex := tx.Bucket(stateSlotIndicesBucket)
+	return bkt.ForEach(func(k, v []byte) error {
+		report.StateDiffs++
+		if len(v) < stateDiffHeaderLength {
+			report.addIssue(StateDiffBaseCheck, k, false, "state diff of length %d is too short", len(v))
+			return nil
+		}
+		slot := bytesutil.BytesToUint64BigEndian(v[:stateDiffSlotLength])
+		baseSlot := v[stateDiffSlotLength:stateDiffHeaderLength]
+		roots := slotIndex.Get(baseSlot)
+		for i := 0; i+32 <= len(roots); i += 32 {
+			if states.Get(roots[i:i+32]) != nil {
+				return nil
+			}
+		}
+		report.addIssue(StateDiffBaseCheck, k, false, "base state at slot %d of state diff at slot %d is not saved", bytesutil.BytesToUint64BigEndian(baseSlot), slot)
+		return nil
+	})
+}
+
+func containsRoot(roots [][]byte, root []byte) bool {
+	for _, r := range roots {
+		if bytes.Equal(r, root) {
+			return true
+		}
+	}
+	return false
+}
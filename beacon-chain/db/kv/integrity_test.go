@@ -0,0 +1,98 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func issueCounts(report *IntegrityReport) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range report.Issues {
+		counts[issue.Check]++
+	}
+	return counts
+}
+
+func TestVerifyIntegrity_Valid(t *testing.T) {
+	db := setupDB(t)
+	populateArchiveDB(t, db)
+
+	report, err := verifyIntegrity(context.Background(), db.db, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10, report.Blocks)
+	assert.Equal(t, 10, report.StateSummaries)
+	assert.Equal(t, 1, report.ArchivedPoints)
+	assert.Equal(t, 1, report.StateDiffs)
+	assert.Equal(t, 0, len(report.Issues))
+	assert.Equal(t, true, report.OK())
+}
+
+func TestVerifyIntegrity_MissingStateDiffBase(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	roots := populateArchiveDB(t, db)
+	require.NoError(t, db.SaveStateDiff(ctx, roots[8], 9, 4, []byte("diff 9")))
+
+	report, err := verifyIntegrity(ctx, db.db, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.StateDiffs)
+	assert.Equal(t, false, report.OK())
+	assert.Equal(t, 1, issueCounts(report)[StateDiffBaseCheck])
+	assert.DeepEqual(t, fmt.Sprintf("%#x", roots[8][:]), report.Issues[0].Key)
+}
+
+func TestVerifyIntegrity_RepairsIndices(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	roots := populateArchiveDB(t, db)
+
+	// A block above the finalized slot whose parent was never saved.
+	orphan := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 100, ParentRoot: bytesutil.PadTo([]byte("missing"), 32)}}
+	require.NoError(t, db.SaveBlock(ctx, orphan))
+	require.NoError(t, db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 30, Root: bytesutil.PadTo([]byte("no block"), 32)}))
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		// Drop the slot index of the block at slot 3.
+		if err := tx.Bucket(blockSlotIndicesBucket).Delete(bytesutil.Uint64ToBytesBigEndian(3)); err != nil {
+			return err
+		}
+		// Point the slot 4 index at the wrong block.
+		if err := tx.Bucket(blockSlotIndicesBucket).Put(bytesutil.Uint64ToBytesBigEndian(4), roots[5][:]); err != nil {
+			return err
+		}
+		// An archived point without a saved state.
+		return tx.Bucket(stateSlotIndicesBucket).Put(bytesutil.Uint64ToBytesBigEndian(64), roots[9][:])
+	}))
+
+	report, err := verifyIntegrity(ctx, db.db, false)
+	require.NoError(t, err)
+	assert.Equal(t, false, report.OK())
+	counts := issueCounts(report)
+	assert.Equal(t, 1, counts[MissingParentCheck])
+	assert.Equal(t, 1, counts[StateSummaryBlockCheck])
+	assert.Equal(t, 3, counts[BlockSlotIndexCheck])
+	assert.Equal(t, 1, counts[ArchivedPointStateCheck])
+	for _, issue := range report.Issues {
+		assert.Equal(t, false, issue.Repaired)
+	}
+
+	report, err = verifyIntegrity(ctx, db.db, true)
+	require.NoError(t, err)
+	assert.Equal(t, false, report.OK())
+
+	// Only the issues which cannot be repaired by rewriting indices remain.
+	report, err = verifyIntegrity(ctx, db.db, false)
+	require.NoError(t, err)
+	counts = issueCounts(report)
+	assert.Equal(t, 2, len(report.Issues))
+	assert.Equal(t, 1, counts[MissingParentCheck])
+	assert.Equal(t, 1, counts[StateSummaryBlockCheck])
+	assert.Equal(t, false, db.HasArchivedPoint(ctx, 64))
+}
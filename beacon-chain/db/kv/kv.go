@@ -97,6 +97,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			checkpointBucket,
 			powchainBucket,
 			stateSummaryBucket,
+			stateDiffBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -105,6 +106,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			attestationTargetEpochIndicesBucket,
 			blockSlotIndicesBucket,
 			stateSlotIndicesBucket,
+			stateDiffSlotIndicesBucket,
 			blockParentRootIndicesBucket,
 			finalizedBlockRootsIndexBucket,
 			// New State Management service bucket.
@@ -116,9 +118,15 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 		return nil, err
 	}
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	// Several stores may be open at once, e.g. when exporting one database into another, in which
+	// case the bolt metrics are only collected for the first one.
+	if err := prometheus.Register(createBoltCollector(kv.db)); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			return nil, err
+		}
+	}
 
-	return kv, err
+	return kv, nil
 }
 
 // ClearDB removes the previously stored database in the data directory.
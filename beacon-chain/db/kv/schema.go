@@ -17,6 +17,7 @@ var (
 	chainMetadataBucket     = []byte("chain-metadata")
 	checkpointBucket        = []byte("check-point")
 	powchainBucket          = []byte("powchain")
+	stateDiffBucket         = []byte("state-diffs")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -27,6 +28,7 @@ var (
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
 	blockSlotIndicesBucket              = []byte("block-slot-indices")
 	stateSlotIndicesBucket              = []byte("state-slot-indices")
+	stateDiffSlotIndicesBucket          = []byte("state-diff-slot-indices")
 	attestationHeadBlockRootBucket      = []byte("attestation-head-block-root-indices")
 	attestationSourceRootIndicesBucket  = []byte("attestation-source-root-indices")
 	attestationSourceEpochIndicesBucket = []byte("attestation-source-epoch-indices")
@@ -37,10 +39,13 @@ var (
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
 	genesisBlockRootKey       = []byte("genesis-root")
+	originBlockRootKey        = []byte("origin-root")
+	backfillBlockRootKey      = []byte("backfill-root")
 	depositContractAddressKey = []byte("deposit-contract")
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
+	forkChoiceStoreKey        = []byte("fork-choice-store")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
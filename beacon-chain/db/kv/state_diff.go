@@ -0,0 +1,132 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// State diffs are saved with the slot of their state and the slot of their base state as 8 byte big
+// endian prefixes, so the slot index can be cleaned up when a diff is deleted and the base state of
+// every diff can be checked without decoding it.
+const (
+	stateDiffSlotLength   = 8
+	stateDiffHeaderLength = 2 * stateDiffSlotLength
+)
+
+// SaveStateDiff saves the encoded diff of the state of the given block root and slot against the
+// full state at the base slot. The diff is opaque to the database and indexed by slot.
+func (kv *Store) SaveStateDiff(ctx context.Context, blockRoot [32]byte, slot, baseSlot uint64, diff []byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+
+	enc := make([]byte, 0, stateDiffHeaderLength+len(diff))
+	enc = append(enc, bytesutil.Uint64ToBytesBigEndian(slot)...)
+	enc = append(enc, bytesutil.Uint64ToBytesBigEndian(baseSlot)...)
+	enc = append(enc, diff...)
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		indices := map[string][]byte{string(stateDiffSlotIndicesBucket): bytesutil.Uint64ToBytesBigEndian(slot)}
+		if err := updateValueForIndices(ctx, indices, blockRoot[:], tx); err != nil {
+			return err
+		}
+		return tx.Bucket(stateDiffBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// StateDiff returns the encoded state diff of the given block root, or nil if there is none.
+func (kv *Store) StateDiff(ctx context.Context, blockRoot [32]byte) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.StateDiff")
+	defer span.End()
+
+	var diff []byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateDiffBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+		if len(enc) < stateDiffHeaderLength {
+			return fmt.Errorf("state diff of length %d is too short", len(enc))
+		}
+		diff = make([]byte, len(enc)-stateDiffHeaderLength)
+		copy(diff, enc[stateDiffHeaderLength:])
+		return nil
+	})
+	return diff, err
+}
+
+// HasStateDiff returns true if a state diff of the given block root exists in the db.
+func (kv *Store) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+
+	var exists bool
+	if err := kv.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(stateDiffBucket).Get(blockRoot[:]) != nil
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+	return exists
+}
+
+// HighestSlotStateDiffsBelow returns the encoded state diffs with the highest slot below the input
+// slot from the db. Unlike states, there is no genesis fallback.
+func (kv *Store) HighestSlotStateDiffsBelow(ctx context.Context, slot uint64) ([][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotStateDiffsBelow")
+	defer span.End()
+
+	var best []byte
+	if err := kv.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(stateDiffSlotIndicesBucket).Cursor()
+		for s, roots := c.First(); s != nil; s, roots = c.Next() {
+			if bytesutil.BytesToUint64BigEndian(s) >= slot {
+				break
+			}
+			best = roots
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	diffs := make([][]byte, 0)
+	for i := 0; i+32 <= len(best); i += 32 {
+		diff, err := kv.StateDiff(ctx, bytesutil.ToBytes32(best[i:i+32]))
+		if err != nil {
+			return nil, err
+		}
+		if diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// DeleteStateDiffs deletes the state diffs of the given block roots from the db.
+func (kv *Store) DeleteStateDiffs(ctx context.Context, blockRoots [][32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteStateDiffs")
+	defer span.End()
+
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffBucket)
+		for _, blockRoot := range blockRoots {
+			enc := bkt.Get(blockRoot[:])
+			if enc == nil {
+				continue
+			}
+			if len(enc) >= stateDiffSlotLength {
+				indices := map[string][]byte{string(stateDiffSlotIndicesBucket): enc[:stateDiffSlotLength]}
+				if err := deleteValueForIndices(ctx, indices, blockRoot[:], tx); err != nil {
+					return err
+				}
+			}
+			if err := bkt.Delete(blockRoot[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -36,6 +36,24 @@ func (kv *Store) SaveStateSummaries(ctx context.Context, summaries []*pb.StateSu
 	})
 }
 
+// DeleteStateSummaries deletes the state summary objects of the input block roots from the DB.
+// The summaries of states still in the DB are used to index them, so the states should be
+// deleted first.
+func (kv *Store) DeleteStateSummaries(ctx context.Context, blockRoots [][32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteStateSummaries")
+	defer span.End()
+
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateSummaryBucket)
+		for _, blockRoot := range blockRoots {
+			if err := bucket.Delete(blockRoot[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // StateSummary returns the state summary object from the db using input block root.
 func (kv *Store) StateSummary(ctx context.Context, blockRoot [32]byte) (*pb.StateSummary, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.StateSummary")
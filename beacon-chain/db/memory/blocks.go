@@ -0,0 +1,298 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Block retrieval by root.
+func (s *Store) Block(ctx context.Context, blockRoot [32]byte) (*ethpb.SignedBeaconBlock, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.block(blockRoot), nil
+}
+
+// HeadBlock returns the latest canonical block in eth2.
+func (s *Store) HeadBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.headRoot == nil {
+		return nil, nil
+	}
+	return s.block(*s.headRoot), nil
+}
+
+// Blocks retrieves a list of beacon blocks by filter criteria.
+func (s *Store) Blocks(ctx context.Context, f *filters.QueryFilter) ([]*ethpb.SignedBeaconBlock, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	roots, err := s.blockRootsByFilter(f)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*ethpb.SignedBeaconBlock, 0, len(roots))
+	for _, root := range roots {
+		blocks = append(blocks, s.block(root))
+	}
+	return blocks, nil
+}
+
+// BlockRoots retrieves a list of beacon block roots by filter criteria.
+func (s *Store) BlockRoots(ctx context.Context, f *filters.QueryFilter) ([][32]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	roots, err := s.blockRootsByFilter(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve block roots")
+	}
+	return roots, nil
+}
+
+// HasBlock checks if a block by root exists in the db.
+func (s *Store) HasBlock(ctx context.Context, blockRoot [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.blocks[blockRoot]
+	return ok
+}
+
+// SaveBlock to the db.
+func (s *Store) SaveBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock) error {
+	return s.SaveBlocks(ctx, []*ethpb.SignedBeaconBlock{signed})
+}
+
+// SaveBlocks to the db. Blocks which were already saved are skipped.
+func (s *Store) SaveBlocks(ctx context.Context, blocks []*ethpb.SignedBeaconBlock) error {
+	roots := make([][32]byte, len(blocks))
+	for i, blk := range blocks {
+		root, err := stateutil.BlockRoot(blk.Block)
+		if err != nil {
+			return err
+		}
+		roots[i] = root
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, blk := range blocks {
+		root := roots[i]
+		if _, ok := s.blocks[root]; ok {
+			continue
+		}
+		s.blocks[root] = state.CopySignedBeaconBlock(blk)
+		s.blockSlots.add(blk.Block.Slot, root)
+		if len(blk.Block.ParentRoot) > 0 {
+			parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+			s.blockChildren[parentRoot] = append(s.blockChildren[parentRoot], root)
+		}
+	}
+	return nil
+}
+
+// DeleteBlocks by block roots. Roots of blocks which are not in the db are ignored. The genesis,
+// finalized and head blocks cannot be deleted.
+func (s *Store) DeleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var genesisRoot, finalizedRoot, headRoot [32]byte
+	if s.genesisRoot != nil {
+		genesisRoot = *s.genesisRoot
+		finalizedRoot = genesisRoot
+	}
+	if s.finalized != nil {
+		finalizedRoot = bytesutil.ToBytes32(s.finalized.Root)
+	}
+	if s.headRoot != nil {
+		headRoot = *s.headRoot
+	}
+	for _, root := range blockRoots {
+		if _, ok := s.blocks[root]; !ok {
+			continue
+		}
+		if root == genesisRoot || root == finalizedRoot || root == headRoot {
+			return errors.New("cannot delete genesis, finalized, or head block")
+		}
+	}
+	for _, root := range blockRoots {
+		blk, ok := s.blocks[root]
+		if !ok {
+			continue
+		}
+		s.blockSlots.remove(blk.Block.Slot, root)
+		parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+		children := s.blockChildren[parentRoot]
+		for i, child := range children {
+			if child == root {
+				children = append(children[:i:i], children[i+1:]...)
+				break
+			}
+		}
+		if len(children) == 0 {
+			delete(s.blockChildren, parentRoot)
+		} else {
+			s.blockChildren[parentRoot] = children
+		}
+		delete(s.blocks, root)
+	}
+	return nil
+}
+
+// SaveHeadBlockRoot to the db.
+func (s *Store) SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.hasStateOrSummary(blockRoot) {
+		return errors.New("no state or state summary found with head block root")
+	}
+	s.headRoot = &blockRoot
+	return nil
+}
+
+// GenesisBlock retrieves the genesis block of the beacon chain.
+func (s *Store) GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.genesisRoot == nil {
+		return nil, nil
+	}
+	return s.block(*s.genesisRoot), nil
+}
+
+// SaveGenesisBlockRoot to the db.
+func (s *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.genesisRoot = &blockRoot
+	return nil
+}
+
+// OriginBlockRoot returns the block root of the trusted checkpoint block the node was started from.
+// ErrNotFoundOriginBlockRoot is returned if the node was started from genesis.
+func (s *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.originRoot == nil {
+		return [32]byte{}, kv.ErrNotFoundOriginBlockRoot
+	}
+	return *s.originRoot, nil
+}
+
+// SaveOriginBlockRoot to the db. This marks the block which a checkpoint synced node started from.
+func (s *Store) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.originRoot = &blockRoot
+	return nil
+}
+
+// BackfillBlockRoot returns the root of the lowest block saved by the backfill service.
+// ErrNotFoundBackfillBlockRoot is returned if no block has been backfilled yet.
+func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.backfillRoot == nil {
+		return [32]byte{}, kv.ErrNotFoundBackfillBlockRoot
+	}
+	return *s.backfillRoot, nil
+}
+
+// SaveBackfillBlockRoot to the db.
+func (s *Store) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.backfillRoot = &blockRoot
+	return nil
+}
+
+// HighestSlotBlocksBelow returns the block with the highest slot below the input slot from the db,
+// falling back to the genesis block.
+func (s *Store) HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*ethpb.SignedBeaconBlock, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var blk *ethpb.SignedBeaconBlock
+	if root, ok := s.blockSlots.highestBelow(slot); ok {
+		blk = s.block(root)
+	}
+	if blk == nil && s.genesisRoot != nil {
+		blk = s.block(*s.genesisRoot)
+	}
+	return []*ethpb.SignedBeaconBlock{blk}, nil
+}
+
+// block returns a copy of the block saved with the given root, or nil. The lock must be held.
+func (s *Store) block(blockRoot [32]byte) *ethpb.SignedBeaconBlock {
+	blk, ok := s.blocks[blockRoot]
+	if !ok {
+		return nil
+	}
+	return state.CopySignedBeaconBlock(blk)
+}
+
+// blockRootsByFilter mirrors the filtering of the kv store: the roots are ordered by slot, or in
+// the order the children were saved when filtering by parent root. The lock must be held.
+func (s *Store) blockRootsByFilter(f *filters.QueryFilter) ([][32]byte, error) {
+	if f == nil {
+		return nil, errors.New("must specify a filter criteria for retrieving blocks")
+	}
+
+	var startSlot, endSlot, step uint64
+	var parentRoot []byte
+	var startEpoch, endEpoch uint64
+	var hasParentRoot, hasStartEpoch, hasEndEpoch bool
+	for k, v := range f.Filters() {
+		switch k {
+		case filters.ParentRoot:
+			root, ok := v.([]byte)
+			if !ok {
+				return nil, errors.New("parent root is not []byte")
+			}
+			parentRoot, hasParentRoot = root, true
+		case filters.StartSlot:
+			startSlot, _ = v.(uint64)
+		case filters.EndSlot:
+			endSlot, _ = v.(uint64)
+		case filters.StartEpoch:
+			startEpoch, hasStartEpoch = v.(uint64)
+		case filters.EndEpoch:
+			endEpoch, hasEndEpoch = v.(uint64)
+		case filters.SlotStep:
+			step, _ = v.(uint64)
+		default:
+			return nil, fmt.Errorf("filter criterion %v not supported for blocks", k)
+		}
+	}
+	if hasStartEpoch && hasEndEpoch {
+		startSlot = helpers.StartSlot(startEpoch)
+		endSlot = helpers.StartSlot(endEpoch) + params.BeaconConfig().SlotsPerEpoch - 1
+	}
+	if endSlot != 0 && endSlot < startSlot {
+		return [][32]byte{}, nil
+	}
+
+	roots := s.blockSlots.rootsInRange(startSlot, endSlot, step)
+	if !hasParentRoot {
+		return roots, nil
+	}
+	inRange := make(map[[32]byte]bool, len(roots))
+	for _, root := range roots {
+		inRange[root] = true
+	}
+	children := make([][32]byte, 0)
+	for _, root := range s.blockChildren[bytesutil.ToBytes32(parentRoot)] {
+		if inRange[root] {
+			children = append(children, root)
+		}
+	}
+	return children, nil
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var errMissingStateForCheckpoint = errors.New("missing state summary for finalized root")
+
+// JustifiedCheckpoint returns the latest justified checkpoint in beacon chain.
+func (s *Store) JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.justified == nil {
+		return &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}, nil
+	}
+	return proto.Clone(s.justified).(*ethpb.Checkpoint), nil
+}
+
+// FinalizedCheckpoint returns the latest finalized checkpoint in beacon chain.
+func (s *Store) FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.finalized == nil {
+		return &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}, nil
+	}
+	return proto.Clone(s.finalized).(*ethpb.Checkpoint), nil
+}
+
+// SaveJustifiedCheckpoint saves justified checkpoint in beacon chain.
+func (s *Store) SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.hasStateOrSummary(bytesutil.ToBytes32(checkpoint.Root)) {
+		return errMissingStateForCheckpoint
+	}
+	s.justified = proto.Clone(checkpoint).(*ethpb.Checkpoint)
+	return nil
+}
+
+// SaveFinalizedCheckpoint saves finalized checkpoint in beacon chain.
+func (s *Store) SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.hasStateOrSummary(bytesutil.ToBytes32(checkpoint.Root)) {
+		return errMissingStateForCheckpoint
+	}
+	if err := s.updateFinalizedBlockRoots(checkpoint); err != nil {
+		return err
+	}
+	s.finalized = proto.Clone(checkpoint).(*ethpb.Checkpoint)
+	return nil
+}
+
+// IsFinalizedBlock returns true if the block root is part of the finalized and canonical chain.
+// Beacon blocks from the latest finalized epoch return true, whether or not they are considered
+// canonical in the "head view" of the beacon node.
+func (s *Store) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.finalizedRoots[blockRoot] {
+		return true
+	}
+	var genesisRoot [32]byte
+	if s.genesisRoot != nil {
+		genesisRoot = *s.genesisRoot
+	}
+	return genesisRoot == blockRoot
+}
+
+// updateFinalizedBlockRoots follows the algorithm of the kv store finalized block roots index:
+// the blocks since the previous finalized epoch are de-indexed, the chain is walked up from the
+// new finalized root until an indexed block, the genesis block or the origin block is found, and
+// all the blocks from the finalized epoch onwards are indexed. The lock must be held.
+func (s *Store) updateFinalizedBlockRoots(checkpoint *ethpb.Checkpoint) error {
+	var previousEpoch uint64
+	if s.prevFinalized != nil {
+		previousEpoch = s.prevFinalized.Epoch
+	}
+	epochEnd := helpers.StartSlot(checkpoint.Epoch+1) + params.BeaconConfig().SlotsPerEpoch - 1
+	for _, root := range s.blockSlots.rootsInRange(helpers.StartSlot(previousEpoch), epochEnd, 1) {
+		delete(s.finalizedRoots, root)
+	}
+
+	root := bytesutil.ToBytes32(checkpoint.Root)
+	for {
+		if s.genesisRoot != nil && root == *s.genesisRoot {
+			break
+		}
+		blk, ok := s.blocks[root]
+		if !ok || blk.Block == nil {
+			return fmt.Errorf("missing block in database: block root=%#x", root)
+		}
+		s.finalizedRoots[root] = true
+		if s.originRoot != nil && root == *s.originRoot {
+			break
+		}
+		parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+		if s.finalizedRoots[parentRoot] {
+			break
+		}
+		root = parentRoot
+	}
+
+	for _, root := range s.blockSlots.rootsInRange(helpers.StartSlot(checkpoint.Epoch), epochEnd, 1) {
+		s.finalizedRoots[root] = true
+	}
+
+	s.prevFinalized = proto.Clone(checkpoint).(*ethpb.Checkpoint)
+	return nil
+}
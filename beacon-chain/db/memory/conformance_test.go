@@ -0,0 +1,19 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/memory"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_DatabaseTests(t *testing.T) {
+	dbtest.RunDatabaseTests(t, func(t *testing.T) db.Database {
+		s, err := memory.NewStore("", 0, cache.NewStateSummaryCache())
+		require.NoError(t, err)
+		return s
+	})
+}
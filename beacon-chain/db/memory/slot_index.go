@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"sort"
+)
+
+// slotIndex maps slots to the roots of the objects at that slot, in the order they were
+// saved, while keeping the slots sorted for range scans. It mirrors the slot indices
+// buckets of the kv store.
+type slotIndex struct {
+	slots []uint64
+	roots map[uint64][][32]byte
+}
+
+func newSlotIndex() *slotIndex {
+	return &slotIndex{
+		roots: make(map[uint64][][32]byte),
+	}
+}
+
+// add a root at the given slot, unless it is already indexed.
+func (i *slotIndex) add(slot uint64, root [32]byte) {
+	roots, ok := i.roots[slot]
+	for _, r := range roots {
+		if r == root {
+			return
+		}
+	}
+	if !ok {
+		pos := sort.Search(len(i.slots), func(j int) bool { return i.slots[j] >= slot })
+		i.slots = append(i.slots, 0)
+		copy(i.slots[pos+1:], i.slots[pos:])
+		i.slots[pos] = slot
+	}
+	i.roots[slot] = append(roots, root)
+}
+
+// remove a root from the given slot, dropping the slot once it has no roots left.
+func (i *slotIndex) remove(slot uint64, root [32]byte) {
+	roots := i.roots[slot]
+	for j, r := range roots {
+		if r != root {
+			continue
+		}
+		if len(roots) > 1 {
+			i.roots[slot] = append(roots[:j:j], roots[j+1:]...)
+			return
+		}
+		delete(i.roots, slot)
+		pos := sort.Search(len(i.slots), func(k int) bool { return i.slots[k] >= slot })
+		i.slots = append(i.slots[:pos], i.slots[pos+1:]...)
+		return
+	}
+}
+
+// has returns true if any root is indexed at the given slot.
+func (i *slotIndex) has(slot uint64) bool {
+	_, ok := i.roots[slot]
+	return ok
+}
+
+// first returns the first root saved at the given slot.
+func (i *slotIndex) first(slot uint64) ([32]byte, bool) {
+	roots, ok := i.roots[slot]
+	if !ok {
+		return [32]byte{}, false
+	}
+	return roots[0], true
+}
+
+// last returns the highest indexed slot and the first root saved at it.
+func (i *slotIndex) last() (uint64, [32]byte, bool) {
+	if len(i.slots) == 0 {
+		return 0, [32]byte{}, false
+	}
+	slot := i.slots[len(i.slots)-1]
+	return slot, i.roots[slot][0], true
+}
+
+// highestBelow returns the first root saved at the highest indexed slot below the given slot.
+func (i *slotIndex) highestBelow(slot uint64) ([32]byte, bool) {
+	pos := sort.Search(len(i.slots), func(j int) bool { return i.slots[j] >= slot })
+	if pos == 0 {
+		return [32]byte{}, false
+	}
+	return i.roots[i.slots[pos-1]][0], true
+}
+
+// rootsInRange returns the roots from the start slot to the end slot, both included, keeping only
+// the slots which are a multiple of step away from the start slot. An end slot of zero means the
+// range is unbounded.
+func (i *slotIndex) rootsInRange(startSlot, endSlot, step uint64) [][32]byte {
+	if step == 0 {
+		step = 1
+	}
+	roots := make([][32]byte, 0)
+	pos := sort.Search(len(i.slots), func(j int) bool { return i.slots[j] >= startSlot })
+	for ; pos < len(i.slots); pos++ {
+		slot := i.slots[pos]
+		if endSlot != 0 && slot > endSlot {
+			break
+		}
+		if (slot-startSlot)%step != 0 {
+			continue
+		}
+		roots = append(roots, i.roots[slot]...)
+	}
+	return roots
+}
@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// The snapshot is a regular kv store, written next to the previous one and moved in place once
+// complete so a crash while snapshotting never leaves a partial snapshot behind.
+const snapshotDirectoryName = "snapshot"
+
+// snapshotContent holds the objects of the store at the time a snapshot is taken. Saved objects are
+// never mutated in place, so they can be written out without holding the lock. Slashings, voluntary
+// exits, state diffs and the fork choice store are not part of a snapshot, as the archive format
+// used to restore it does not carry them.
+type snapshotContent struct {
+	blocks          []*ethpb.SignedBeaconBlock
+	summaries       []*pb.StateSummary
+	states          map[[32]byte]*pb.BeaconState
+	genesisRoot     *[32]byte
+	originRoot      *[32]byte
+	backfillRoot    *[32]byte
+	headRoot        *[32]byte
+	justified       *ethpb.Checkpoint
+	finalized       *ethpb.Checkpoint
+	depositContract []byte
+	powchainData    *dbpb.ETH1ChainData
+}
+
+// Snapshot writes the content of the database to the snapshot directory of the database path.
+func (s *Store) Snapshot(ctx context.Context) error {
+	if s.databasePath == "" {
+		return errors.New("cannot snapshot an in-memory database without a database path")
+	}
+	start := time.Now()
+	snapshotPath := path.Join(s.databasePath, snapshotDirectoryName)
+	if err := s.writeSnapshot(ctx, snapshotPath); err != nil {
+		return err
+	}
+	log.WithField("path", snapshotPath).WithField("duration", time.Since(start)).Debug("Saved database snapshot")
+	return nil
+}
+
+func (s *Store) snapshotRoutine(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Snapshot(ctx); err != nil {
+				log.WithError(err).Error("Could not snapshot database")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writeSnapshot writes a kv store with the content of the database at the given path, replacing
+// any previous snapshot at that path.
+func (s *Store) writeSnapshot(ctx context.Context, snapshotPath string) error {
+	content := s.snapshotContent()
+	tmpPath := snapshotPath + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	db, err := kv.NewKVStore(tmpPath, cache.NewStateSummaryCache())
+	if err != nil {
+		return errors.Wrap(err, "could not create snapshot database")
+	}
+	if err := content.save(ctx, db); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close snapshot database")
+		}
+		return errors.Wrap(err, "could not save snapshot")
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(snapshotPath); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, snapshotPath)
+}
+
+func (s *Store) snapshotContent() *snapshotContent {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	content := &snapshotContent{
+		blocks:          make([]*ethpb.SignedBeaconBlock, 0, len(s.blocks)),
+		summaries:       make([]*pb.StateSummary, 0, len(s.stateSummaries)),
+		states:          make(map[[32]byte]*pb.BeaconState, len(s.states)),
+		genesisRoot:     s.genesisRoot,
+		originRoot:      s.originRoot,
+		backfillRoot:    s.backfillRoot,
+		headRoot:        s.headRoot,
+		justified:       s.justified,
+		finalized:       s.finalized,
+		depositContract: s.depositContract,
+		powchainData:    s.powchainData,
+	}
+	for _, blk := range s.blocks {
+		content.blocks = append(content.blocks, blk)
+	}
+	for _, summary := range s.stateSummaries {
+		content.summaries = append(content.summaries, summary)
+	}
+	for root, st := range s.states {
+		content.states[root] = st
+	}
+	return content
+}
+
+// save the snapshot content to a database, in an order which satisfies the checks done when saving
+// the head block root and the checkpoints.
+func (c *snapshotContent) save(ctx context.Context, db *kv.Store) error {
+	if err := db.SaveBlocks(ctx, c.blocks); err != nil {
+		return err
+	}
+	if err := db.SaveStateSummaries(ctx, c.summaries); err != nil {
+		return err
+	}
+	states := make([]*state.BeaconState, 0, len(c.states))
+	stateRoots := make([][32]byte, 0, len(c.states))
+	for root, st := range c.states {
+		// The states are only encoded by the kv store, so they do not need to be copied.
+		bs, err := state.InitializeFromProtoUnsafe(st)
+		if err != nil {
+			return err
+		}
+		states = append(states, bs)
+		stateRoots = append(stateRoots, root)
+	}
+	if err := db.SaveStates(ctx, states, stateRoots); err != nil {
+		return err
+	}
+	roots := []struct {
+		root *[32]byte
+		save func(context.Context, [32]byte) error
+	}{
+		{c.genesisRoot, db.SaveGenesisBlockRoot},
+		{c.originRoot, db.SaveOriginBlockRoot},
+		{c.backfillRoot, db.SaveBackfillBlockRoot},
+		{c.headRoot, db.SaveHeadBlockRoot},
+	}
+	for _, r := range roots {
+		if r.root == nil {
+			continue
+		}
+		if err := r.save(ctx, *r.root); err != nil {
+			return err
+		}
+	}
+	if c.justified != nil {
+		if err := db.SaveJustifiedCheckpoint(ctx, c.justified); err != nil {
+			return err
+		}
+	}
+	if c.finalized != nil {
+		if err := db.SaveFinalizedCheckpoint(ctx, c.finalized); err != nil {
+			return err
+		}
+	}
+	if c.depositContract != nil {
+		if err := db.SaveDepositContractAddress(ctx, common.BytesToAddress(c.depositContract)); err != nil {
+			return err
+		}
+	}
+	if c.powchainData != nil {
+		return db.SavePowchainData(ctx, c.powchainData)
+	}
+	return nil
+}
+
+// loadSnapshot restores the database from the snapshot in the database path, if there is one. The
+// snapshot is streamed as a database archive from the kv store into the in-memory store.
+func (s *Store) loadSnapshot(ctx context.Context) error {
+	snapshotPath := path.Join(s.databasePath, snapshotDirectoryName)
+	if _, err := os.Stat(snapshotPath); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := kv.NewKVStore(snapshotPath, cache.NewStateSummaryCache())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close snapshot database")
+		}
+	}()
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(db.Export(ctx, pw, nil))
+	}()
+	if err := kv.ImportArchive(ctx, pr, s); err != nil {
+		pr.CloseWithError(err)
+		return err
+	}
+	log.WithField("path", snapshotPath).Info("Loaded database snapshot")
+	return nil
+}
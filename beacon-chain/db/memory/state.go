@@ -0,0 +1,229 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// State returns the saved state using block's signing root,
+// this particular block was used to generate the state.
+func (s *Store) State(ctx context.Context, blockRoot [32]byte) (*state.BeaconState, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.state(blockRoot)
+}
+
+// HeadState returns the latest canonical state in beacon chain.
+func (s *Store) HeadState(ctx context.Context) (*state.BeaconState, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.headRoot == nil {
+		return nil, nil
+	}
+	return s.state(*s.headRoot)
+}
+
+// GenesisState returns the genesis state in beacon chain.
+func (s *Store) GenesisState(ctx context.Context) (*state.BeaconState, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.genesisRoot == nil {
+		return nil, nil
+	}
+	return s.state(*s.genesisRoot)
+}
+
+// SaveState stores a state to the db using block's signing root which was used to generate the state.
+func (s *Store) SaveState(ctx context.Context, st *state.BeaconState, blockRoot [32]byte) error {
+	return s.SaveStates(ctx, []*state.BeaconState{st}, [][32]byte{blockRoot})
+}
+
+// SaveStates stores multiple states to the db using the provided corresponding roots.
+func (s *Store) SaveStates(ctx context.Context, states []*state.BeaconState, blockRoots [][32]byte) error {
+	if states == nil {
+		return errors.New("nil state")
+	}
+	copies := make([]*pb.BeaconState, len(states))
+	for i, st := range states {
+		copies[i] = st.CloneInnerState()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, root := range blockRoots {
+		s.stateSlots.add(copies[i].Slot, root)
+		s.states[root] = copies[i]
+	}
+	return nil
+}
+
+// HasState checks if a state by root exists in the db.
+func (s *Store) HasState(ctx context.Context, blockRoot [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.states[blockRoot]
+	return ok
+}
+
+// DeleteState by block root.
+func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
+	return s.DeleteStates(ctx, [][32]byte{blockRoot})
+}
+
+// DeleteStates by block roots. The genesis, finalized and head states cannot be deleted.
+func (s *Store) DeleteStates(ctx context.Context, blockRoots [][32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var genesisRoot, finalizedRoot, headRoot [32]byte
+	if s.genesisRoot != nil {
+		genesisRoot = *s.genesisRoot
+		finalizedRoot = genesisRoot
+	}
+	if s.finalized != nil {
+		finalizedRoot = bytesutil.ToBytes32(s.finalized.Root)
+	}
+	if s.headRoot != nil {
+		headRoot = *s.headRoot
+	}
+	for _, root := range blockRoots {
+		if _, ok := s.states[root]; !ok {
+			continue
+		}
+		if root == genesisRoot || root == finalizedRoot || root == headRoot {
+			return errors.New("cannot delete genesis, finalized, or head state")
+		}
+	}
+	for _, root := range blockRoots {
+		st, ok := s.states[root]
+		if !ok {
+			continue
+		}
+		s.stateSlots.remove(st.Slot, root)
+		delete(s.states, root)
+	}
+	return nil
+}
+
+// HighestSlotStatesBelow returns the states with the highest slot below the input slot
+// from the db, falling back to the genesis state.
+func (s *Store) HighestSlotStatesBelow(ctx context.Context, slot uint64) ([]*state.BeaconState, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var st *state.BeaconState
+	var err error
+	if root, ok := s.stateSlots.highestBelow(slot); ok {
+		st, err = s.state(root)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if st == nil && s.genesisRoot != nil {
+		st, err = s.state(*s.genesisRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []*state.BeaconState{st}, nil
+}
+
+// SaveStateSummary saves a state summary object to the DB.
+func (s *Store) SaveStateSummary(ctx context.Context, summary *pb.StateSummary) error {
+	return s.SaveStateSummaries(ctx, []*pb.StateSummary{summary})
+}
+
+// SaveStateSummaries saves state summary objects to the DB.
+func (s *Store) SaveStateSummaries(ctx context.Context, summaries []*pb.StateSummary) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, summary := range summaries {
+		s.stateSummaries[bytesutil.ToBytes32(summary.Root)] = proto.Clone(summary).(*pb.StateSummary)
+	}
+	return nil
+}
+
+// DeleteStateSummaries deletes the state summary objects of the input block roots from the DB.
+func (s *Store) DeleteStateSummaries(ctx context.Context, blockRoots [][32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, root := range blockRoots {
+		delete(s.stateSummaries, root)
+	}
+	return nil
+}
+
+// StateSummary returns the state summary object from the db using input block root.
+func (s *Store) StateSummary(ctx context.Context, blockRoot [32]byte) (*pb.StateSummary, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	summary, ok := s.stateSummaries[blockRoot]
+	if !ok {
+		return nil, nil
+	}
+	return proto.Clone(summary).(*pb.StateSummary), nil
+}
+
+// HasStateSummary returns true if a state summary exists in DB.
+func (s *Store) HasStateSummary(ctx context.Context, blockRoot [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.stateSummaries[blockRoot]
+	return ok
+}
+
+// LastArchivedSlot from the db.
+func (s *Store) LastArchivedSlot(ctx context.Context) (uint64, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	slot, _, _ := s.stateSlots.last()
+	return slot, nil
+}
+
+// LastArchivedRoot from the db.
+func (s *Store) LastArchivedRoot(ctx context.Context) [32]byte {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, root, _ := s.stateSlots.last()
+	return root
+}
+
+// ArchivedPointRoot returns the block root of an archived point from the DB.
+func (s *Store) ArchivedPointRoot(ctx context.Context, slot uint64) [32]byte {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	root, _ := s.stateSlots.first(slot)
+	return root
+}
+
+// HasArchivedPoint returns true if an archived point exists in DB.
+func (s *Store) HasArchivedPoint(ctx context.Context, slot uint64) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.stateSlots.has(slot)
+}
+
+// state returns a copy of the state saved with the given root, or nil. The lock must be held.
+func (s *Store) state(blockRoot [32]byte) (*state.BeaconState, error) {
+	st, ok := s.states[blockRoot]
+	if !ok {
+		return nil, nil
+	}
+	return state.InitializeFromProto(st)
+}
+
+// hasStateOrSummary mirrors the checks of the kv store before saving the head block root or a
+// checkpoint. The lock must be held.
+func (s *Store) hasStateOrSummary(blockRoot [32]byte) bool {
+	if _, ok := s.states[blockRoot]; ok {
+		return true
+	}
+	if _, ok := s.stateSummaries[blockRoot]; ok {
+		return true
+	}
+	return s.stateSummaryCache != nil && s.stateSummaryCache.Has(blockRoot)
+}
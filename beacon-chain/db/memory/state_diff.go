@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+)
+
+// savedStateDiff is an encoded state diff along with the slots of its state and of its base state.
+type savedStateDiff struct {
+	slot     uint64
+	baseSlot uint64
+	enc      []byte
+}
+
+// SaveStateDiff saves the encoded diff of the state of the given block root and slot against the
+// full state at the base slot.
+func (s *Store) SaveStateDiff(ctx context.Context, blockRoot [32]byte, slot, baseSlot uint64, diff []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if saved, ok := s.stateDiffs[blockRoot]; ok {
+		s.stateDiffSlots.remove(saved.slot, blockRoot)
+	}
+	s.stateDiffs[blockRoot] = &savedStateDiff{slot: slot, baseSlot: baseSlot, enc: append([]byte{}, diff...)}
+	s.stateDiffSlots.add(slot, blockRoot)
+	return nil
+}
+
+// StateDiff returns the encoded state diff of the given block root, or nil if there is none.
+func (s *Store) StateDiff(ctx context.Context, blockRoot [32]byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	saved, ok := s.stateDiffs[blockRoot]
+	if !ok {
+		return nil, nil
+	}
+	return append([]byte{}, saved.enc...), nil
+}
+
+// HasStateDiff returns true if a state diff of the given block root exists in the db.
+func (s *Store) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.stateDiffs[blockRoot]
+	return ok
+}
+
+// HighestSlotStateDiffsBelow returns the encoded state diffs with the highest slot below the input
+// slot from the db. Unlike states, there is no genesis fallback.
+func (s *Store) HighestSlotStateDiffsBelow(ctx context.Context, slot uint64) ([][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	root, ok := s.stateDiffSlots.highestBelow(slot)
+	if !ok {
+		return [][]byte{}, nil
+	}
+	return [][]byte{append([]byte{}, s.stateDiffs[root].enc...)}, nil
+}
+
+// DeleteStateDiffs deletes the state diffs of the given block roots from the db.
+func (s *Store) DeleteStateDiffs(ctx context.Context, blockRoots [][32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, root := range blockRoots {
+		saved, ok := s.stateDiffs[root]
+		if !ok {
+			continue
+		}
+		s.stateDiffSlots.remove(saved.slot, root)
+		delete(s.stateDiffs, root)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package memory defines an in-memory implementation of the Database interface
+// of a Prysm beacon node, which may be periodically snapshotted to disk.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var _ = iface.Database(&Store{})
+
+const backupsDirectoryName = "backups"
+
+// Store defines an implementation of the Prysm Database interface which keeps
+// every object in memory. Objects are copied when they are saved and when they
+// are retrieved, so callers may freely mutate them.
+type Store struct {
+	lock              sync.RWMutex
+	databasePath      string
+	stateSummaryCache *cache.StateSummaryCache
+	snapshotInterval  time.Duration
+	cancel            context.CancelFunc
+	done              chan struct{}
+
+	blocks          map[[32]byte]*ethpb.SignedBeaconBlock
+	blockSlots      *slotIndex
+	blockChildren   map[[32]byte][][32]byte
+	states          map[[32]byte]*pb.BeaconState
+	stateSlots      *slotIndex
+	stateSummaries  map[[32]byte]*pb.StateSummary
+	stateDiffs      map[[32]byte]*savedStateDiff
+	stateDiffSlots  *slotIndex
+	finalizedRoots  map[[32]byte]bool
+	headRoot        *[32]byte
+	genesisRoot     *[32]byte
+	originRoot      *[32]byte
+	backfillRoot    *[32]byte
+	justified       *ethpb.Checkpoint
+	finalized       *ethpb.Checkpoint
+	prevFinalized   *ethpb.Checkpoint
+	depositContract []byte
+	powchainData    *dbpb.ETH1ChainData
+	forkChoice      []byte
+
+	proposerSlashings map[[32]byte]*ethpb.ProposerSlashing
+	attesterSlashings map[[32]byte]*ethpb.AttesterSlashing
+	voluntaryExits    map[[32]byte]*ethpb.VoluntaryExit
+}
+
+// NewStore initializes a new in-memory database. If a directory path is given, the
+// database is restored from the snapshot found in that directory, if any. A non-zero
+// snapshot interval periodically writes the content of the database to a snapshot in
+// the directory, which is also written once more when the store is closed.
+func NewStore(dirPath string, snapshotInterval time.Duration, stateSummaryCache *cache.StateSummaryCache) (*Store, error) {
+	if snapshotInterval > 0 && dirPath == "" {
+		return nil, errors.New("a directory path is required to snapshot the in-memory database")
+	}
+	s := &Store{
+		databasePath:      dirPath,
+		stateSummaryCache: stateSummaryCache,
+		snapshotInterval:  snapshotInterval,
+	}
+	s.reset()
+
+	if dirPath != "" {
+		if err := os.MkdirAll(dirPath, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+			return nil, err
+		}
+		if err := s.loadSnapshot(context.Background()); err != nil {
+			return nil, errors.Wrap(err, "could not load database snapshot")
+		}
+	}
+
+	if snapshotInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.done = make(chan struct{})
+		go s.snapshotRoutine(ctx)
+	}
+	return s, nil
+}
+
+func (s *Store) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.blocks = make(map[[32]byte]*ethpb.SignedBeaconBlock)
+	s.blockSlots = newSlotIndex()
+	s.blockChildren = make(map[[32]byte][][32]byte)
+	s.states = make(map[[32]byte]*pb.BeaconState)
+	s.stateSlots = newSlotIndex()
+	s.stateSummaries = make(map[[32]byte]*pb.StateSummary)
+	s.stateDiffs = make(map[[32]byte]*savedStateDiff)
+	s.stateDiffSlots = newSlotIndex()
+	s.finalizedRoots = make(map[[32]byte]bool)
+	s.headRoot = nil
+	s.genesisRoot = nil
+	s.originRoot = nil
+	s.backfillRoot = nil
+	s.justified = nil
+	s.finalized = nil
+	s.prevFinalized = nil
+	s.depositContract = nil
+	s.powchainData = nil
+	s.forkChoice = nil
+	s.proposerSlashings = make(map[[32]byte]*ethpb.ProposerSlashing)
+	s.attesterSlashings = make(map[[32]byte]*ethpb.AttesterSlashing)
+	s.voluntaryExits = make(map[[32]byte]*ethpb.VoluntaryExit)
+}
+
+// ClearDB removes every object from the database along with its snapshot on disk.
+func (s *Store) ClearDB() error {
+	s.reset()
+	if s.databasePath == "" {
+		return nil
+	}
+	if err := os.RemoveAll(path.Join(s.databasePath, snapshotDirectoryName)); err != nil {
+		return errors.Wrap(err, "could not remove database snapshot")
+	}
+	return nil
+}
+
+// Close stops the periodic snapshots, writing a last snapshot if they are enabled.
+func (s *Store) Close() error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+	return s.Snapshot(context.Background())
+}
+
+// DatabasePath at which this database writes its snapshots.
+func (s *Store) DatabasePath() string {
+	return s.databasePath
+}
+
+// Backup writes a snapshot of the database to the backups directory of the database path.
+// Example for backup at slot 345: $DATADIR/backups/prysm_beacondb_at_slot_0000345.backup
+func (s *Store) Backup(ctx context.Context) error {
+	if s.databasePath == "" {
+		return errors.New("cannot backup an in-memory database without a database path")
+	}
+	head, err := s.HeadBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return errors.New("no head block")
+	}
+	backupPath := path.Join(s.databasePath, backupsDirectoryName, fmt.Sprintf("prysm_beacondb_at_slot_%07d.backup", head.Block.Slot))
+	log.WithField("backup", backupPath).Info("Writing backup database.")
+	return s.writeSnapshot(ctx, backupPath)
+}
+
+// HistoricalStatesDeleted is a no-op for the in-memory database, which never deletes historical states
+// on its own and does not persist the slots per archived point.
+func (s *Store) HistoricalStatesDeleted(ctx context.Context) error {
+	return nil
+}
+
+// RunMigrations is a no-op for the in-memory database, as objects are never saved in an older schema.
+func (s *Store) RunMigrations(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func setupSnapshotDir(t *testing.T) string {
+	p := path.Join(testutil.TempDir(), t.Name())
+	require.NoError(t, os.RemoveAll(p))
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(p))
+	})
+	return p
+}
+
+// This fills the store with 10 blocks, their state summaries, a state at slot 8 and chain metadata.
+func populateStore(t *testing.T, s *Store) [][32]byte {
+	ctx := context.Background()
+	blks := make([]*ethpb.SignedBeaconBlock, 10)
+	roots := make([][32]byte, 10)
+	var previousRoot [32]byte
+	for i := range blks {
+		blks[i] = &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: uint64(i + 1), ParentRoot: previousRoot[:]}}
+		r, err := stateutil.BlockRoot(blks[i].Block)
+		require.NoError(t, err)
+		roots[i] = r
+		previousRoot = r
+		require.NoError(t, s.SaveStateSummary(ctx, &pb.StateSummary{Slot: uint64(i + 1), Root: r[:]}))
+	}
+	require.NoError(t, s.SaveBlocks(ctx, blks))
+	st := testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(8))
+	require.NoError(t, s.SaveState(ctx, st, roots[7]))
+	require.NoError(t, s.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, s.SaveHeadBlockRoot(ctx, roots[7]))
+	require.NoError(t, s.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[7][:]}))
+	require.NoError(t, s.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[7][:]}))
+	require.NoError(t, s.SaveDepositContractAddress(ctx, common.Address{'A'}))
+	require.NoError(t, s.SavePowchainData(ctx, &dbpb.ETH1ChainData{
+		CurrentEth1Data: &dbpb.LatestETH1Data{BlockHeight: 100},
+	}))
+	return roots
+}
+
+func TestStore_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := setupSnapshotDir(t)
+	s, err := NewStore(dir, 0, cache.NewStateSummaryCache())
+	require.NoError(t, err)
+	roots := populateStore(t, s)
+	require.NoError(t, s.Snapshot(ctx))
+
+	restored, err := NewStore(dir, 0, cache.NewStateSummaryCache())
+	require.NoError(t, err)
+	for _, r := range roots {
+		assert.Equal(t, true, restored.HasBlock(ctx, r))
+		assert.Equal(t, true, restored.HasStateSummary(ctx, r))
+	}
+	blks, err := restored.Blocks(ctx, filters.NewFilter().SetStartSlot(1).SetEndSlot(10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, len(blks))
+	st, err := restored.HeadState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), st.Slot())
+	cp, err := restored.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[7][:], cp.Root)
+	assert.Equal(t, true, restored.IsFinalizedBlock(ctx, roots[7]))
+	addr, err := restored.DepositContractAddress(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, common.Address{'A'}.Bytes(), addr)
+	data, err := restored.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), data.CurrentEth1Data.BlockHeight)
+
+	require.NoError(t, restored.ClearDB())
+	assert.Equal(t, false, restored.HasBlock(ctx, roots[0]))
+	_, err = os.Stat(path.Join(dir, snapshotDirectoryName))
+	assert.Equal(t, true, os.IsNotExist(err), "Expected the snapshot to be removed")
+}
+
+func TestStore_SnapshotOnClose(t *testing.T) {
+	ctx := context.Background()
+	dir := setupSnapshotDir(t)
+	_, err := NewStore("", time.Hour, cache.NewStateSummaryCache())
+	assert.ErrorContains(t, "a directory path is required", err)
+
+	s, err := NewStore(dir, time.Hour, cache.NewStateSummaryCache())
+	require.NoError(t, err)
+	roots := populateStore(t, s)
+	require.NoError(t, s.Close())
+
+	restored, err := NewStore(dir, 0, cache.NewStateSummaryCache())
+	require.NoError(t, err)
+	assert.Equal(t, true, restored.HasState(ctx, roots[7]))
+}
+
+func TestSlotIndex(t *testing.T) {
+	idx := newSlotIndex()
+	a, b, c := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+	idx.add(5, a)
+	idx.add(1, b)
+	idx.add(5, c)
+	idx.add(5, a)
+	assert.DeepEqual(t, []uint64{1, 5}, idx.slots)
+	assert.DeepEqual(t, [][32]byte{b, a, c}, idx.rootsInRange(0, 0, 1))
+	assert.DeepEqual(t, [][32]byte{b}, idx.rootsInRange(1, 4, 1))
+
+	root, ok := idx.highestBelow(5)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, b, root)
+	_, ok = idx.highestBelow(1)
+	assert.Equal(t, false, ok)
+
+	idx.remove(5, a)
+	root, ok = idx.first(5)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, c, root)
+	idx.remove(5, c)
+	assert.Equal(t, false, idx.has(5))
+	slot, root, ok := idx.last()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(1), slot)
+	assert.Equal(t, b, root)
+}
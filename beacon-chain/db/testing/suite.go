@@ -0,0 +1,462 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// RunDatabaseTests runs the tests every implementation of the database interface must pass
+// against fresh databases created by the setup function.
+func RunDatabaseTests(t *testing.T, setup func(t *testing.T) db.Database) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, d db.Database)
+	}{
+		{"Blocks_CRUD", testBlocksCRUD},
+		{"BlockRoots_Filters", testBlockRootsFilters},
+		{"Blocks_GenesisHeadAndHighestBelow", testBlocksGenesisHeadAndHighestBelow},
+		{"Blocks_OriginAndBackfillRoots", testOriginAndBackfillRoots},
+		{"Blocks_Delete", testBlocksDelete},
+		{"States_CRUD", testStatesCRUD},
+		{"States_DeleteAndHighestBelow", testStatesDeleteAndHighestBelow},
+		{"StateSummaries_CRUD", testStateSummariesCRUD},
+		{"StateDiffs_CRUD", testStateDiffsCRUD},
+		{"Checkpoints", testCheckpoints},
+		{"IsFinalizedBlock", testIsFinalizedBlock},
+		{"Operations_CRUD", testOperationsCRUD},
+		{"DepositContractAndPowchainData", testDepositContractAndPowchainData},
+		{"ForkChoiceStore", testForkChoiceStore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, setup(t))
+		})
+	}
+}
+
+// chainOfBlocks returns n blocks from slot 1, each the child of the previous one.
+func chainOfBlocks(t *testing.T, n uint64) ([]*ethpb.SignedBeaconBlock, [][32]byte) {
+	blocks := make([]*ethpb.SignedBeaconBlock, n)
+	roots := make([][32]byte, n)
+	var previousRoot [32]byte
+	for i := uint64(0); i < n; i++ {
+		blocks[i] = &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{
+				Slot:       i + 1,
+				ParentRoot: bytesutil.PadTo(previousRoot[:], 32),
+			},
+		}
+		r, err := stateutil.BlockRoot(blocks[i].Block)
+		require.NoError(t, err)
+		roots[i] = r
+		previousRoot = r
+	}
+	return blocks, roots
+}
+
+func testBlocksCRUD(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 20, ParentRoot: bytesutil.PadTo([]byte{1, 2, 3}, 32)}}
+	root, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+
+	retrieved, err := d.Block(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpb.SignedBeaconBlock)(nil), retrieved, "Expected nil block")
+	assert.Equal(t, false, d.HasBlock(ctx, root))
+
+	require.NoError(t, d.SaveBlock(ctx, blk))
+	require.NoError(t, d.SaveBlock(ctx, blk), "Saving a block twice should be a no-op")
+	assert.Equal(t, true, d.HasBlock(ctx, root))
+	retrieved, err = d.Block(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(blk, retrieved), "Wanted %v, received %v", blk, retrieved)
+
+	blks, err := d.Blocks(ctx, filters.NewFilter().SetStartSlot(20).SetEndSlot(20))
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(blks))
+}
+
+func testBlocksDelete(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	blocks, roots := chainOfBlocks(t, 4)
+	// A fork of the first block.
+	fork := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: roots[0][:]}}
+	forkRoot, err := stateutil.BlockRoot(fork.Block)
+	require.NoError(t, err)
+	require.NoError(t, d.SaveBlocks(ctx, append(blocks, fork)))
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: 4, Root: roots[3][:]}))
+	require.NoError(t, d.SaveHeadBlockRoot(ctx, roots[3]))
+
+	assert.ErrorContains(t, "cannot delete genesis, finalized, or head block", d.DeleteBlocks(ctx, [][32]byte{roots[0]}))
+	assert.ErrorContains(t, "cannot delete genesis, finalized, or head block", d.DeleteBlocks(ctx, [][32]byte{roots[3]}))
+	require.NoError(t, d.DeleteBlocks(ctx, [][32]byte{forkRoot, roots[2], {'a'}}))
+	assert.Equal(t, false, d.HasBlock(ctx, forkRoot))
+	assert.Equal(t, false, d.HasBlock(ctx, roots[2]))
+	assert.Equal(t, true, d.HasBlock(ctx, roots[1]))
+
+	got, err := d.BlockRoots(ctx, filters.NewFilter().SetStartSlot(1).SetEndSlot(4))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[0], roots[1], roots[3]}, got)
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetParentRoot(roots[0][:]))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[1]}, got)
+}
+
+func testBlockRootsFilters(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	blocks, roots := chainOfBlocks(t, 2*params.BeaconConfig().SlotsPerEpoch)
+	// A fork of the first block.
+	fork := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 3, ParentRoot: roots[0][:]}}
+	forkRoot, err := stateutil.BlockRoot(fork.Block)
+	require.NoError(t, err)
+	require.NoError(t, d.SaveBlocks(ctx, append(blocks, fork)))
+
+	_, err = d.BlockRoots(ctx, nil)
+	assert.ErrorContains(t, "must specify a filter criteria", err)
+
+	got, err := d.BlockRoots(ctx, filters.NewFilter().SetStartSlot(2).SetEndSlot(4))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[1], roots[2], forkRoot, roots[3]}, got)
+
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetStartSlot(2).SetEndSlot(8).SetSlotStep(3))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[1], roots[4], roots[7]}, got)
+
+	// Blocks are at slots 1 to 2 epochs, so the last block is the first slot of epoch 2.
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetStartEpoch(1).SetEndEpoch(1))
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[params.BeaconConfig().SlotsPerEpoch-1:len(roots)-1], got)
+
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetParentRoot(roots[0][:]))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[1], forkRoot}, got)
+
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetParentRoot(roots[0][:]).SetStartSlot(3))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{forkRoot}, got)
+
+	got, err = d.BlockRoots(ctx, filters.NewFilter().SetStartSlot(1000))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+}
+
+func testBlocksGenesisHeadAndHighestBelow(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	blocks, roots := chainOfBlocks(t, 4)
+	require.NoError(t, d.SaveBlocks(ctx, blocks))
+
+	head, err := d.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpb.SignedBeaconBlock)(nil), head, "Expected nil head block")
+	assert.ErrorContains(t, "no state or state summary found", d.SaveHeadBlockRoot(ctx, roots[3]))
+	require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: 4, Root: roots[3][:]}))
+	require.NoError(t, d.SaveHeadBlockRoot(ctx, roots[3]))
+	head, err = d.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(blocks[3], head), "Wanted %v, received %v", blocks[3], head)
+
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+	genesis, err := d.GenesisBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(blocks[0], genesis), "Wanted %v, received %v", blocks[0], genesis)
+
+	highest, err := d.HighestSlotBlocksBelow(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), highest[0].Block.Slot)
+	highest, err = d.HighestSlotBlocksBelow(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), highest[0].Block.Slot)
+	highest, err = d.HighestSlotBlocksBelow(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), highest[0].Block.Slot, "Expected the genesis block")
+}
+
+func testOriginAndBackfillRoots(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	_, err := d.OriginBlockRoot(ctx)
+	assert.Equal(t, true, errors.Is(err, db.ErrNotFoundOriginBlockRoot))
+	_, err = d.BackfillBlockRoot(ctx)
+	assert.Equal(t, true, errors.Is(err, db.ErrNotFoundBackfillBlockRoot))
+
+	origin := bytesutil.ToBytes32([]byte("origin"))
+	backfill := bytesutil.ToBytes32([]byte("backfill"))
+	require.NoError(t, d.SaveOriginBlockRoot(ctx, origin))
+	require.NoError(t, d.SaveBackfillBlockRoot(ctx, backfill))
+	got, err := d.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, origin, got)
+	got, err = d.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, backfill, got)
+}
+
+func testStatesCRUD(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	root := bytesutil.ToBytes32([]byte("state"))
+	st, err := d.State(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, true, st == nil, "Expected nil state")
+	assert.Equal(t, false, d.HasState(ctx, root))
+
+	st = testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(100))
+	require.NoError(t, d.SaveState(ctx, st, root))
+	assert.Equal(t, true, d.HasState(ctx, root))
+
+	// Mutating the saved state must not alter the state in the database.
+	require.NoError(t, st.SetSlot(200))
+	retrieved, err := d.State(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), retrieved.Slot())
+	require.NoError(t, retrieved.SetSlot(300))
+	retrieved, err = d.State(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), retrieved.Slot())
+
+	assert.Equal(t, true, d.HasArchivedPoint(ctx, 100))
+	assert.Equal(t, root, d.ArchivedPointRoot(ctx, 100))
+	assert.Equal(t, root, d.LastArchivedRoot(ctx))
+	slot, err := d.LastArchivedSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), slot)
+
+	require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: 100, Root: root[:]}))
+	require.NoError(t, d.SaveHeadBlockRoot(ctx, root))
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, root))
+	head, err := d.HeadState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), head.Slot())
+	genesis, err := d.GenesisState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), genesis.Slot())
+}
+
+func testStatesDeleteAndHighestBelow(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	roots := make([][32]byte, 5)
+	for i := range roots {
+		roots[i] = bytesutil.ToBytes32([]byte{byte(i + 1)})
+		st := testutil.NewBeaconState()
+		require.NoError(t, st.SetSlot(uint64(10*i)))
+		require.NoError(t, d.SaveState(ctx, st, roots[i]))
+		require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: uint64(10 * i), Root: roots[i][:]}))
+	}
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, d.SaveHeadBlockRoot(ctx, roots[4]))
+
+	states, err := d.HighestSlotStatesBelow(ctx, 25)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), states[0].Slot())
+
+	assert.ErrorContains(t, "cannot delete genesis, finalized, or head state", d.DeleteState(ctx, roots[0]))
+	assert.ErrorContains(t, "cannot delete genesis, finalized, or head state", d.DeleteState(ctx, roots[4]))
+	require.NoError(t, d.DeleteStates(ctx, [][32]byte{roots[1], roots[2]}))
+	assert.Equal(t, false, d.HasState(ctx, roots[1]))
+	assert.Equal(t, false, d.HasState(ctx, roots[2]))
+	assert.Equal(t, false, d.HasArchivedPoint(ctx, 20))
+	assert.Equal(t, true, d.HasState(ctx, roots[3]))
+
+	states, err = d.HighestSlotStatesBelow(ctx, 25)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), states[0].Slot(), "Expected the genesis state")
+}
+
+func testStateSummariesCRUD(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	root := bytesutil.ToBytes32([]byte("summary"))
+	assert.Equal(t, false, d.HasStateSummary(ctx, root))
+	summary, err := d.StateSummary(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, (*pb.StateSummary)(nil), summary, "Expected nil state summary")
+
+	want := &pb.StateSummary{Slot: 20, Root: root[:]}
+	require.NoError(t, d.SaveStateSummaries(ctx, []*pb.StateSummary{want}))
+	assert.Equal(t, true, d.HasStateSummary(ctx, root))
+	summary, err = d.StateSummary(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want, summary), "Wanted %v, received %v", want, summary)
+
+	require.NoError(t, d.DeleteStateSummaries(ctx, [][32]byte{root}))
+	assert.Equal(t, false, d.HasStateSummary(ctx, root))
+}
+
+func testStateDiffsCRUD(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	r1, r2, r3 := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+	assert.Equal(t, false, d.HasStateDiff(ctx, r1))
+	diff, err := d.StateDiff(ctx, r1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(diff))
+	diffs, err := d.HighestSlotStateDiffsBelow(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(diffs))
+
+	require.NoError(t, d.SaveStateDiff(ctx, r1, 10, 0, []byte("diff 10")))
+	require.NoError(t, d.SaveStateDiff(ctx, r2, 20, 0, []byte("diff 20")))
+	require.NoError(t, d.SaveStateDiff(ctx, r3, 30, 0, []byte("diff 30")))
+	assert.Equal(t, true, d.HasStateDiff(ctx, r2))
+	diff, err = d.StateDiff(ctx, r2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("diff 20"), diff)
+	diffs, err = d.HighestSlotStateDiffsBelow(ctx, 30)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][]byte{[]byte("diff 20")}, diffs)
+
+	require.NoError(t, d.DeleteStateDiffs(ctx, [][32]byte{r2, {'d'}}))
+	assert.Equal(t, false, d.HasStateDiff(ctx, r2))
+	diffs, err = d.HighestSlotStateDiffsBelow(ctx, 30)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][]byte{[]byte("diff 10")}, diffs)
+}
+
+func testCheckpoints(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	cp, err := d.JustifiedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], cp.Root)
+	cp, err = d.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], cp.Root)
+
+	blocks, roots := chainOfBlocks(t, 2)
+	require.NoError(t, d.SaveBlocks(ctx, blocks))
+	want := &ethpb.Checkpoint{Epoch: 1, Root: roots[1][:]}
+	assert.ErrorContains(t, "missing state summary for finalized root", d.SaveJustifiedCheckpoint(ctx, want))
+	assert.ErrorContains(t, "missing state summary for finalized root", d.SaveFinalizedCheckpoint(ctx, want))
+
+	require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: 2, Root: roots[1][:]}))
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, d.SaveJustifiedCheckpoint(ctx, want))
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, want))
+	cp, err = d.JustifiedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want, cp), "Wanted %v, received %v", want, cp)
+	cp, err = d.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want, cp), "Wanted %v, received %v", want, cp)
+}
+
+func testIsFinalizedBlock(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	blocks, roots := chainOfBlocks(t, 4*slotsPerEpoch)
+	// A fork of the first block, which is never part of the canonical chain.
+	fork := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: roots[0][:]}}
+	forkRoot, err := stateutil.BlockRoot(fork.Block)
+	require.NoError(t, err)
+	require.NoError(t, d.SaveBlocks(ctx, append(blocks, fork)))
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+
+	finalizedIdx := slotsPerEpoch - 1
+	require.NoError(t, d.SaveStateSummary(ctx, &pb.StateSummary{Slot: blocks[finalizedIdx].Block.Slot, Root: roots[finalizedIdx][:]}))
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[finalizedIdx][:]}))
+
+	for i := uint64(0); i <= finalizedIdx; i++ {
+		assert.Equal(t, true, d.IsFinalizedBlock(ctx, roots[i]), "Block at index %d should be finalized", i)
+	}
+	assert.Equal(t, false, d.IsFinalizedBlock(ctx, forkRoot), "Forked block should not be finalized")
+	assert.Equal(t, false, d.IsFinalizedBlock(ctx, roots[len(roots)-1]), "Head block should not be finalized")
+}
+
+func testOperationsCRUD(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	header := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			ProposerIndex: 5,
+			BodyRoot:      make([]byte, 32),
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+		},
+		Signature: make([]byte, 96),
+	}
+	prop := &ethpb.ProposerSlashing{Header_1: header, Header_2: header}
+	propRoot, err := ssz.HashTreeRoot(prop)
+	require.NoError(t, err)
+	assert.Equal(t, false, d.HasProposerSlashing(ctx, propRoot))
+	require.NoError(t, d.SaveProposerSlashing(ctx, prop))
+	assert.Equal(t, true, d.HasProposerSlashing(ctx, propRoot))
+	retrievedProp, err := d.ProposerSlashing(ctx, propRoot)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(prop, retrievedProp), "Wanted %v, received %v", prop, retrievedProp)
+
+	indexed := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{5},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	att := &ethpb.AttesterSlashing{Attestation_1: indexed, Attestation_2: indexed}
+	attRoot, err := ssz.HashTreeRoot(att)
+	require.NoError(t, err)
+	assert.Equal(t, false, d.HasAttesterSlashing(ctx, attRoot))
+	require.NoError(t, d.SaveAttesterSlashing(ctx, att))
+	assert.Equal(t, true, d.HasAttesterSlashing(ctx, attRoot))
+	retrievedAtt, err := d.AttesterSlashing(ctx, attRoot)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(att, retrievedAtt), "Wanted %v, received %v", att, retrievedAtt)
+
+	exit := &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}
+	exitRoot, err := ssz.HashTreeRoot(exit)
+	require.NoError(t, err)
+	assert.Equal(t, false, d.HasVoluntaryExit(ctx, exitRoot))
+	require.NoError(t, d.SaveVoluntaryExit(ctx, exit))
+	assert.Equal(t, true, d.HasVoluntaryExit(ctx, exitRoot))
+	retrievedExit, err := d.VoluntaryExit(ctx, exitRoot)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(exit, retrievedExit), "Wanted %v, received %v", exit, retrievedExit)
+}
+
+func testDepositContractAndPowchainData(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	addr, err := d.DepositContractAddress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(addr), "Expected no deposit contract address")
+	contract := common.Address{1, 2, 3}
+	require.NoError(t, d.SaveDepositContractAddress(ctx, contract))
+	addr, err = d.DepositContractAddress(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, contract.Bytes(), addr)
+	assert.ErrorContains(t, "cannot override deposit contract address", d.SaveDepositContractAddress(ctx, common.Address{4}))
+
+	data, err := d.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*dbpb.ETH1ChainData)(nil), data, "Expected nil powchain data")
+	want := &dbpb.ETH1ChainData{CurrentEth1Data: &dbpb.LatestETH1Data{BlockHeight: 100}}
+	require.NoError(t, d.SavePowchainData(ctx, want))
+	data, err = d.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want, data), "Wanted %v, received %v", want, data)
+}
+
+func testForkChoiceStore(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	enc, err := d.ForkChoiceStore(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(enc), "Expected no fork choice store")
+	require.NoError(t, d.SaveForkChoiceStore(ctx, []byte("first")))
+	require.NoError(t, d.SaveForkChoiceStore(ctx, []byte("second")))
+	enc, err = d.ForkChoiceStore(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("second"), enc)
+}
@@ -94,6 +94,17 @@ var (
 		Name:  "unsafe-sync",
 		Usage: "Starts the beacon node with the previously saved head state instead of finalized state.",
 	}
+	// CheckpointStateFlag defines a flag for the beacon node to start from a trusted finalized state file.
+	CheckpointStateFlag = &cli.StringFlag{
+		Name: "checkpoint-state",
+		Usage: "Starts the beacon node from a trusted, finalized beacon state file (.SSZ) instead of genesis. " +
+			"Must be used with --checkpoint-block",
+	}
+	// CheckpointBlockFlag defines a flag for the signed block which corresponds to the checkpoint state.
+	CheckpointBlockFlag = &cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "The signed beacon block file (.SSZ) of the trusted finalized state. Must be used with --checkpoint-state",
+	}
 	// SlasherCertFlag defines a flag for the slasher TLS certificate.
 	SlasherCertFlag = &cli.StringFlag{
 		Name:  "slasher-tls-cert",
@@ -112,6 +123,12 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the DB.",
 		Value: 2048,
 	}
+	// ArchivedStateDiffs specifies the number of archived points saved as diffs against the previous full
+	// archived state, between two full archived states.
+	ArchivedStateDiffs = &cli.Uint64Flag{
+		Name:  "archived-state-diffs",
+		Usage: "The number of archived states saved as diffs against the previous full archived state, between two full archived states. Zero saves every archived state in full.",
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
@@ -129,6 +146,12 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 10,
 	}
+	// BlockBatchUploadLimit specifies the upload rate for block batch responses to a single peer.
+	BlockBatchUploadLimit = &cli.IntFlag{
+		Name:  "block-batch-upload-limit",
+		Usage: "The maximum number of bytes per second sent to a single peer in block batch responses, 0 means no limit.",
+		Value: 0,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
@@ -14,6 +14,7 @@ type GlobalFlags struct {
 	MinimumSyncPeers           int
 	BlockBatchLimit            int
 	BlockBatchLimitBurstFactor int
+	BlockBatchUploadLimit      int
 }
 
 var globalConfig *GlobalFlags
@@ -43,6 +44,7 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	}
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
+	cfg.BlockBatchUploadLimit = ctx.Int(BlockBatchUploadLimit.Name)
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
@@ -0,0 +1,51 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// ArchivePathFlag defines the path of a database archive to export to or import from.
+	ArchivePathFlag = &cli.StringFlag{
+		Name:  "archive",
+		Usage: "The path of the database archive file",
+	}
+	// ArchiveStartSlotFlag restricts a database export to the objects at or above this slot.
+	ArchiveStartSlotFlag = &cli.Uint64Flag{
+		Name:  "start-slot",
+		Usage: "Only export blocks, state summaries and states at or above this slot",
+	}
+	// ArchiveEndSlotFlag restricts a database export to the objects at or below this slot.
+	ArchiveEndSlotFlag = &cli.Uint64Flag{
+		Name:  "end-slot",
+		Usage: "Only export blocks, state summaries and states at or below this slot, 0 exports up to the head",
+	}
+	// InMemoryDBFlag keeps the beacon chain database in memory instead of on disk.
+	InMemoryDBFlag = &cli.BoolFlag{
+		Name: "in-memory-db",
+		Usage: "Keeps the beacon chain database in memory. The database is lost on shutdown unless " +
+			"--in-memory-db-snapshot-interval is set",
+	}
+	// InMemoryDBSnapshotIntervalFlag defines how often the in-memory database is snapshotted to the data directory.
+	InMemoryDBSnapshotIntervalFlag = &cli.DurationFlag{
+		Name: "in-memory-db-snapshot-interval",
+		Usage: "How often the in-memory database is written to a snapshot in the data directory, which is restored " +
+			"on the next start. A value of 0 disables snapshots",
+	}
+	// PruneDBFlag deletes the blocks which are not part of the canonical chain once they are finalized.
+	PruneDBFlag = &cli.BoolFlag{
+		Name:  "prune-db",
+		Usage: "Deletes the blocks, states and state summaries which are not part of the canonical chain once they are finalized",
+	}
+	// HistoryRetentionEpochsFlag defines how many epochs of canonical history a pruned database keeps.
+	HistoryRetentionEpochsFlag = &cli.Uint64Flag{
+		Name: "history-retention-epochs",
+		Usage: "With --prune-db, the number of epochs of canonical history kept before the finalized checkpoint. " +
+			"Older blocks and archived states are deleted and not backfilled. A value of 0 keeps the whole history",
+	}
+	// RepairDBFlag makes the database verification rewrite the indices which do not match the saved objects.
+	RepairDBFlag = &cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Rewrite the block slot, parent root and archived point indices which do not match the saved objects",
+	}
+)
@@ -13,6 +13,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
+	Marshaler            // to persist fork choice across restarts.
 }
 
 // HeadRetriever retrieves head root of the current chain.
@@ -43,4 +44,10 @@ type Getter interface {
 	Store() *protoarray.Store
 	HasParent(root [32]byte) bool
 	AncestorRoot(ctx context.Context, root [32]byte, slot uint64) ([]byte, error)
+	Graph(startSlot uint64, endSlot uint64) *protoarray.Graph
+}
+
+// Marshaler encodes the fork choice store so it can be saved to the DB.
+type Marshaler interface {
+	Marshal() ([]byte, error)
 }
@@ -0,0 +1,113 @@
+package protoarray
+
+import (
+	"fmt"
+
+	"github.com/emicklei/dot"
+)
+
+// Graph is a view of the fork choice tree for debugging forks. It can be rendered as Graphviz DOT
+// or encoded as JSON.
+type Graph struct {
+	JustifiedEpoch uint64       `json:"justified_epoch"`
+	FinalizedEpoch uint64       `json:"finalized_epoch"`
+	Nodes          []*GraphNode `json:"nodes"`
+}
+
+// GraphNode is a block node of the fork choice tree with the votes it currently holds. The parent,
+// best child and best descendant roots are empty when the node has none.
+type GraphNode struct {
+	Slot           uint64 `json:"slot"`
+	Root           string `json:"root"`
+	Parent         string `json:"parent"`
+	JustifiedEpoch uint64 `json:"justified_epoch"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	Weight         uint64 `json:"weight"`
+	Votes          uint64 `json:"votes"`
+	BestChild      string `json:"best_child"`
+	BestDescendant string `json:"best_descendant"`
+	Graffiti       string `json:"graffiti"`
+}
+
+// Graph returns the nodes of the fork choice tree with a slot between the start and end slots,
+// inclusive.
+func (f *ForkChoice) Graph(startSlot uint64, endSlot uint64) *Graph {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+
+	votes := make(map[[32]byte]uint64)
+	for _, v := range f.votes {
+		votes[v.currentRoot]++
+	}
+	nodes := f.store.nodes
+	rootAt := func(i uint64) string {
+		if i >= uint64(len(nodes)) {
+			return ""
+		}
+		return fmt.Sprintf("%#x", nodes[i].root)
+	}
+
+	g := &Graph{
+		JustifiedEpoch: f.store.justifiedEpoch,
+		FinalizedEpoch: f.store.finalizedEpoch,
+		Nodes:          make([]*GraphNode, 0, len(nodes)),
+	}
+	for _, n := range nodes {
+		if n.slot < startSlot || n.slot > endSlot {
+			continue
+		}
+		g.Nodes = append(g.Nodes, &GraphNode{
+			Slot:           n.slot,
+			Root:           fmt.Sprintf("%#x", n.root),
+			Parent:         rootAt(n.parent),
+			JustifiedEpoch: n.justifiedEpoch,
+			FinalizedEpoch: n.finalizedEpoch,
+			Weight:         n.weight,
+			Votes:          votes[n.root],
+			BestChild:      rootAt(n.bestChild),
+			BestDescendant: rootAt(n.bestDescendant),
+			Graffiti:       fmt.Sprintf("%#x", n.graffiti),
+		})
+	}
+	return g
+}
+
+// DOT renders the graph in the Graphviz DOT format. Edges point from a node to its parent, the edge
+// from the best child of a node is drawn in bold and the leaves of the tree are drawn in green.
+func (g *Graph) DOT() string {
+	graph := dot.NewGraph(dot.Directed)
+	graph.Attr("rankdir", "RL")
+	graph.Attr("labeljust", "l")
+
+	byRoot := make(map[string]*GraphNode, len(g.Nodes))
+	dotNodes := make(map[string]dot.Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		byRoot[n.Root] = n
+		label := fmt.Sprintf("slot: %d\n root: %s\n weight: %d\n votes: %d\n justified: %d\n finalized: %d",
+			n.Slot, shortRoot(n.Root), n.Weight/1e9, n.Votes, n.JustifiedEpoch, n.FinalizedEpoch)
+		dotN := graph.Node(n.Root).Box().Attr("label", label)
+		if n.BestDescendant == "" {
+			dotN = dotN.Attr("color", "green")
+		}
+		dotNodes[n.Root] = dotN
+	}
+	for _, n := range g.Nodes {
+		parent, ok := dotNodes[n.Parent]
+		if !ok {
+			continue
+		}
+		edge := graph.Edge(dotNodes[n.Root], parent)
+		if byRoot[n.Parent].BestChild == n.Root {
+			edge.Attr("style", "bold")
+		}
+	}
+	return graph.String()
+}
+
+// This shortens a hex encoded root for node labels.
+func shortRoot(root string) string {
+	if len(root) > 10 {
+		return root[:10]
+	}
+	return root
+}
@@ -0,0 +1,47 @@
+package protoarray
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestForkChoice_Graph(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(1), [32]byte{}, 1, 1))
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 2)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 2)
+	head, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, []uint64{1, 1, 1}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(3), head)
+
+	g := f.Graph(0, ^uint64(0))
+	require.Equal(t, 4, len(g.Nodes))
+	assert.Equal(t, uint64(1), g.JustifiedEpoch)
+	n := g.Nodes[3]
+	assert.Equal(t, fmt.Sprintf("%#x", indexToHash(3)), n.Root)
+	assert.Equal(t, fmt.Sprintf("%#x", indexToHash(1)), n.Parent)
+	assert.Equal(t, uint64(2), n.Votes)
+	assert.Equal(t, uint64(2), n.Weight)
+	assert.Equal(t, "", n.BestChild)
+	assert.Equal(t, n.Root, g.Nodes[0].BestDescendant)
+
+	// Nodes are filtered by slot, edges are only drawn between nodes in the graph.
+	g = f.Graph(2, 3)
+	require.Equal(t, 2, len(g.Nodes))
+	assert.Equal(t, uint64(2), g.Nodes[0].Slot)
+	assert.Equal(t, uint64(3), g.Nodes[1].Slot)
+	assert.Equal(t, false, strings.Contains(g.DOT(), "->"))
+
+	dot := f.Graph(0, ^uint64(0)).DOT()
+	assert.Equal(t, 3, strings.Count(dot, "->"))
+	assert.Equal(t, true, strings.Contains(dot, "votes: 2"))
+}
@@ -0,0 +1,192 @@
+package protoarray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// forkChoiceEncodingVersion prefixes every encoded fork choice store so the format can evolve.
+const forkChoiceEncodingVersion = uint64(1)
+
+// Encoded sizes of the fixed width objects of the store.
+const (
+	encodedNodeSize = 6*8 + 2*32 + 8
+	encodedVoteSize = 2*32 + 8
+)
+
+// Marshal encodes the fork choice store with its nodes, validator votes and balances so it can be
+// persisted across restarts. The node indices are not encoded, Unmarshal rebuilds them from the nodes.
+//
+// The encoding is the version followed by the justified epoch, finalized epoch and finalized root
+// of the store, then the nodes, votes and balances as counted lists of fixed width little endian
+// values.
+func (f *ForkChoice) Marshal() ([]byte, error) {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+
+	buf := &bytes.Buffer{}
+	putUint64(buf, forkChoiceEncodingVersion)
+	putUint64(buf, f.store.justifiedEpoch)
+	putUint64(buf, f.store.finalizedEpoch)
+	buf.Write(f.store.finalizedRoot[:])
+
+	putUint64(buf, uint64(len(f.store.nodes)))
+	for _, n := range f.store.nodes {
+		putUint64(buf, n.slot)
+		buf.Write(n.root[:])
+		putUint64(buf, n.parent)
+		putUint64(buf, n.justifiedEpoch)
+		putUint64(buf, n.finalizedEpoch)
+		putUint64(buf, n.weight)
+		putUint64(buf, n.bestChild)
+		putUint64(buf, n.bestDescendant)
+		buf.Write(n.graffiti[:])
+	}
+	putUint64(buf, uint64(len(f.votes)))
+	for _, v := range f.votes {
+		buf.Write(v.currentRoot[:])
+		buf.Write(v.nextRoot[:])
+		putUint64(buf, v.nextEpoch)
+	}
+	putUint64(buf, uint64(len(f.balances)))
+	for _, b := range f.balances {
+		putUint64(buf, b)
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a fork choice store encoded by Marshal. An error is returned if the encoding
+// is malformed or if the node links it holds are not consistent.
+func Unmarshal(enc []byte) (*ForkChoice, error) {
+	r := bytes.NewReader(enc)
+	version, err := getUint64(r)
+	if err != nil {
+		return nil, err
+	}
+	if version != forkChoiceEncodingVersion {
+		return nil, fmt.Errorf("unsupported fork choice encoding version %d", version)
+	}
+	var justifiedEpoch, finalizedEpoch uint64
+	var finalizedRoot [32]byte
+	if justifiedEpoch, err = getUint64(r); err != nil {
+		return nil, err
+	}
+	if finalizedEpoch, err = getUint64(r); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, finalizedRoot[:]); err != nil {
+		return nil, err
+	}
+	f := New(justifiedEpoch, finalizedEpoch, finalizedRoot)
+
+	count, err := getCount(r, encodedNodeSize)
+	if err != nil {
+		return nil, err
+	}
+	f.store.nodes = make([]*Node, count)
+	for i := range f.store.nodes {
+		n := &Node{}
+		fields := []*uint64{&n.parent, &n.justifiedEpoch, &n.finalizedEpoch, &n.weight, &n.bestChild, &n.bestDescendant}
+		if n.slot, err = getUint64(r); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, n.root[:]); err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			if *field, err = getUint64(r); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := io.ReadFull(r, n.graffiti[:]); err != nil {
+			return nil, err
+		}
+		f.store.nodes[i] = n
+	}
+
+	if count, err = getCount(r, encodedVoteSize); err != nil {
+		return nil, err
+	}
+	f.votes = make([]Vote, count)
+	for i := range f.votes {
+		if _, err := io.ReadFull(r, f.votes[i].currentRoot[:]); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, f.votes[i].nextRoot[:]); err != nil {
+			return nil, err
+		}
+		if f.votes[i].nextEpoch, err = getUint64(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if count, err = getCount(r, 8); err != nil {
+		return nil, err
+	}
+	f.balances = make([]uint64, count)
+	for i := range f.balances {
+		if f.balances[i], err = getUint64(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() != 0 {
+		return nil, errors.New("unexpected trailing bytes in fork choice encoding")
+	}
+
+	if err := f.store.rebuildNodesIndices(); err != nil {
+		return nil, err
+	}
+	nodeCount.Set(float64(len(f.store.nodes)))
+	return f, nil
+}
+
+// This rebuilds the node indices from the nodes, checking that every node links to nodes which
+// exist. A parent always precedes its children in the list.
+func (s *Store) rebuildNodesIndices() error {
+	length := uint64(len(s.nodes))
+	for i, n := range s.nodes {
+		if _, ok := s.nodesIndices[n.root]; ok {
+			return fmt.Errorf("duplicated fork choice node %#x", n.root)
+		}
+		if n.parent != NonExistentNode && n.parent >= uint64(i) {
+			return fmt.Errorf("fork choice node %d has invalid parent index %d", i, n.parent)
+		}
+		if (n.bestChild != NonExistentNode && n.bestChild >= length) ||
+			(n.bestDescendant != NonExistentNode && n.bestDescendant >= length) {
+			return errInvalidBestChildIndex
+		}
+		s.nodesIndices[n.root] = uint64(i)
+	}
+	return nil
+}
+
+func putUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+func getUint64(r *bytes.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, errors.Wrap(err, "could not decode fork choice store")
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+// This reads the count of a list whose items take the given size, which cannot exceed the
+// remaining bytes so a corrupted encoding never makes a huge allocation.
+func getCount(r *bytes.Reader, itemSize uint64) (uint64, error) {
+	count, err := getUint64(r)
+	if err != nil {
+		return 0, err
+	}
+	if count > uint64(r.Len())/itemSize {
+		return 0, errors.New("fork choice encoding is too short")
+	}
+	return count, nil
+}
@@ -0,0 +1,61 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestForkChoice_MarshalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	balances := []uint64{1, 1, 1}
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{'g'}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(2), [32]byte{}, 1, 1))
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(1), 2)
+	head, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(1), head)
+
+	enc, err := f.Marshal()
+	require.NoError(t, err)
+	restored, err := Unmarshal(enc)
+	require.NoError(t, err)
+	assert.DeepEqual(t, f.store.nodes, restored.store.nodes)
+	assert.DeepEqual(t, f.store.nodesIndices, restored.store.nodesIndices)
+	assert.DeepEqual(t, f.votes, restored.votes)
+	assert.DeepEqual(t, f.balances, restored.balances)
+	assert.Equal(t, f.store.finalizedRoot, restored.store.finalizedRoot)
+
+	// The restored store keeps the votes, so new votes move the head the same way.
+	for _, store := range []*ForkChoice{f, restored} {
+		store.ProcessAttestation(ctx, []uint64{2}, indexToHash(3), 3)
+		store.ProcessAttestation(ctx, []uint64{1}, indexToHash(3), 3)
+		head, err := store.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+		require.NoError(t, err)
+		assert.Equal(t, indexToHash(3), head)
+	}
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	enc, err := f.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(enc[:len(enc)-1])
+	assert.NotNil(t, err)
+	_, err = Unmarshal(append(enc, 0))
+	assert.ErrorContains(t, "unexpected trailing bytes", err)
+
+	// A node pointing to a parent which does not precede it.
+	f.store.nodes[0].parent = 1
+	enc, err = f.Marshal()
+	require.NoError(t, err)
+	_, err = Unmarshal(enc)
+	assert.ErrorContains(t, "invalid parent index", err)
+}
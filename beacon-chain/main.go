@@ -10,6 +10,7 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 	golog "github.com/ipfs/go-log/v2"
 	joonix "github.com/joonix/log"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/commands"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -38,16 +39,24 @@ var appFlags = []cli.Flag{
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
 	flags.UnsafeSync,
+	flags.CheckpointStateFlag,
+	flags.CheckpointBlockFlag,
 	flags.SlasherCertFlag,
 	flags.SlasherProviderFlag,
 	flags.DisableDiscv5,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.BlockBatchUploadLimit,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.ArchivedStateDiffs,
+	flags.InMemoryDBFlag,
+	flags.InMemoryDBSnapshotIntervalFlag,
+	flags.PruneDBFlag,
+	flags.HistoryRetentionEpochsFlag,
 	flags.EnableDebugRPCEndpoints,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
@@ -69,6 +78,12 @@ var appFlags = []cli.Flag{
 	cmd.P2PMetadata,
 	cmd.P2PAllowList,
 	cmd.P2PDenyList,
+	cmd.P2PAllowListPeers,
+	cmd.P2PDenyListPeers,
+	cmd.P2PTrustedPeers,
+	cmd.P2PGossipTraceDir,
+	cmd.P2PGossipTraceMaxFileSize,
+	cmd.P2PGossipTraceMaxFiles,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
 	cmd.EnableTracingFlag,
@@ -106,6 +121,9 @@ func main() {
 	app.Usage = "this is a beacon chain implementation for Ethereum 2.0"
 	app.Action = startNode
 	app.Version = version.GetVersion()
+	app.Commands = []*cli.Command{
+		commands.DatabaseCommands,
+	}
 
 	app.Flags = appFlags
 
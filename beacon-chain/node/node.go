@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	checkpointsync "github.com/prysmaticlabs/prysm/beacon-chain/checkpoint-sync"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
@@ -31,9 +32,11 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/pruner"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -121,6 +124,11 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		c.SlotsPerArchivedPoint = uint64(cliCtx.Int(flags.SlotsPerArchivedPoint.Name))
 		params.OverrideBeaconConfig(c)
 	}
+	if cliCtx.IsSet(flags.ArchivedStateDiffs.Name) {
+		c := params.BeaconConfig()
+		c.ArchivedStateDiffs = cliCtx.Uint64(flags.ArchivedStateDiffs.Name)
+		params.OverrideBeaconConfig(c)
+	}
 
 	// Setting chain network specific flags.
 	if cliCtx.IsSet(flags.DepositContractFlag.Name) {
@@ -189,12 +197,20 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerCheckpointSyncService(); err != nil {
+		return nil, err
+	}
+
 	beacon.startForkChoice()
 
 	if err := beacon.registerBlockchainService(); err != nil {
 		return nil, err
 	}
 
+	if err := beacon.registerPrunerService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerInitialSyncService(); err != nil {
 		return nil, err
 	}
@@ -203,6 +219,10 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
 	}
@@ -296,7 +316,13 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := db.NewDB(dbPath, b.stateSummaryCache)
+	newDB := func() (db.Database, error) {
+		if cliCtx.Bool(flags.InMemoryDBFlag.Name) {
+			return db.NewInMemoryDB(dbPath, cliCtx.Duration(flags.InMemoryDBSnapshotIntervalFlag.Name), b.stateSummaryCache)
+		}
+		return db.NewDB(dbPath, b.stateSummaryCache)
+	}
+	d, err := newDB()
 	if err != nil {
 		return err
 	}
@@ -315,7 +341,10 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = db.NewDB(dbPath, b.stateSummaryCache)
+		if err := d.Close(); err != nil {
+			return errors.Wrap(err, "could not close cleared database")
+		}
+		d, err = newDB()
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
@@ -400,11 +429,18 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
 		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
-		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
+		AllowListCIDR:     sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PAllowList.Name)),
 		DenyListCIDR:      sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		AllowListPeers:    sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PAllowListPeers.Name)),
+		DenyListPeers:     sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyListPeers.Name)),
+		TrustedPeers:      sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PTrustedPeers.Name)),
 		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		DisableDiscv5:     cliCtx.Bool(flags.DisableDiscv5.Name),
 		StateNotifier:     b,
+		DB:                b.db,
+		GossipTraceDir:    cliCtx.String(cmd.P2PGossipTraceDir.Name),
+		GossipTraceSize:   cliCtx.Uint64(cmd.P2PGossipTraceMaxFileSize.Name) << 20, // Megabytes to bytes.
+		GossipTraceFiles:  cliCtx.Int(cmd.P2PGossipTraceMaxFiles.Name),
 	})
 	if err != nil {
 		return err
@@ -462,6 +498,18 @@ func (b *BeaconNode) registerBlockchainService() error {
 	return b.services.RegisterService(blockchainService)
 }
 
+func (b *BeaconNode) registerPrunerService() error {
+	if !b.cliCtx.Bool(flags.PruneDBFlag.Name) {
+		return nil
+	}
+	svc := pruner.NewService(b.ctx, &pruner.Config{
+		BeaconDB:        b.db,
+		StateNotifier:   b,
+		RetentionEpochs: b.cliCtx.Uint64(flags.HistoryRetentionEpochsFlag.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerPOWChainService() error {
 	if b.cliCtx.Bool(testSkipPowFlag) {
 		return b.services.RegisterService(&powchain.Service{})
@@ -556,6 +604,25 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService() error {
+	var initSync *initialsync.Service
+	if err := b.services.FetchService(&initSync); err != nil {
+		return err
+	}
+
+	var retentionEpochs uint64
+	if b.cliCtx.Bool(flags.PruneDBFlag.Name) {
+		retentionEpochs = b.cliCtx.Uint64(flags.HistoryRetentionEpochsFlag.Name)
+	}
+	svc := backfill.NewService(b.ctx, &backfill.Config{
+		P2P:                    b.fetchP2P(),
+		DB:                     b.db,
+		InitialSync:            initSync,
+		HistoryRetentionEpochs: retentionEpochs,
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerRPCService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -572,6 +639,11 @@ func (b *BeaconNode) registerRPCService() error {
 		return err
 	}
 
+	var backfillService *backfill.Service
+	if err := b.services.FetchService(&backfillService); err != nil {
+		return err
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	genesisStatePath := b.cliCtx.String(flags.InteropGenesisStateFlag.Name)
 	var depositFetcher depositcache.DepositFetcher
@@ -620,6 +692,7 @@ func (b *BeaconNode) registerRPCService() error {
 		ChainStartFetcher:       chainStartFetcher,
 		MockEth1Votes:           mockEth1DataVotes,
 		SyncService:             syncService,
+		BackfillService:         backfillService,
 		DepositFetcher:          depositFetcher,
 		PendingDepositFetcher:   b.depositCache,
 		BlockNotifier:           b,
@@ -652,6 +725,7 @@ func (b *BeaconNode) registerPrometheusService() error {
 	}
 
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/tree", Handler: c.TreeHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/fork-choice", Handler: c.ForkChoiceHandler})
 
 	service := prometheus.NewPrometheusService(
 		fmt.Sprintf("%s:%d", b.cliCtx.String(cmd.MonitoringHostFlag.Name), b.cliCtx.Int(flags.MonitoringPortFlag.Name)),
@@ -705,3 +779,22 @@ func (b *BeaconNode) registerInteropServices() error {
 	}
 	return nil
 }
+
+func (b *BeaconNode) registerCheckpointSyncService() error {
+	statePath := b.cliCtx.String(flags.CheckpointStateFlag.Name)
+	blockPath := b.cliCtx.String(flags.CheckpointBlockFlag.Name)
+	if statePath == "" && blockPath == "" {
+		return nil
+	}
+
+	svc, err := checkpointsync.NewService(b.ctx, &checkpointsync.Config{
+		BeaconDB:  b.db,
+		StateGen:  b.stateGen,
+		StatePath: statePath,
+		BlockPath: blockPath,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not register checkpoint sync service")
+	}
+	return b.services.RegisterService(svc)
+}
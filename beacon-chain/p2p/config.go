@@ -2,6 +2,7 @@ package p2p
 
 import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 )
 
 // Config for the p2p service. These parameters are set from application level flags
@@ -23,7 +24,14 @@ type Config struct {
 	TCPPort             uint
 	UDPPort             uint
 	MaxPeers            uint
-	AllowListCIDR       string
+	AllowListCIDR       []string
 	DenyListCIDR        []string
+	AllowListPeers      []string
+	DenyListPeers       []string
+	TrustedPeers        []string
 	StateNotifier       statefeed.Notifier
+	DB                  db.ReadOnlyDatabase
+	GossipTraceDir      string
+	GossipTraceSize     uint64
+	GossipTraceFiles    int
 }
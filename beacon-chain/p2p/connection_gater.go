@@ -10,6 +10,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	filter "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr-net"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,7 +22,7 @@ const ipBurst = 8
 
 // InterceptPeerDial tests whether we're permitted to Dial the specified peer.
 func (s *Service) InterceptPeerDial(p peer.ID) (allow bool) {
-	return true
+	return !s.isPeerBanned(p) && s.isPeerAllowed(p)
 }
 
 // InterceptAddrDial tests whether we're permitted to dial the specified
@@ -41,7 +42,7 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 		return false
 	}
 
-	if s.isPeerAtLimit() {
+	if s.isPeerAtLimit() && !s.isTrustedAddr(n.RemoteMultiaddr()) {
 		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
@@ -51,7 +52,17 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (s *Service) InterceptSecured(_ network.Direction, _ peer.ID, n network.ConnMultiaddrs) (allow bool) {
+func (s *Service) InterceptSecured(_ network.Direction, p peer.ID, n network.ConnMultiaddrs) (allow bool) {
+	if s.isPeerBanned(p) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned"}).Trace("Not accepting connection from peer")
+		return false
+	}
+	if !s.isPeerAllowed(p) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "not allowed"}).Trace("Not accepting connection from peer")
+		return false
+	}
 	return true
 }
 
@@ -78,8 +89,8 @@ func (s *Service) validateDial(addr multiaddr.Multiaddr) bool {
 func configureFilter(cfg *Config) (*filter.Filters, error) {
 	addrFilter := filter.NewFilters()
 	// Configure from provided allow list in the config.
-	if cfg.AllowListCIDR != "" {
-		_, ipnet, err := net.ParseCIDR(cfg.AllowListCIDR)
+	for _, cidr := range cfg.AllowListCIDR {
+		_, ipnet, err := net.ParseCIDR(cidr)
 		if err != nil {
 			return nil, err
 		}
@@ -98,6 +109,80 @@ func configureFilter(cfg *Config) (*filter.Filters, error) {
 	return addrFilter, nil
 }
 
+// configurePeerLists parses the provided peer allow, deny and
+// trusted lists.
+func configurePeerLists(cfg *Config) (allowed, denied map[peer.ID]bool, trusted map[peer.ID]*peer.AddrInfo, err error) {
+	allowed = make(map[peer.ID]bool, len(cfg.AllowListPeers))
+	for _, id := range cfg.AllowListPeers {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid allow list peer id %s", id)
+		}
+		allowed[pid] = true
+	}
+	denied = make(map[peer.ID]bool, len(cfg.DenyListPeers))
+	for _, id := range cfg.DenyListPeers {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid deny list peer id %s", id)
+		}
+		denied[pid] = true
+	}
+	trusted = make(map[peer.ID]*peer.AddrInfo, len(cfg.TrustedPeers))
+	for _, addr := range cfg.TrustedPeers {
+		info, err := MakePeer(addr)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid trusted peer %s", addr)
+		}
+		trusted[info.ID] = info
+	}
+	return allowed, denied, trusted, nil
+}
+
+// isPeerAllowed checks the peer against the configured peer lists. Trusted
+// peers are always allowed, and if we have an allow list all other peers
+// which are not in it are rejected.
+func (s *Service) isPeerAllowed(p peer.ID) bool {
+	if _, ok := s.trustedPeers[p]; ok {
+		return true
+	}
+	if s.deniedPeers[p] {
+		return false
+	}
+	return len(s.allowedPeers) == 0 || s.allowedPeers[p]
+}
+
+// isPeerBanned checks whether the peer is banned. Trusted peers are never
+// rejected for being banned, as they are never bad either.
+func (s *Service) isPeerBanned(p peer.ID) bool {
+	if _, ok := s.trustedPeers[p]; ok {
+		return false
+	}
+	return s.peers.IsBanned(p)
+}
+
+// isTrustedAddr checks whether the multiaddr shares its ip address with one
+// of the trusted peers, so that trusted peers are not rejected at the peer limit
+// before their peer id is known.
+func (s *Service) isTrustedAddr(a multiaddr.Multiaddr) bool {
+	if len(s.trustedPeers) == 0 {
+		return false
+	}
+	ip, err := manet.ToIP(a)
+	if err != nil {
+		return false
+	}
+	for _, info := range s.trustedPeers {
+		for _, addr := range info.Addrs {
+			trustedIP, err := manet.ToIP(addr)
+			if err == nil && trustedIP.Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // filterConnections checks the appropriate ip subnets from our
 // filter and decides what to do with them. By default libp2p
 // accepts all incoming dials, so if we have an allow list
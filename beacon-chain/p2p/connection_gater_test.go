@@ -2,11 +2,15 @@ package p2p
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
@@ -141,8 +145,11 @@ func TestPeerAllowList(t *testing.T) {
 	require.NoError(t, err, "Failed to p2p listen")
 	s := &Service{
 		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &peers.PeerScorerConfig{},
+		}),
 	}
-	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: cidr})
+	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: []string{cidr}})
 	require.NoError(t, err)
 	h1, err := libp2p.New(context.Background(), []libp2p.Option{privKeyOption(pkey), libp2p.ListenAddrs(listen), libp2p.ConnectionGater(s)}...)
 	require.NoError(t, err)
@@ -183,6 +190,9 @@ func TestPeerDenyList(t *testing.T) {
 	require.NoError(t, err, "Failed to p2p listen")
 	s := &Service{
 		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &peers.PeerScorerConfig{},
+		}),
 	}
 	s.addrFilter, err = configureFilter(&Config{DenyListCIDR: []string{cidr}})
 	require.NoError(t, err)
@@ -211,13 +221,91 @@ func TestPeerDenyList(t *testing.T) {
 	assert.ErrorContains(t, "no good addresses", err)
 }
 
+func TestService_InterceptBannedPeer(t *testing.T) {
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &peers.PeerScorerConfig{},
+		}),
+	}
+	multiAddress, err := multiaddr.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	pid := peer.ID("peer1")
+	assert.Equal(t, true, s.InterceptPeerDial(pid))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, pid, &maEndpoints{raddr: multiAddress}))
+
+	s.peers.Ban(pid, time.Now().Add(time.Hour))
+	assert.Equal(t, false, s.InterceptPeerDial(pid), "Expected banned peer to not be dialed")
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, pid, &maEndpoints{raddr: multiAddress}), "Expected banned peer to be rejected")
+
+	s.peers.Ban(pid, time.Now().Add(-time.Hour))
+	assert.Equal(t, true, s.InterceptPeerDial(pid), "Expected expired ban to be lifted")
+}
+
+func TestService_InterceptPeerLists(t *testing.T) {
+	newPeerID := func() peer.ID {
+		key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+		return pid
+	}
+	allowed, denied, other, trusted := newPeerID(), newPeerID(), newPeerID(), newPeerID()
+	cfg := &Config{
+		AllowListPeers: []string{allowed.String(), denied.String()},
+		DenyListPeers:  []string{denied.String()},
+		TrustedPeers:   []string{fmt.Sprintf("/ip4/212.67.10.122/tcp/3000/p2p/%s", trusted.String())},
+	}
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &peers.PeerScorerConfig{},
+		}),
+	}
+	var err error
+	s.allowedPeers, s.deniedPeers, s.trustedPeers, err = configurePeerLists(cfg)
+	require.NoError(t, err)
+	multiAddress, err := multiaddr.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+
+	assert.Equal(t, true, s.InterceptPeerDial(allowed))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, allowed, &maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, false, s.InterceptPeerDial(denied), "Expected denied peer to not be dialed")
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, denied, &maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, false, s.InterceptPeerDial(other), "Expected peer outside of the allow list to not be dialed")
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, other, &maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, true, s.InterceptPeerDial(trusted), "Expected trusted peer to be allowed")
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, trusted, &maEndpoints{raddr: multiAddress}))
+	s.peers.Ban(trusted, time.Now().Add(time.Hour))
+	assert.Equal(t, true, s.InterceptPeerDial(trusted), "Expected banned trusted peer to be allowed")
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, trusted, &maEndpoints{raddr: multiAddress}))
+
+	assert.Equal(t, true, s.isTrustedAddr(multiAddress))
+	otherAddress, err := multiaddr.NewMultiaddr("/ip4/212.67.10.123/tcp/3000")
+	require.NoError(t, err)
+	assert.Equal(t, false, s.isTrustedAddr(otherAddress))
+
+	_, _, _, err = configurePeerLists(&Config{DenyListPeers: []string{"invalid"}})
+	assert.ErrorContains(t, "invalid deny list peer id", err)
+}
+
+func TestConfigureFilter_MultipleAllowListCIDRs(t *testing.T) {
+	f, err := configureFilter(&Config{AllowListCIDR: []string{"212.67.0.0/16", "10.0.0.0/8"}})
+	require.NoError(t, err)
+	for ip, allowed := range map[string]bool{"212.67.10.122": true, "10.1.2.3": true, "192.168.0.1": false} {
+		a, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/3000", ip))
+		require.NoError(t, err)
+		assert.Equal(t, allowed, filterConnections(f, a), "Unexpected filtering of %s", ip)
+	}
+	_, err = configureFilter(&Config{AllowListCIDR: []string{"10.0.0.0/8", "invalid"}})
+	assert.NotNil(t, err)
+}
+
 func TestService_InterceptAddrDial_Allow(t *testing.T) {
 	s := &Service{
 		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
 	}
 	var err error
 	cidr := "212.67.89.112/16"
-	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: cidr})
+	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: []string{cidr}})
 	require.NoError(t, err)
 	ip := "212.67.10.122"
 	multiAddress, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, 3000))
@@ -227,3 +315,17 @@ func TestService_InterceptAddrDial_Allow(t *testing.T) {
 		t.Errorf("Expected multiaddress with ip %s to not be rejected with an allow cidr mask of %s", ip, cidr)
 	}
 }
+
+// maEndpoints implements network.ConnMultiaddrs.
+type maEndpoints struct {
+	laddr multiaddr.Multiaddr
+	raddr multiaddr.Multiaddr
+}
+
+func (m *maEndpoints) LocalMultiaddr() multiaddr.Multiaddr {
+	return m.laddr
+}
+
+func (m *maEndpoints) RemoteMultiaddr() multiaddr.Multiaddr {
+	return m.raddr
+}
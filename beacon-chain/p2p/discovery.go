@@ -255,9 +255,20 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 // determines whether our currently connected and
 // active peers are above our set max peer limit.
 func (s *Service) isPeerAtLimit() bool {
-	numOfConns := len(s.host.Network().Peers())
 	maxPeers := int(s.cfg.MaxPeers)
-	activePeers := len(s.Peers().Active())
+	// Trusted peers do not count towards the peer limit.
+	numOfConns := 0
+	for _, pid := range s.host.Network().Peers() {
+		if _, ok := s.trustedPeers[pid]; !ok {
+			numOfConns++
+		}
+	}
+	activePeers := 0
+	for _, pid := range s.Peers().Active() {
+		if _, ok := s.trustedPeers[pid]; !ok {
+			activePeers++
+		}
+	}
 
 	return activePeers >= maxPeers || numOfConns >= maxPeers
 }
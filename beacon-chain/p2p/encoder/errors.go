@@ -0,0 +1,36 @@
+package encoder
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrExceedsMaxSize is returned when the length of a received message goes over the maximum
+// ssz size of its message type.
+var ErrExceedsMaxSize = errors.New("message exceeds max ssz size")
+
+// DecodeError is returned when a message received from a peer is invalid, i.e. it exceeds the
+// allowed size, is not valid snappy, or does not unmarshal into the expected message type.
+// Errors of the underlying stream, such as timeouts or resets, are not decode errors.
+type DecodeError struct {
+	Err error
+}
+
+// Error returns the message of the wrapped error.
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsDecodeError returns true if the error, or any error it wraps, is a DecodeError.
+func IsDecodeError(err error) bool {
+	var decodeErr *DecodeError
+	return errors.As(err, &decodeErr)
+}
+
+func decodeError(err error) error {
+	return &DecodeError{Err: err}
+}
@@ -0,0 +1,76 @@
+package encoder
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Size of an offset of a variable sized ssz object.
+const bytesPerOffset = 4
+
+// maxSSZSize returns the maximum ssz encoded size of the given message type, as derived from
+// the schema and the limits of its lists. False is returned for unknown message types, which
+// are only limited by the max chunk or gossip size.
+func maxSSZSize(msg interface{}) (uint64, bool) {
+	switch msg.(type) {
+	case *ethpb.SignedBeaconBlock:
+		return maxSignedBeaconBlockSize(), true
+	case *ethpb.SignedBeaconBlockHeader:
+		return uint64(new(ethpb.SignedBeaconBlockHeader).SizeSSZ()), true
+	case *ethpb.Attestation:
+		return maxAttestationSize(), true
+	case *ethpb.SignedAggregateAttestationAndProof:
+		return uint64(new(ethpb.SignedAggregateAttestationAndProof).SizeSSZ()) + maxBitlistSize(), true
+	case *ethpb.AttesterSlashing:
+		return maxAttesterSlashingSize(), true
+	case *ethpb.ProposerSlashing:
+		return uint64(new(ethpb.ProposerSlashing).SizeSSZ()), true
+	case *ethpb.SignedVoluntaryExit:
+		return uint64(new(ethpb.SignedVoluntaryExit).SizeSSZ()), true
+	case *pb.Status:
+		return uint64(new(pb.Status).SizeSSZ()), true
+	case *pb.MetaData:
+		return uint64(new(pb.MetaData).SizeSSZ()), true
+	case *pb.BeaconBlocksByRangeRequest:
+		return uint64(new(pb.BeaconBlocksByRangeRequest).SizeSSZ()), true
+	case *pb.BeaconBlocksByRootRequest:
+		return bytesPerOffset + params.BeaconNetworkConfig().MaxRequestBlocks*32, true
+	case *[][32]byte:
+		return params.BeaconNetworkConfig().MaxRequestBlocks * 32, true
+	case *pb.ErrorResponse:
+		// Error messages are limited to 256 bytes.
+		return bytesPerOffset + 256, true
+	case *uint64:
+		return 8, true
+	default:
+		return 0, false
+	}
+}
+
+// maxBitlistSize returns the maximum size of the aggregation bits of an attestation, including
+// the length bit of the bitlist.
+func maxBitlistSize() uint64 {
+	return params.BeaconConfig().MaxValidatorsPerCommittee/8 + 1
+}
+
+func maxAttestationSize() uint64 {
+	return uint64(new(ethpb.Attestation).SizeSSZ()) + maxBitlistSize()
+}
+
+func maxAttesterSlashingSize() uint64 {
+	// Both of the indexed attestations may list every validator of a committee.
+	indices := 2 * params.BeaconConfig().MaxValidatorsPerCommittee * 8
+	return uint64(new(ethpb.AttesterSlashing).SizeSSZ()) + indices
+}
+
+func maxSignedBeaconBlockSize() uint64 {
+	cfg := params.BeaconConfig()
+	size := uint64(new(ethpb.SignedBeaconBlock).SizeSSZ())
+	size += cfg.MaxProposerSlashings * uint64(new(ethpb.ProposerSlashing).SizeSSZ())
+	size += cfg.MaxAttesterSlashings * (bytesPerOffset + maxAttesterSlashingSize())
+	size += cfg.MaxAttestations * (bytesPerOffset + maxAttestationSize())
+	size += cfg.MaxDeposits * uint64(new(ethpb.Deposit).SizeSSZ())
+	size += cfg.MaxVoluntaryExits * uint64(new(ethpb.SignedVoluntaryExit).SizeSSZ())
+	return size
+}
@@ -28,7 +28,11 @@ var bufReaderPool = new(sync.Pool)
 
 // SszNetworkEncoder supports p2p networking encoding using SimpleSerialize
 // with snappy compression (if enabled).
-type SszNetworkEncoder struct{}
+type SszNetworkEncoder struct {
+	// StrictSizeLimits enforces the maximum ssz size of each known message type, as derived
+	// from the schema, before any memory is allocated for a received message.
+	StrictSizeLimits bool
+}
 
 func (e SszNetworkEncoder) doEncode(msg interface{}) ([]byte, error) {
 	if v, ok := msg.(fastssz.Marshaler); ok {
@@ -88,14 +92,23 @@ func (e SszNetworkEncoder) doDecode(b []byte, to interface{}) error {
 // DecodeGossip decodes the bytes to the protobuf gossip message provided.
 func (e SszNetworkEncoder) DecodeGossip(b []byte, to interface{}) error {
 	size, err := snappy.DecodedLen(b)
+	if err != nil {
+		return decodeError(err)
+	}
 	if uint64(size) > MaxGossipSize {
-		return errors.Errorf("gossip message exceeds max gossip size: %d bytes > %d bytes", size, MaxGossipSize)
+		return decodeError(errors.Errorf("gossip message exceeds max gossip size: %d bytes > %d bytes", size, MaxGossipSize))
+	}
+	if err := e.checkMaxSSZSize(uint64(size), to); err != nil {
+		return err
 	}
 	b, err = snappy.Decode(nil /*dst*/, b)
 	if err != nil {
-		return err
+		return decodeError(err)
+	}
+	if err := e.doDecode(b, to); err != nil {
+		return decodeError(err)
 	}
-	return e.doDecode(b, to)
+	return nil
 }
 
 // DecodeWithMaxLength the bytes from io.Reader to the protobuf message provided.
@@ -103,23 +116,61 @@ func (e SszNetworkEncoder) DecodeGossip(b []byte, to interface{}) error {
 func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to interface{}) error {
 	msgLen, err := readVarint(r)
 	if err != nil {
+		if err == errExcessMaxLength {
+			return decodeError(err)
+		}
 		return err
 	}
 	if msgLen > params.BeaconNetworkConfig().MaxChunkSize {
-		return fmt.Errorf(
+		return decodeError(fmt.Errorf(
 			"remaining bytes %d goes over the provided max limit of %d",
 			msgLen,
 			params.BeaconNetworkConfig().MaxChunkSize,
-		)
+		))
+	}
+	if err := e.checkMaxSSZSize(msgLen, to); err != nil {
+		return err
 	}
 	r = newBufferedReader(r)
 	defer bufReaderPool.Put(r)
+	if e.StrictSizeLimits {
+		return e.decodeExactLength(r, msgLen, to)
+	}
 	b := make([]byte, e.MaxLength(int(msgLen)))
 	numOfBytes, err := r.Read(b)
 	if err != nil {
-		return err
+		return wrapReadError(err)
 	}
-	return e.doDecode(b[:numOfBytes], to)
+	if err := e.doDecode(b[:numOfBytes], to); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// decodeExactLength reads exactly the number of uncompressed bytes announced by the
+// length prefix, so that no more than the size of the message is ever allocated.
+func (e SszNetworkEncoder) decodeExactLength(r io.Reader, msgLen uint64, to interface{}) error {
+	b := make([]byte, msgLen)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return wrapReadError(err)
+	}
+	if err := e.doDecode(b, to); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// checkMaxSSZSize verifies that the size of a received message does not go over the maximum
+// ssz size of its type. This is a no-op unless strict size limits are enabled.
+func (e SszNetworkEncoder) checkMaxSSZSize(size uint64, to interface{}) error {
+	if !e.StrictSizeLimits {
+		return nil
+	}
+	maxSize, ok := maxSSZSize(to)
+	if !ok || size <= maxSize {
+		return nil
+	}
+	return decodeError(errors.Wrapf(ErrExceedsMaxSize, "%T of %d bytes > %d bytes", to, size, maxSize))
 }
 
 // ProtocolSuffix returns the appropriate suffix for protocol IDs.
@@ -133,6 +184,18 @@ func (e SszNetworkEncoder) MaxLength(length int) int {
 	return snappy.MaxEncodedLen(length)
 }
 
+// Wraps the errors which are caused by a malformed or truncated message, as opposed to
+// a failure of the underlying stream. A plain io.EOF is left as is, as callers rely on
+// it to detect the end of a stream.
+func wrapReadError(err error) error {
+	switch err {
+	case snappy.ErrCorrupt, snappy.ErrUnsupported, io.ErrUnexpectedEOF:
+		return decodeError(err)
+	default:
+		return err
+	}
+}
+
 // Writes a bytes value through a snappy buffered writer.
 func writeSnappyBuffer(w io.Writer, b []byte) (int, error) {
 	bufWriter := newBufferedWriter(w)
@@ -7,7 +7,10 @@ import (
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	testpb "github.com/prysmaticlabs/prysm/proto/testing"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
@@ -96,3 +99,96 @@ func TestSszNetworkEncoder_DecodeWithMaxLength(t *testing.T) {
 	wanted := fmt.Sprintf("goes over the provided max limit of %d", maxChunkSize)
 	assert.ErrorContains(t, wanted, err)
 }
+
+func TestSszNetworkEncoder_RoundTrip_StrictSizeLimits(t *testing.T) {
+	e := &encoder.SszNetworkEncoder{StrictSizeLimits: true}
+	testRoundTripWithLength(t, e)
+	testRoundTripWithGossip(t, e)
+
+	buf := new(bytes.Buffer)
+	msg := &pb.Status{
+		ForkDigest:     []byte{'A', 'B', 'C', 'D'},
+		FinalizedRoot:  make([]byte, 32),
+		FinalizedEpoch: 10,
+		HeadRoot:       make([]byte, 32),
+		HeadSlot:       100,
+	}
+	_, err := e.EncodeWithMaxLength(buf, msg)
+	require.NoError(t, err)
+	decoded := &pb.Status{}
+	require.NoError(t, e.DecodeWithMaxLength(buf, decoded))
+	assert.DeepEqual(t, msg, decoded)
+}
+
+func TestSszNetworkEncoder_StrictSizeLimits_ExceedsMaxSize(t *testing.T) {
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data: &ethpb.Eth1Data{
+					DepositRoot: make([]byte, 32),
+					BlockHash:   make([]byte, 32),
+				},
+				Graffiti: make([]byte, 32),
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+
+	t.Run("with length", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		e := &encoder.SszNetworkEncoder{StrictSizeLimits: true}
+		_, err := e.EncodeWithMaxLength(buf, blk)
+		require.NoError(t, err)
+		// A block is bigger than the max size of a voluntary exit.
+		err = e.DecodeWithMaxLength(buf, &ethpb.SignedVoluntaryExit{})
+		assert.ErrorContains(t, encoder.ErrExceedsMaxSize.Error(), err)
+		assert.Equal(t, true, errors.Is(err, encoder.ErrExceedsMaxSize))
+		assert.Equal(t, true, encoder.IsDecodeError(err))
+	})
+
+	t.Run("gossip", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		e := &encoder.SszNetworkEncoder{StrictSizeLimits: true}
+		_, err := e.EncodeGossip(buf, blk)
+		require.NoError(t, err)
+		err = e.DecodeGossip(buf.Bytes(), &ethpb.SignedVoluntaryExit{})
+		assert.ErrorContains(t, encoder.ErrExceedsMaxSize.Error(), err)
+		assert.Equal(t, true, encoder.IsDecodeError(err))
+	})
+
+	t.Run("no strict size limits", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		e := &encoder.SszNetworkEncoder{}
+		_, err := e.EncodeWithMaxLength(buf, blk)
+		require.NoError(t, err)
+		// The message is still rejected by ssz, but without being checked against its max size.
+		err = e.DecodeWithMaxLength(buf, &ethpb.SignedVoluntaryExit{})
+		require.NotNil(t, err)
+		assert.Equal(t, false, errors.Is(err, encoder.ErrExceedsMaxSize))
+		assert.Equal(t, true, encoder.IsDecodeError(err))
+	})
+}
+
+func TestSszNetworkEncoder_DecodeError(t *testing.T) {
+	e := &encoder.SszNetworkEncoder{StrictSizeLimits: true}
+
+	// Failures to read from the stream are not caused by the peer's message.
+	err := e.DecodeWithMaxLength(new(bytes.Buffer), &pb.Status{})
+	require.NotNil(t, err)
+	assert.Equal(t, false, encoder.IsDecodeError(err))
+
+	// Invalid snappy frames.
+	buf := new(bytes.Buffer)
+	buf.Write(proto.EncodeVarint(10))
+	buf.Write([]byte("not snappy"))
+	err = e.DecodeWithMaxLength(buf, &pb.Status{})
+	require.NotNil(t, err)
+	assert.Equal(t, true, encoder.IsDecodeError(err))
+
+	err = e.DecodeGossip([]byte("not snappy"), &pb.Status{})
+	require.NotNil(t, err)
+	assert.Equal(t, true, encoder.IsDecodeError(err))
+}
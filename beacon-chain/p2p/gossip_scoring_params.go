@@ -0,0 +1,161 @@
+package p2p
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Topic weights, they set how much each topic counts towards the score of a peer. The weight of
+// the attestation subnets is shared between all of the subnets.
+const (
+	beaconBlockWeight      = 0.8
+	aggregateWeight        = 0.5
+	attestationTotalWeight = 1
+	attesterSlashingWeight = 0.05
+	proposerSlashingWeight = 0.05
+	voluntaryExitWeight    = 0.05
+	totalTopicWeight       = beaconBlockWeight + aggregateWeight + attestationTotalWeight + attesterSlashingWeight + proposerSlashingWeight + voluntaryExitWeight
+)
+
+// Topic score parameters, the durations are in slots or epochs as named.
+const (
+	maxInMeshScore           = 10.0
+	maxFirstDeliveryScore    = 40.0
+	maxPositiveTopicScore    = maxInMeshScore + maxFirstDeliveryScore
+	inMeshCapSlots           = 300
+	firstDeliveryDecayEpochs = 20
+	meshDeliveryDecayEpochs  = 5
+	meshDeliveryActivation   = 4
+	invalidDecayEpochs       = 50
+	// invalidMessagesToGraylist is the number of invalid messages on any topic which take a peer
+	// with no positive score below the graylist threshold.
+	invalidMessagesToGraylist = 10
+)
+
+// Peer score thresholds, gossip is not sent to peers below the gossip threshold, messages are not
+// published to peers below the publish threshold and all messages from peers below the graylist
+// threshold are ignored.
+const (
+	gossipThreshold             = -4000
+	publishThreshold            = -8000
+	graylistThreshold           = -16000
+	acceptPXThreshold           = 100
+	opportunisticGraftThreshold = 5
+)
+
+// The score of a peer decays to zero once it falls below this value.
+const decayToZero = 0.01
+
+// This returns the peer score parameters and thresholds gossipsub is started with. The topic
+// parameters are set once the topics are joined.
+func peerScoringParams() (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds) {
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             gossipThreshold,
+		PublishThreshold:            publishThreshold,
+		GraylistThreshold:           graylistThreshold,
+		AcceptPXThreshold:           acceptPXThreshold,
+		OpportunisticGraftThreshold: opportunisticGraftThreshold,
+	}
+	scoreParams := &pubsub.PeerScoreParams{
+		Topics:        make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap: maxPositiveTopicScore * totalTopicWeight / 2,
+		AppSpecificScore: func(p peer.ID) float64 {
+			return 0
+		},
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -maxPositiveTopicScore * totalTopicWeight,
+		IPColocationFactorThreshold: 10,
+		BehaviourPenaltyWeight:      -15.92,
+		BehaviourPenaltyDecay:       scoreDecay(10 * oneEpochDuration()),
+		DecayInterval:               oneSlotDuration(),
+		DecayToZero:                 decayToZero,
+		RetainScore:                 100 * oneEpochDuration(),
+	}
+	return scoreParams, thresholds
+}
+
+// This returns the score parameters of the gossip topic, computed from the number of active
+// validators which sets the expected message rate of the attestation topics. Nil is returned for
+// topics which are not scored, such as topics unknown to this version of the node.
+func topicScoreParams(topic string, activeValidators uint64) *pubsub.TopicScoreParams {
+	cfg := params.BeaconConfig()
+	switch {
+	case strings.Contains(topic, "beacon_block"):
+		return scoreParamsForRate(beaconBlockWeight, 1)
+	case strings.Contains(topic, "beacon_aggregate_and_proof"):
+		committees := helpers.SlotCommitteeCount(activeValidators)
+		return scoreParamsForRate(aggregateWeight, float64(committees*cfg.TargetAggregatorsPerCommittee))
+	case strings.Contains(topic, "beacon_attestation"):
+		subnets := params.BeaconNetworkConfig().AttestationSubnetCount
+		rate := float64(activeValidators) / float64(cfg.SlotsPerEpoch*subnets)
+		return scoreParamsForRate(attestationTotalWeight/float64(subnets), rate)
+	case strings.Contains(topic, "voluntary_exit"):
+		return scoreParamsForRate(voluntaryExitWeight, 1/float64(cfg.SlotsPerEpoch))
+	case strings.Contains(topic, "proposer_slashing"):
+		return scoreParamsForRate(proposerSlashingWeight, 1/float64(cfg.SlotsPerEpoch))
+	case strings.Contains(topic, "attester_slashing"):
+		return scoreParamsForRate(attesterSlashingWeight, 1/float64(cfg.SlotsPerEpoch))
+	default:
+		return nil
+	}
+}
+
+// This returns the score parameters of a topic of the given weight on which the given number of
+// messages is expected every slot.
+//
+// A peer earns up to maxInMeshScore for staying in the mesh and up to maxFirstDeliveryScore for
+// being the first to deliver messages. Mesh peers which deliver less than a tenth of the expected
+// messages lose as much as they can earn, which is only enforced on topics with at least a message
+// every slot as the rate of sparser topics is too irregular. Invalid messages are penalised enough
+// for invalidMessagesToGraylist of them to graylist a peer, whatever the weight of the topic.
+func scoreParamsForRate(weight float64, messagesPerSlot float64) *pubsub.TopicScoreParams {
+	slotsPerEpoch := float64(params.BeaconConfig().SlotsPerEpoch)
+	firstDeliveryCap := math.Max(messagesPerSlot*slotsPerEpoch*firstDeliveryDecayEpochs/float64(pubsub.GossipSubD), 1)
+	meshDeliveryCap := math.Max(messagesPerSlot*slotsPerEpoch*meshDeliveryDecayEpochs, 1)
+	meshDeliveryThreshold := meshDeliveryCap / 10
+	meshDeliveryWeight := float64(0)
+	if messagesPerSlot >= 1 {
+		meshDeliveryWeight = -maxPositiveTopicScore / (meshDeliveryThreshold * meshDeliveryThreshold)
+	}
+
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     weight,
+		TimeInMeshWeight:                maxInMeshScore / inMeshCapSlots,
+		TimeInMeshQuantum:               oneSlotDuration(),
+		TimeInMeshCap:                   inMeshCapSlots,
+		FirstMessageDeliveriesWeight:    maxFirstDeliveryScore / firstDeliveryCap,
+		FirstMessageDeliveriesDecay:     scoreDecay(firstDeliveryDecayEpochs * oneEpochDuration()),
+		FirstMessageDeliveriesCap:       firstDeliveryCap,
+		MeshMessageDeliveriesWeight:     meshDeliveryWeight,
+		MeshMessageDeliveriesDecay:      scoreDecay(meshDeliveryDecayEpochs * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        meshDeliveryCap,
+		MeshMessageDeliveriesThreshold:  meshDeliveryThreshold,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: meshDeliveryActivation * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        meshDeliveryWeight,
+		MeshFailurePenaltyDecay:         scoreDecay(meshDeliveryDecayEpochs * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  graylistThreshold / (weight * invalidMessagesToGraylist * invalidMessagesToGraylist),
+		InvalidMessageDeliveriesDecay:   scoreDecay(invalidDecayEpochs * oneEpochDuration()),
+	}
+}
+
+// This returns the decay factor applied every decay interval for a counter to decay to zero over
+// the given duration.
+func scoreDecay(totalDuration time.Duration) float64 {
+	numOfTimes := totalDuration / oneSlotDuration()
+	return math.Pow(decayToZero, 1/float64(numOfTimes))
+}
+
+func oneSlotDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+}
+
+func oneEpochDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SlotsPerEpoch) * oneSlotDuration()
+}
@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestTopicScoreParams(t *testing.T) {
+	activeValidators := uint64(16384)
+	suffix := encoder.SszNetworkEncoder{}.ProtocolSuffix()
+	for topicFormat := range GossipTopicMappings {
+		topic := fmt.Sprintf(topicFormat, []byte{1, 2, 3, 4}) + suffix
+		if topicFormat == AttestationSubnetTopicFormat {
+			topic = fmt.Sprintf(topicFormat, []byte{1, 2, 3, 4}, 5) + suffix
+		}
+		scoreParams := topicScoreParams(topic, activeValidators)
+		require.NotNil(t, scoreParams, "No score params for topic %s", topic)
+
+		assert.Equal(t, true, scoreParams.TopicWeight > 0, "Topic %s is not weighted", topic)
+		assert.Equal(t, true, scoreParams.FirstMessageDeliveriesWeight > 0)
+		assert.Equal(t, true, scoreParams.MeshMessageDeliveriesWeight <= 0)
+		assert.Equal(t, true, scoreParams.MeshMessageDeliveriesThreshold <= scoreParams.MeshMessageDeliveriesCap)
+		for _, decay := range []float64{
+			scoreParams.FirstMessageDeliveriesDecay,
+			scoreParams.MeshMessageDeliveriesDecay,
+			scoreParams.MeshFailurePenaltyDecay,
+			scoreParams.InvalidMessageDeliveriesDecay,
+		} {
+			assert.Equal(t, true, decay > 0 && decay < 1, "Invalid decay %f for topic %s", decay, topic)
+		}
+		// Every topic graylists a peer after the same number of invalid messages.
+		penalty := scoreParams.TopicWeight * scoreParams.InvalidMessageDeliveriesWeight * invalidMessagesToGraylist * invalidMessagesToGraylist
+		assert.Equal(t, true, math.Abs(penalty-graylistThreshold) < 1e-6, "Unexpected invalid message penalty %f", penalty)
+	}
+
+	// Mesh deliveries are only scored on topics with regular traffic.
+	blockTopic := fmt.Sprintf(BlockSubnetTopicFormat, []byte{1, 2, 3, 4}) + suffix
+	assert.Equal(t, true, topicScoreParams(blockTopic, activeValidators).MeshMessageDeliveriesWeight < 0)
+	exitTopic := fmt.Sprintf(ExitSubnetTopicFormat, []byte{1, 2, 3, 4}) + suffix
+	assert.Equal(t, float64(0), topicScoreParams(exitTopic, activeValidators).MeshMessageDeliveriesWeight)
+
+	// The expected attestation rate grows with the number of active validators.
+	attTopic := fmt.Sprintf(AttestationSubnetTopicFormat, []byte{1, 2, 3, 4}, 5) + suffix
+	small := topicScoreParams(attTopic, 1024)
+	large := topicScoreParams(attTopic, 4*1024)
+	assert.Equal(t, 4*small.MeshMessageDeliveriesCap, large.MeshMessageDeliveriesCap)
+
+	assert.Equal(t, true, topicScoreParams("foo", activeValidators) == nil)
+}
+
+func TestPeerScoringParams(t *testing.T) {
+	scoreParams, thresholds := peerScoringParams()
+	assert.Equal(t, true, thresholds.GraylistThreshold <= thresholds.PublishThreshold)
+	assert.Equal(t, true, thresholds.PublishThreshold <= thresholds.GossipThreshold)
+	assert.Equal(t, true, thresholds.GossipThreshold <= 0)
+	assert.Equal(t, true, scoreParams.DecayToZero > 0 && scoreParams.DecayToZero < 1)
+	assert.Equal(t, true, scoreParams.BehaviourPenaltyDecay > 0 && scoreParams.BehaviourPenaltyDecay < 1)
+	assert.Equal(t, 0, len(scoreParams.Topics))
+}
@@ -0,0 +1,243 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const (
+	gossipTraceFilePrefix = "gossip-trace-"
+	gossipTraceFileSuffix = ".jsonl"
+	// Number of records which may be queued before records are dropped, so that a slow disk never
+	// holds up the validation of gossip messages.
+	gossipTraceQueueSize = 4096
+	// Maximum size of a single encoded record, a base64 encoded message of the max gossip size
+	// along with its metadata.
+	maxGossipTraceRecordSize = 4 << 20
+)
+
+// GossipTraceRecord is a received gossip message, along with the result of its validation, as
+// recorded by the gossip tracer.
+type GossipTraceRecord struct {
+	Topic     string    `json:"topic"`
+	Sender    string    `json:"sender"`
+	Timestamp time.Time `json:"timestamp"`
+	Result    string    `json:"result"`
+	Data      []byte    `json:"data"`
+}
+
+// Names of the validation results in the gossip traces.
+const (
+	GossipTraceAccept = "accept"
+	GossipTraceIgnore = "ignore"
+	GossipTraceReject = "reject"
+)
+
+// GossipTraceResult returns the name of a validation result in the gossip traces.
+func GossipTraceResult(result pubsub.ValidationResult) string {
+	switch result {
+	case pubsub.ValidationAccept:
+		return GossipTraceAccept
+	case pubsub.ValidationIgnore:
+		return GossipTraceIgnore
+	case pubsub.ValidationReject:
+		return GossipTraceReject
+	default:
+		return fmt.Sprintf("unknown(%d)", result)
+	}
+}
+
+// gossipTracer records gossip messages into files of the trace directory, one JSON encoded record
+// per line. Files are rotated once they reach the max file size, and only the most recent files
+// are kept.
+type gossipTracer struct {
+	dir         string
+	maxFileSize uint64
+	maxFiles    int
+	records     chan *GossipTraceRecord
+	file        *os.File
+	writer      *bufio.Writer
+	size        uint64
+}
+
+func newGossipTracer(dir string, maxFileSize uint64, maxFiles int) (*gossipTracer, error) {
+	if err := os.MkdirAll(dir, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, errors.Wrap(err, "could not create gossip trace directory")
+	}
+	if maxFiles < 1 {
+		maxFiles = 1
+	}
+	t := &gossipTracer{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+		records:     make(chan *GossipTraceRecord, gossipTraceQueueSize),
+	}
+	if err := t.rotate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// trace queues a record to be written. The record is dropped when the queue is full.
+func (t *gossipTracer) trace(record *GossipTraceRecord) {
+	select {
+	case t.records <- record:
+	default:
+		gossipTraceDropped.Inc()
+	}
+}
+
+// run writes the queued records until the context is cancelled. Records are flushed to the
+// file whenever the queue is drained.
+func (t *gossipTracer) run(ctx context.Context) {
+	defer func() {
+		if err := t.close(); err != nil {
+			log.WithError(err).Error("Could not close gossip trace file")
+		}
+	}()
+	for {
+		select {
+		case record := <-t.records:
+			t.writeAndLog(record)
+			if len(t.records) == 0 {
+				if err := t.writer.Flush(); err != nil {
+					log.WithError(err).Error("Could not flush gossip trace")
+				}
+			}
+		case <-ctx.Done():
+			// Write the records which are still queued.
+			for len(t.records) > 0 {
+				t.writeAndLog(<-t.records)
+			}
+			return
+		}
+	}
+}
+
+func (t *gossipTracer) writeAndLog(record *GossipTraceRecord) {
+	if err := t.write(record); err != nil {
+		log.WithError(err).Error("Could not write gossip trace")
+	}
+}
+
+func (t *gossipTracer) write(record *GossipTraceRecord) error {
+	enc, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	enc = append(enc, '\n')
+	if t.size > 0 && t.size+uint64(len(enc)) > t.maxFileSize {
+		if err := t.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := t.writer.Write(enc)
+	t.size += uint64(n)
+	return err
+}
+
+// rotate closes the current trace file, opens a new one and removes the oldest trace files
+// above the max number of files.
+func (t *gossipTracer) rotate() error {
+	if err := t.close(); err != nil {
+		return err
+	}
+	// The nanosecond timestamp sorts the files in the order they are created.
+	var f *os.File
+	var err error
+	for ts := time.Now().UnixNano(); ; ts++ {
+		name := fmt.Sprintf("%s%d%s", gossipTraceFilePrefix, ts, gossipTraceFileSuffix)
+		f, err = os.OpenFile(filepath.Join(t.dir, name), os.O_CREATE|os.O_WRONLY|os.O_EXCL, params.BeaconIoConfig().ReadWritePermissions)
+		if !os.IsExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not create gossip trace file")
+	}
+	t.file = f
+	t.writer = bufio.NewWriter(f)
+	t.size = 0
+
+	files, err := GossipTraceFiles(t.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > t.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return errors.Wrap(err, "could not remove gossip trace file")
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (t *gossipTracer) close() error {
+	if t.file == nil {
+		return nil
+	}
+	if err := t.writer.Flush(); err != nil {
+		return err
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// GossipTraceFiles returns the paths of the gossip trace files in the given directory, from the
+// oldest to the most recent file.
+func GossipTraceFiles(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasPrefix(name, gossipTraceFilePrefix) || !strings.HasSuffix(name, gossipTraceFileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadGossipTraceFile calls the given function with each of the records of a gossip trace file,
+// in the order they were recorded.
+func ReadGossipTraceFile(path string, f func(record *GossipTraceRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Debug("Could not close gossip trace file")
+		}
+	}()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxGossipTraceRecordSize)
+	for scanner.Scan() {
+		record := &GossipTraceRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return errors.Wrapf(err, "could not decode gossip trace record in %s", path)
+		}
+		if err := f(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
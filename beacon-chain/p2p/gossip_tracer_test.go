@@ -0,0 +1,91 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGossipTracer_Rotation(t *testing.T) {
+	dir := path.Join(testutil.TempDir(), strconv.Itoa(int(time.Now().UnixNano())))
+	// Every file fits two records.
+	tracer, err := newGossipTracer(dir, 300, 2)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, tracer.write(&GossipTraceRecord{
+			Topic:     "/eth2/abcdef01/beacon_block/ssz_snappy",
+			Sender:    "peer",
+			Timestamp: time.Unix(int64(i), 0).UTC(),
+			Result:    GossipTraceAccept,
+			Data:      []byte(fmt.Sprintf("message %d", i)),
+		}))
+	}
+	require.NoError(t, tracer.close())
+
+	files, err := GossipTraceFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files), "Expected the oldest files to be removed")
+	var data []string
+	for _, file := range files {
+		require.NoError(t, ReadGossipTraceFile(file, func(record *GossipTraceRecord) error {
+			data = append(data, string(record.Data))
+			return nil
+		}))
+	}
+	assert.DeepEqual(t, []string{"message 6", "message 7", "message 8", "message 9"}, data)
+}
+
+func TestService_TraceGossipMessage(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	dir := path.Join(testutil.TempDir(), strconv.Itoa(int(time.Now().UnixNano())))
+	tracer, err := newGossipTracer(dir, 1<<20, 1)
+	require.NoError(t, err)
+	s := &Service{
+		host:         p1.BHost,
+		gossipTracer: tracer,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		tracer.run(ctx)
+		close(done)
+	}()
+
+	topic := "/eth2/abcdef01/beacon_block/ssz_snappy"
+	s.TraceGossipMessage(topic, &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: []byte("block")},
+		ReceivedFrom: "peer",
+	}, pubsub.ValidationReject)
+	// Messages published by this node are not recorded.
+	s.TraceGossipMessage(topic, &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: []byte("own block")},
+		ReceivedFrom: p1.BHost.ID(),
+	}, pubsub.ValidationAccept)
+	cancel()
+	<-done
+
+	files, err := GossipTraceFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	var records []*GossipTraceRecord
+	require.NoError(t, ReadGossipTraceFile(files[0], func(record *GossipTraceRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, topic, records[0].Topic)
+	assert.Equal(t, peer.ID("peer").String(), records[0].Sender)
+	assert.Equal(t, GossipTraceReject, records[0].Result)
+	assert.DeepEqual(t, []byte("block"), records[0].Data)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -64,6 +65,7 @@ type EncodingProvider interface {
 // PubSubProvider provides the p2p pubsub protocol.
 type PubSubProvider interface {
 	PubSub() *pubsub.PubSub
+	TraceGossipMessage(topic string, msg *pubsub.Message, result pubsub.ValidationResult)
 }
 
 // PeerManager abstracts some peer management methods from libp2p.
@@ -71,6 +73,7 @@ type PeerManager interface {
 	Disconnect(peer.ID) error
 	PeerID() peer.ID
 	Host() host.Host
+	BandwidthReporter() metrics.Reporter
 	ENR() *enr.Record
 	RefreshENR()
 	FindPeersWithSubnet(index uint64) (bool, error)
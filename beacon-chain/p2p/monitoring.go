@@ -15,6 +15,35 @@ var (
 		Name: "p2p_repeat_attempts",
 		Help: "The number of repeat attempts the connection handler is triggered for a peer.",
 	})
+	gossipPeerScores = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_gossip_peer_score",
+		Help: "The gossipsub score of the peers the node is connected to.",
+	},
+		[]string{"peer"})
+	peerBandwidthBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_peer_bandwidth_bytes",
+		Help: "The total number of bytes exchanged with the peers the node is connected to.",
+	},
+		[]string{"peer", "direction"})
+	peerBandwidthRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_peer_bandwidth_rate",
+		Help: "The current bandwidth rate (in bytes per second) of the peers the node is connected to.",
+	},
+		[]string{"peer", "direction"})
+	protocolBandwidthBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_protocol_bandwidth_bytes",
+		Help: "The total number of bytes exchanged for a given protocol.",
+	},
+		[]string{"protocol", "direction"})
+	protocolBandwidthRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_protocol_bandwidth_rate",
+		Help: "The current bandwidth rate (in bytes per second) for a given protocol.",
+	},
+		[]string{"protocol", "direction"})
+	gossipTraceDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_gossip_trace_dropped",
+		Help: "The number of gossip messages which could not be recorded, as the gossip trace queue was full.",
+	})
 )
 
 func (s *Service) updateMetrics() {
@@ -23,4 +52,26 @@ func (s *Service) updateMetrics() {
 	p2pPeerCount.WithLabelValues("Connecting").Set(float64(len(s.peers.Connecting())))
 	p2pPeerCount.WithLabelValues("Disconnecting").Set(float64(len(s.peers.Disconnecting())))
 	p2pPeerCount.WithLabelValues("Bad").Set(float64(len(s.peers.Bad())))
+	s.updateBandwidthMetrics()
+}
+
+func (s *Service) updateBandwidthMetrics() {
+	if s.bandwidthCounter == nil {
+		return
+	}
+	peerBandwidthBytes.Reset()
+	peerBandwidthRate.Reset()
+	for _, pid := range s.peers.Connected() {
+		stats := s.bandwidthCounter.GetBandwidthForPeer(pid)
+		peerBandwidthBytes.WithLabelValues(pid.String(), "in").Set(float64(stats.TotalIn))
+		peerBandwidthBytes.WithLabelValues(pid.String(), "out").Set(float64(stats.TotalOut))
+		peerBandwidthRate.WithLabelValues(pid.String(), "in").Set(stats.RateIn)
+		peerBandwidthRate.WithLabelValues(pid.String(), "out").Set(stats.RateOut)
+	}
+	for proto, stats := range s.bandwidthCounter.GetBandwidthByProtocol() {
+		protocolBandwidthBytes.WithLabelValues(string(proto), "in").Set(float64(stats.TotalIn))
+		protocolBandwidthBytes.WithLabelValues(string(proto), "out").Set(float64(stats.TotalOut))
+		protocolBandwidthRate.WithLabelValues(string(proto), "in").Set(stats.RateIn)
+		protocolBandwidthRate.WithLabelValues(string(proto), "out").Set(stats.RateOut)
+	}
 }
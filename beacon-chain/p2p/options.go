@@ -35,6 +35,7 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		libp2p.ListenAddrs(listen),
 		libp2p.UserAgent(version.GetBuildData()),
 		libp2p.ConnectionGater(s),
+		libp2p.BandwidthReporter(s.bandwidthCounter),
 	}
 	if featureconfig.Get().EnableNoise {
 		// Enable NOISE for the beacon node with secio as a fallback.
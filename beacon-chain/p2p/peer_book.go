@@ -0,0 +1,165 @@
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const peerBookPath = "peerbook.json"
+
+// The number of recently seen peers kept in the peer book, banned peers are kept regardless.
+const peerBookSize = 1000
+
+// How often the peer book is saved to disk.
+const peerBookSaveInterval = 5 * time.Minute
+
+// peerBookEntry is the encoding of a peer record in the peer book file.
+type peerBookEntry struct {
+	ID          string  `json:"id"`
+	ENR         string  `json:"enr,omitempty"`
+	Address     string  `json:"address,omitempty"`
+	Score       float64 `json:"score"`
+	LastSeen    int64   `json:"last_seen"`
+	BannedUntil int64   `json:"banned_until,omitempty"`
+}
+
+// loadPeerBook reads the peer book from the data directory and restores its peers and bans. The
+// restored peers with the best scores are returned to be dialed.
+func (s *Service) loadPeerBook() ([]*peers.PeerRecord, error) {
+	if s.cfg.DataDir == "" {
+		return nil, nil
+	}
+	enc, err := ioutil.ReadFile(path.Join(s.cfg.DataDir, peerBookPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read peer book")
+	}
+	var entries []*peerBookEntry
+	if err := json.Unmarshal(enc, &entries); err != nil {
+		return nil, errors.Wrap(err, "could not decode peer book")
+	}
+
+	records := make([]*peers.PeerRecord, 0, len(entries))
+	for _, entry := range entries {
+		record, err := entryToPeerRecord(entry)
+		if err != nil {
+			log.WithError(err).WithField("peer", entry.ID).Debug("Skipping invalid peer book entry")
+			continue
+		}
+		records = append(records, record)
+	}
+	s.peers.RestorePeerBook(records)
+
+	dialable := make([]*peers.PeerRecord, 0, len(records))
+	for _, record := range records {
+		if record.Address != nil && !s.peers.IsBad(record.ID) {
+			dialable = append(dialable, record)
+		}
+	}
+	sort.Slice(dialable, func(i, j int) bool {
+		return dialable[i].Score > dialable[j].Score
+	})
+	if len(dialable) > int(s.cfg.MaxPeers) {
+		dialable = dialable[:s.cfg.MaxPeers]
+	}
+	log.WithField("peers", len(records)).Debug("Restored peer book")
+	return dialable, nil
+}
+
+// savePeerBook writes the peer book to the data directory.
+func (s *Service) savePeerBook() error {
+	if s.cfg.DataDir == "" {
+		return nil
+	}
+	records := s.peers.PeerBook(peerBookSize)
+	entries := make([]*peerBookEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, peerRecordToEntry(record))
+	}
+	enc, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "could not encode peer book")
+	}
+	if err := ioutil.WriteFile(path.Join(s.cfg.DataDir, peerBookPath), enc, params.BeaconIoConfig().ReadWritePermissions); err != nil {
+		return errors.Wrap(err, "could not write peer book")
+	}
+	return nil
+}
+
+// dialPeerBook connects to the given peer book records.
+func (s *Service) dialPeerBook(records []*peers.PeerRecord) {
+	for _, record := range records {
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(info); err != nil {
+				log.WithError(err).Tracef("Could not connect with peer %s", info.String())
+			}
+		}(peer.AddrInfo{ID: record.ID, Addrs: []ma.Multiaddr{record.Address}})
+	}
+}
+
+func peerRecordToEntry(record *peers.PeerRecord) *peerBookEntry {
+	entry := &peerBookEntry{
+		ID:    record.ID.String(),
+		Score: record.Score,
+	}
+	if !record.LastSeen.IsZero() {
+		entry.LastSeen = record.LastSeen.Unix()
+	}
+	if record.ENR != nil {
+		if node, err := enode.New(enode.ValidSchemes, record.ENR); err == nil {
+			entry.ENR = node.String()
+		}
+	}
+	if record.Address != nil {
+		entry.Address = record.Address.String()
+	}
+	if !record.BannedUntil.IsZero() {
+		entry.BannedUntil = record.BannedUntil.Unix()
+	}
+	return entry
+}
+
+func entryToPeerRecord(entry *peerBookEntry) (*peers.PeerRecord, error) {
+	pid, err := peer.Decode(entry.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode peer id")
+	}
+	record := &peers.PeerRecord{
+		ID:    pid,
+		Score: entry.Score,
+	}
+	if entry.LastSeen != 0 {
+		record.LastSeen = time.Unix(entry.LastSeen, 0)
+	}
+	if entry.ENR != "" {
+		node, err := enode.Parse(enode.ValidSchemes, entry.ENR)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse enr")
+		}
+		record.ENR = node.Record()
+	}
+	if entry.Address != "" {
+		addr, err := ma.NewMultiaddr(entry.Address)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse address")
+		}
+		record.Address = addr
+	}
+	if entry.BannedUntil != 0 {
+		record.BannedUntil = time.Unix(entry.BannedUntil, 0)
+	}
+	return record, nil
+}
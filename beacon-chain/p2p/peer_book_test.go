@@ -0,0 +1,91 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestPeerBook_SaveAndLoad(t *testing.T) {
+	tempPath := path.Join(testutil.TempDir(), strconv.Itoa(int(time.Now().UnixNano())))
+	require.NoError(t, os.Mkdir(tempPath, 0700))
+	defer func() {
+		require.NoError(t, os.RemoveAll(tempPath))
+	}()
+	newService := func() *Service {
+		return &Service{
+			cfg: &Config{DataDir: tempPath, MaxPeers: 30},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit:    30,
+				ScorerParams: &peers.PeerScorerConfig{},
+			}),
+		}
+	}
+	newPeerID := func() peer.ID {
+		key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+		return pid
+	}
+
+	_, pkey := createAddrAndPrivKey(t)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	record := enode.NewLocalNode(db, pkey).Node().Record()
+	addr, err := multiaddr.NewMultiaddr("/ip4/212.67.10.122/tcp/13000")
+	require.NoError(t, err)
+
+	s := newService()
+	// A peer which has been connected.
+	seenPeer := newPeerID()
+	s.peers.Add(record, seenPeer, addr, network.DirOutbound)
+	s.peers.SetConnectionState(seenPeer, peers.PeerConnected)
+	s.peers.SetConnectionState(seenPeer, peers.PeerDisconnected)
+	// A banned peer which has never been connected.
+	bannedPeer := newPeerID()
+	bannedUntil := time.Now().Add(time.Hour)
+	s.peers.Ban(bannedPeer, bannedUntil)
+	// A peer which has never been connected.
+	unseenPeer := newPeerID()
+	s.peers.Add(nil, unseenPeer, addr, network.DirInbound)
+	require.NoError(t, s.savePeerBook())
+
+	s = newService()
+	dialable, err := s.loadPeerBook()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(dialable), "Expected only the seen peer to be dialed")
+	assert.Equal(t, seenPeer, dialable[0].ID)
+	assert.Equal(t, addr.String(), dialable[0].Address.String())
+	restoredRecord, err := s.peers.ENR(seenPeer)
+	require.NoError(t, err)
+	assert.Equal(t, record.Seq(), restoredRecord.Seq())
+
+	assert.Equal(t, true, s.peers.IsBanned(bannedPeer))
+	assert.Equal(t, bannedUntil.Unix(), s.peers.Banned()[bannedPeer].Unix())
+	_, err = s.peers.Address(unseenPeer)
+	assert.ErrorContains(t, peers.ErrPeerUnknown.Error(), err)
+}
+
+func TestPeerBook_NoFile(t *testing.T) {
+	s := &Service{
+		cfg: &Config{DataDir: path.Join(testutil.TempDir(), "nonexistent")},
+	}
+	dialable, err := s.loadPeerBook()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(dialable))
+}
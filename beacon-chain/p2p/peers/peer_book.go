@@ -0,0 +1,101 @@
+package peers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+// PeerRecord is an entry of the peer book, the part of the peer status which is kept across
+// restarts of the node.
+type PeerRecord struct {
+	ID          peer.ID
+	ENR         *enr.Record
+	Address     ma.Multiaddr
+	Score       float64
+	LastSeen    time.Time
+	BannedUntil time.Time
+}
+
+// PeerBook returns the records of the most recently seen peers, up to the given limit, along with the
+// records of all the banned peers. Peers which have never been connected are not recorded. The
+// scorers are not persisted, so peers which are bad because of their scores are recorded as banned
+// until their scores would have decayed.
+func (p *Status) PeerBook(limit int) []*PeerRecord {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := roughtime.Now()
+	seen := make([]*PeerRecord, 0)
+	banned := make([]*PeerRecord, 0)
+	for pid, peerData := range p.store.peers {
+		record := &PeerRecord{
+			ID:          pid,
+			ENR:         peerData.enr,
+			Address:     peerData.address,
+			Score:       p.scorers.score(pid),
+			LastSeen:    peerData.lastSeen,
+			BannedUntil: p.bannedUntil(pid, now),
+		}
+		switch {
+		case now.Before(record.BannedUntil):
+			banned = append(banned, record)
+		case !peerData.lastSeen.IsZero():
+			seen = append(seen, record)
+		}
+	}
+
+	sort.Slice(seen, func(i, j int) bool {
+		return seen[i].LastSeen.After(seen[j].LastSeen)
+	})
+	if len(seen) > limit {
+		seen = seen[:limit]
+	}
+	return append(banned, seen...)
+}
+
+// This returns the latest of the ban of the peer and of the times its scorers stop deeming it bad.
+// Trusted peers are never bad because of their scores. This is lock-free.
+func (p *Status) bannedUntil(pid peer.ID, now time.Time) time.Time {
+	until := p.store.peers[pid].bannedUntil
+	if p.store.trustedPeers[pid] {
+		return until
+	}
+	for _, scorerUntil := range []time.Time{
+		p.scorers.BadResponsesScorer().badUntil(pid, now),
+		p.scorers.GossipScorer().badUntil(pid, now),
+	} {
+		if scorerUntil.After(until) {
+			until = scorerUntil
+		}
+	}
+	return until
+}
+
+// RestorePeerBook adds the peers of the given records as disconnected peers, and restores the bans
+// which have not expired yet. Peers which are already known are left untouched.
+func (p *Status) RestorePeerBook(records []*PeerRecord) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := roughtime.Now()
+	for _, record := range records {
+		if _, ok := p.store.peers[record.ID]; ok {
+			continue
+		}
+		peerData := &peerData{
+			address:   record.Address,
+			enr:       record.ENR,
+			connState: PeerDisconnected,
+			lastSeen:  record.LastSeen,
+		}
+		if now.Before(record.BannedUntil) {
+			peerData.bannedUntil = record.BannedUntil
+		}
+		p.store.peers[record.ID] = peerData
+	}
+}
@@ -129,6 +129,16 @@ func (s *BadResponsesScorer) isBadPeer(pid peer.ID) bool {
 	return false
 }
 
+// badUntil returns the time at which a bad peer stops being bad if it sends no more bad responses,
+// or the zero time if the peer is not bad. This is lock-free.
+func (s *BadResponsesScorer) badUntil(pid peer.ID, now time.Time) time.Time {
+	if !s.isBadPeer(pid) {
+		return time.Time{}
+	}
+	decays := s.store.peers[pid].badResponses - s.config.Threshold + 1
+	return now.Add(time.Duration(decays) * s.config.DecayInterval)
+}
+
 // BadPeers returns the peers that are bad.
 func (s *BadResponsesScorer) BadPeers() []peer.ID {
 	s.store.RLock()
@@ -0,0 +1,200 @@
+package peers
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// DefaultGossipRejectedThreshold defines how many rejected gossip messages to tolerate before peer is deemed bad.
+	DefaultGossipRejectedThreshold = 16
+	// DefaultGossipRejectedWeight is a default weight of rejected messages. Since it is a penalty, it has negative weight.
+	DefaultGossipRejectedWeight = -1.0
+	// DefaultGossipAcceptedWeight is a default reward of a peer which reached the accepted messages cap.
+	DefaultGossipAcceptedWeight = 0.1
+	// DefaultGossipAcceptedCap defines the highest number of accepted messages that are counted towards peer's score.
+	DefaultGossipAcceptedCap = uint64(1000)
+	// DefaultGossipDecayInterval defines how often to decay previous statistics.
+	// Every interval rejected messages counter is decremented by 1, and accepted and ignored
+	// messages counters are halved.
+	DefaultGossipDecayInterval = 10 * time.Minute
+)
+
+// GossipValidationOutcome is the outcome of the validation of a gossip message forwarded by a peer.
+type GossipValidationOutcome int
+
+const (
+	// GossipAccepted means the message was valid and has been forwarded.
+	GossipAccepted GossipValidationOutcome = iota
+	// GossipIgnored means the message was dropped without being invalid, e.g. as a duplicate.
+	GossipIgnored
+	// GossipRejected means the message was invalid.
+	GossipRejected
+)
+
+// GossipScorer represents gossip validation scoring service.
+type GossipScorer struct {
+	ctx    context.Context
+	config *GossipScorerConfig
+	store  *peerDataStore
+}
+
+// GossipScorerConfig holds configuration parameters for gossip validation scoring service.
+type GossipScorerConfig struct {
+	// RejectedThreshold specifies number of rejected messages tolerated, before peer is banned.
+	RejectedThreshold int
+	// RejectedWeight defines weight of rejected messages/threshold ratio on overall score.
+	RejectedWeight float64
+	// AcceptedWeight defines the reward of a peer which reached the accepted messages cap.
+	AcceptedWeight float64
+	// AcceptedCap defines the highest number of accepted messages that are counted towards peer's score.
+	AcceptedCap uint64
+	// DecayInterval specifies how often gossip stats should be decayed.
+	DecayInterval time.Duration
+}
+
+// newGossipScorer creates new gossip validation scoring service.
+func newGossipScorer(ctx context.Context, store *peerDataStore, config *GossipScorerConfig) *GossipScorer {
+	if config == nil {
+		config = &GossipScorerConfig{}
+	}
+	scorer := &GossipScorer{
+		ctx:    ctx,
+		config: config,
+		store:  store,
+	}
+	if scorer.config.RejectedThreshold == 0 {
+		scorer.config.RejectedThreshold = DefaultGossipRejectedThreshold
+	}
+	if scorer.config.RejectedWeight == 0.0 {
+		scorer.config.RejectedWeight = DefaultGossipRejectedWeight
+	}
+	if scorer.config.AcceptedWeight == 0.0 {
+		scorer.config.AcceptedWeight = DefaultGossipAcceptedWeight
+	}
+	if scorer.config.AcceptedCap == 0 {
+		scorer.config.AcceptedCap = DefaultGossipAcceptedCap
+	}
+	if scorer.config.DecayInterval == 0 {
+		scorer.config.DecayInterval = DefaultGossipDecayInterval
+	}
+	return scorer
+}
+
+// Score returns score of the gossip messages peer forwarded.
+func (s *GossipScorer) Score(pid peer.ID) float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.score(pid)
+}
+
+// score is a lock-free version of Score.
+func (s *GossipScorer) score(pid peer.ID) float64 {
+	peerData, ok := s.store.peers[pid]
+	if !ok {
+		return 0
+	}
+	score := float64(0)
+	if peerData.gossipAccepted > 0 {
+		accepted := math.Min(peerData.gossipAccepted, float64(s.config.AcceptedCap))
+		score += accepted / float64(s.config.AcceptedCap) * s.config.AcceptedWeight
+	}
+	if peerData.gossipRejected > 0 {
+		score += float64(peerData.gossipRejected) / float64(s.config.RejectedThreshold) * s.config.RejectedWeight
+	}
+	return score
+}
+
+// Params exposes scorer's parameters.
+func (s *GossipScorer) Params() *GossipScorerConfig {
+	return s.config
+}
+
+// Record records the outcome of the validation of a gossip message forwarded by the given remote peer.
+func (s *GossipScorer) Record(pid peer.ID, outcome GossipValidationOutcome) {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	if _, ok := s.store.peers[pid]; !ok {
+		s.store.peers[pid] = &peerData{}
+	}
+	peerData := s.store.peers[pid]
+	switch outcome {
+	case GossipAccepted:
+		peerData.gossipAccepted++
+	case GossipIgnored:
+		peerData.gossipIgnored++
+	case GossipRejected:
+		peerData.gossipRejected++
+	}
+}
+
+// Counts returns the decayed numbers of accepted, ignored and rejected gossip messages forwarded by
+// the given remote peer.
+func (s *GossipScorer) Counts(pid peer.ID) (accepted float64, ignored float64, rejected int, err error) {
+	s.store.RLock()
+	defer s.store.RUnlock()
+
+	if peerData, ok := s.store.peers[pid]; ok {
+		return peerData.gossipAccepted, peerData.gossipIgnored, peerData.gossipRejected, nil
+	}
+	return 0, 0, -1, ErrPeerUnknown
+}
+
+// IsBadPeer states if the peer is to be considered bad for forwarding too many invalid messages.
+// If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
+func (s *GossipScorer) IsBadPeer(pid peer.ID) bool {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.isBadPeer(pid)
+}
+
+// isBadPeer is lock-free version of IsBadPeer.
+func (s *GossipScorer) isBadPeer(pid peer.ID) bool {
+	if peerData, ok := s.store.peers[pid]; ok {
+		return peerData.gossipRejected >= s.config.RejectedThreshold
+	}
+	return false
+}
+
+// badUntil returns the time at which a bad peer stops being bad if it forwards no more rejected
+// messages, or the zero time if the peer is not bad. This is lock-free.
+func (s *GossipScorer) badUntil(pid peer.ID, now time.Time) time.Time {
+	if !s.isBadPeer(pid) {
+		return time.Time{}
+	}
+	decays := s.store.peers[pid].gossipRejected - s.config.RejectedThreshold + 1
+	return now.Add(time.Duration(decays) * s.config.DecayInterval)
+}
+
+// BadPeers returns the peers that are bad.
+func (s *GossipScorer) BadPeers() []peer.ID {
+	s.store.RLock()
+	defer s.store.RUnlock()
+
+	badPeers := make([]peer.ID, 0)
+	for pid := range s.store.peers {
+		if s.isBadPeer(pid) {
+			badPeers = append(badPeers, pid)
+		}
+	}
+	return badPeers
+}
+
+// Decay reduces the gossip stats of all peers. Bad peers are banned until enough decay intervals
+// pass for their rejected messages to fall back below the threshold.
+func (s *GossipScorer) Decay() {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	for _, peerData := range s.store.peers {
+		if peerData.gossipRejected > 0 {
+			peerData.gossipRejected--
+		}
+		peerData.gossipAccepted /= 2
+		peerData.gossipIgnored /= 2
+	}
+}
@@ -0,0 +1,110 @@
+package peers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestPeerScorer_Gossip_Score(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &peers.PeerScorerConfig{
+			GossipScorerConfig: &peers.GossipScorerConfig{
+				RejectedThreshold: 4,
+				AcceptedWeight:    0.5,
+				AcceptedCap:       4,
+			},
+		},
+	})
+	scorer := peerStatuses.Scorers().GossipScorer()
+
+	assert.Equal(t, 0.0, scorer.Score("peer1"), "Unexpected score for unregistered peer")
+	scorer.Record("peer1", peers.GossipIgnored)
+	assert.Equal(t, 0.0, scorer.Score("peer1"), "Ignored messages should not be scored")
+	scorer.Record("peer1", peers.GossipAccepted)
+	scorer.Record("peer1", peers.GossipAccepted)
+	assert.Equal(t, 0.25, scorer.Score("peer1"))
+	for i := 0; i < 10; i++ {
+		scorer.Record("peer1", peers.GossipAccepted)
+	}
+	assert.Equal(t, 0.5, scorer.Score("peer1"), "Accepted messages over the cap should not be scored")
+
+	scorer.Record("peer1", peers.GossipRejected)
+	assert.Equal(t, 0.25, scorer.Score("peer1"))
+	scorer.Record("peer1", peers.GossipRejected)
+	scorer.Record("peer1", peers.GossipRejected)
+	assert.Equal(t, false, scorer.IsBadPeer("peer1"))
+	scorer.Record("peer1", peers.GossipRejected)
+	assert.Equal(t, -0.5, scorer.Score("peer1"))
+	assert.Equal(t, true, scorer.IsBadPeer("peer1"))
+}
+
+func TestPeerScorer_Gossip_Counts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+	scorer := peerStatuses.Scorers().GossipScorer()
+
+	pid := peer.ID("peer1")
+	_, _, _, err := scorer.Counts(pid)
+	assert.ErrorContains(t, peers.ErrPeerUnknown.Error(), err)
+
+	peerStatuses.Add(nil, pid, nil, network.DirUnknown)
+	scorer.Record(pid, peers.GossipAccepted)
+	scorer.Record(pid, peers.GossipIgnored)
+	scorer.Record(pid, peers.GossipIgnored)
+	scorer.Record(pid, peers.GossipRejected)
+	accepted, ignored, rejected, err := scorer.Counts(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, accepted)
+	assert.Equal(t, 2.0, ignored)
+	assert.Equal(t, 1, rejected)
+}
+
+func TestPeerScorer_Gossip_Decay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &peers.PeerScorerConfig{
+			GossipScorerConfig: &peers.GossipScorerConfig{
+				RejectedThreshold: 2,
+			},
+		},
+	})
+	scorer := peerStatuses.Scorers().GossipScorer()
+
+	pid := peer.ID("peer1")
+	peerStatuses.Add(nil, pid, nil, network.DirUnknown)
+	for i := 0; i < 8; i++ {
+		scorer.Record(pid, peers.GossipAccepted)
+	}
+	scorer.Record(pid, peers.GossipIgnored)
+	scorer.Record(pid, peers.GossipRejected)
+	scorer.Record(pid, peers.GossipRejected)
+	assert.Equal(t, true, peerStatuses.IsBad(pid))
+	assert.DeepEqual(t, []peer.ID{pid}, peerStatuses.Bad())
+
+	scorer.Decay()
+	accepted, ignored, rejected, err := scorer.Counts(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, accepted)
+	assert.Equal(t, 0.5, ignored)
+	assert.Equal(t, 1, rejected)
+	assert.Equal(t, false, peerStatuses.IsBad(pid), "Peer should not be banned after decay")
+	assert.Equal(t, 0, len(peerStatuses.Bad()))
+}
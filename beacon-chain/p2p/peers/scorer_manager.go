@@ -19,6 +19,7 @@ type PeerScorerManager struct {
 	scorers struct {
 		badResponsesScorer  *BadResponsesScorer
 		blockProviderScorer *BlockProviderScorer
+		gossipScorer        *GossipScorer
 	}
 }
 
@@ -26,6 +27,7 @@ type PeerScorerManager struct {
 type PeerScorerConfig struct {
 	BadResponsesScorerConfig  *BadResponsesScorerConfig
 	BlockProviderScorerConfig *BlockProviderScorerConfig
+	GossipScorerConfig        *GossipScorerConfig
 }
 
 // newPeerScorerManager provides fully initialized peer scoring service.
@@ -36,6 +38,7 @@ func newPeerScorerManager(ctx context.Context, store *peerDataStore, config *Pee
 	}
 	mgr.scorers.badResponsesScorer = newBadResponsesScorer(ctx, store, config.BadResponsesScorerConfig)
 	mgr.scorers.blockProviderScorer = newBlockProviderScorer(ctx, store, config.BlockProviderScorerConfig)
+	mgr.scorers.gossipScorer = newGossipScorer(ctx, store, config.GossipScorerConfig)
 	go mgr.loop(mgr.ctx)
 
 	return mgr
@@ -51,17 +54,27 @@ func (m *PeerScorerManager) BlockProviderScorer() *BlockProviderScorer {
 	return m.scorers.blockProviderScorer
 }
 
+// GossipScorer exposes gossip validation scoring service.
+func (m *PeerScorerManager) GossipScorer() *GossipScorer {
+	return m.scorers.gossipScorer
+}
+
 // Score returns calculated peer score across all tracked metrics.
 func (m *PeerScorerManager) Score(pid peer.ID) float64 {
 	m.store.RLock()
 	defer m.store.RUnlock()
+	return m.score(pid)
+}
 
+// score is a lock-free version of Score.
+func (m *PeerScorerManager) score(pid peer.ID) float64 {
 	score := float64(0)
 	if _, ok := m.store.peers[pid]; !ok {
 		return 0
 	}
 	score += m.scorers.badResponsesScorer.score(pid)
 	score += m.scorers.blockProviderScorer.score(pid)
+	score += m.scorers.gossipScorer.score(pid)
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
 }
 
@@ -71,6 +84,8 @@ func (m *PeerScorerManager) loop(ctx context.Context) {
 	defer decayBadResponsesStats.Stop()
 	decayBlockProviderStats := time.NewTicker(m.scorers.blockProviderScorer.Params().DecayInterval)
 	defer decayBlockProviderStats.Stop()
+	decayGossipStats := time.NewTicker(m.scorers.gossipScorer.Params().DecayInterval)
+	defer decayGossipStats.Stop()
 
 	for {
 		select {
@@ -78,6 +93,8 @@ func (m *PeerScorerManager) loop(ctx context.Context) {
 			m.scorers.badResponsesScorer.Decay()
 		case <-decayBlockProviderStats.C:
 			m.scorers.blockProviderScorer.Decay()
+		case <-decayGossipStats.C:
+			m.scorers.gossipScorer.Decay()
 		case <-ctx.Done():
 			return
 		}
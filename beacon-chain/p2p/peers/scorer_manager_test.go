@@ -39,6 +39,15 @@ func TestPeerScorer_PeerScorerManager_Init(t *testing.T) {
 			assert.Equal(t, peers.DefaultBlockProviderDecay, params.Decay)
 			assert.Equal(t, peers.DefaultBlockProviderStalePeerRefreshInterval, params.StalePeerRefreshInterval)
 		})
+
+		t.Run("gossip scorer", func(t *testing.T) {
+			params := peerStatuses.Scorers().GossipScorer().Params()
+			assert.Equal(t, peers.DefaultGossipRejectedThreshold, params.RejectedThreshold)
+			assert.Equal(t, peers.DefaultGossipRejectedWeight, params.RejectedWeight)
+			assert.Equal(t, peers.DefaultGossipAcceptedWeight, params.AcceptedWeight)
+			assert.Equal(t, peers.DefaultGossipAcceptedCap, params.AcceptedCap)
+			assert.Equal(t, peers.DefaultGossipDecayInterval, params.DecayInterval)
+		})
 	})
 
 	t.Run("explicit config", func(t *testing.T) {
@@ -57,6 +66,13 @@ func TestPeerScorer_PeerScorerManager_Init(t *testing.T) {
 					Decay:                    16,
 					StalePeerRefreshInterval: 5 * time.Hour,
 				},
+				GossipScorerConfig: &peers.GossipScorerConfig{
+					RejectedThreshold: 8,
+					RejectedWeight:    -2.0,
+					AcceptedWeight:    0.5,
+					AcceptedCap:       100,
+					DecayInterval:     1 * time.Minute,
+				},
 			},
 		})
 
@@ -76,6 +92,15 @@ func TestPeerScorer_PeerScorerManager_Init(t *testing.T) {
 			assert.Equal(t, 5*time.Hour, params.StalePeerRefreshInterval)
 			assert.Equal(t, 1.0, peerStatuses.Scorers().BlockProviderScorer().MaxScore())
 		})
+
+		t.Run("gossip scorer", func(t *testing.T) {
+			params := peerStatuses.Scorers().GossipScorer().Params()
+			assert.Equal(t, 8, params.RejectedThreshold)
+			assert.Equal(t, -2.0, params.RejectedWeight)
+			assert.Equal(t, 0.5, params.AcceptedWeight)
+			assert.Equal(t, uint64(100), params.AcceptedCap)
+			assert.Equal(t, 1*time.Minute, params.DecayInterval)
+		})
 	})
 }
 
@@ -185,6 +210,20 @@ func TestPeerScorer_PeerScorerManager_Score(t *testing.T) {
 		assert.DeepEqual(t, pack(s, 0, 0.05*3, 0), peerScores(s, pids), "Unexpected scores")
 	})
 
+	t.Run("gossip score", func(t *testing.T) {
+		s, pids := setupScorer()
+		zeroScore := s.BlockProviderScorer().MaxScore()
+		rejectedPenalty := -1.0 / float64(peers.DefaultGossipRejectedThreshold)
+
+		s.GossipScorer().Record("peer1", peers.GossipRejected)
+		s.GossipScorer().Record("peer2", peers.GossipIgnored)
+		assert.DeepEqual(t, pack(s, zeroScore+rejectedPenalty, zeroScore, zeroScore), peerScores(s, pids), "Unexpected scores")
+
+		// See how decaying affects order of peers.
+		s.GossipScorer().Decay()
+		assert.DeepEqual(t, pack(s, zeroScore, zeroScore, zeroScore), peerScores(s, pids), "Unexpected scores")
+	})
+
 	t.Run("overall score", func(t *testing.T) {
 		// Full score, no penalty.
 		s, _ := setupScorer()
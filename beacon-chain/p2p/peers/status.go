@@ -72,6 +72,8 @@ type StatusConfig struct {
 	PeerLimit int
 	// ScorerParams holds peer scorer configuration params.
 	ScorerParams *PeerScorerConfig
+	// TrustedPeers are never considered bad or pruned, and do not count towards the peer limit.
+	TrustedPeers []peer.ID
 }
 
 // NewStatus creates a new status entity.
@@ -79,6 +81,9 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 	store := newPeerDataStore(ctx, &peerDataStoreConfig{
 		maxPeers: maxLimitBuffer + config.PeerLimit,
 	})
+	for _, pid := range config.TrustedPeers {
+		store.trustedPeers[pid] = true
+	}
 	return &Status{
 		ctx:     ctx,
 		store:   store,
@@ -211,6 +216,29 @@ func (p *Status) Metadata(pid peer.ID) (*pb.MetaData, error) {
 	return nil, ErrPeerUnknown
 }
 
+// SetGossipScore sets the gossipsub score of the given remote peer. Scores of unknown peers are
+// not recorded.
+func (p *Status) SetGossipScore(pid peer.ID, score float64) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if peerData, ok := p.store.peers[pid]; ok {
+		peerData.gossipScore = score
+	}
+}
+
+// GossipScore returns the last gossipsub score of the given remote peer.
+// This will error if the peer does not exist.
+func (p *Status) GossipScore(pid peer.ID) (float64, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.peers[pid]; ok {
+		return peerData.gossipScore, nil
+	}
+	return 0, ErrPeerUnknown
+}
+
 // CommitteeIndices retrieves the committee subnets the peer is subscribed to.
 func (p *Status) CommitteeIndices(pid peer.ID) ([]uint64, error) {
 	p.store.RLock()
@@ -254,6 +282,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state PeerConnectionState) {
 	defer p.store.Unlock()
 
 	peerData := p.fetch(pid)
+	if state == PeerConnected || peerData.connState == PeerConnected {
+		peerData.lastSeen = roughtime.Now()
+	}
 	peerData.connState = state
 }
 
@@ -284,7 +315,86 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 // IsBad states if the peer is to be considered bad.
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
 func (p *Status) IsBad(pid peer.ID) bool {
-	return p.scorers.BadResponsesScorer().IsBadPeer(pid)
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isBad(pid)
+}
+
+// isBad is the lock-free version of IsBad.
+func (p *Status) isBad(pid peer.ID) bool {
+	if p.store.trustedPeers[pid] {
+		return false
+	}
+	return p.isBanned(pid) || p.scorers.BadResponsesScorer().isBadPeer(pid) || p.scorers.GossipScorer().isBadPeer(pid)
+}
+
+// IsTrusted states if the peer is one of the configured trusted peers.
+func (p *Status) IsTrusted(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.trustedPeers[pid]
+}
+
+// Trusted returns the configured trusted peers.
+func (p *Status) Trusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peers := make([]peer.ID, 0, len(p.store.trustedPeers))
+	for pid := range p.store.trustedPeers {
+		peers = append(peers, pid)
+	}
+	return peers
+}
+
+// Ban bans the given remote peer until the given time, a banned peer is considered bad.
+func (p *Status) Ban(pid peer.ID, until time.Time) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.fetch(pid)
+	peerData.bannedUntil = until
+}
+
+// Unban lifts the ban of the given remote peer.
+// This will error if the peer does not exist.
+func (p *Status) Unban(pid peer.ID) error {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if peerData, ok := p.store.peers[pid]; ok {
+		peerData.bannedUntil = time.Time{}
+		return nil
+	}
+	return ErrPeerUnknown
+}
+
+// IsBanned states if the peer is currently banned.
+// If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
+func (p *Status) IsBanned(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isBanned(pid)
+}
+
+// isBanned is the lock-free version of IsBanned.
+func (p *Status) isBanned(pid peer.ID) bool {
+	if peerData, ok := p.store.peers[pid]; ok {
+		return roughtime.Now().Before(peerData.bannedUntil)
+	}
+	return false
+}
+
+// Banned returns the peers that are currently banned, along with the time their ban is lifted.
+func (p *Status) Banned() map[peer.ID]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	bans := make(map[peer.ID]time.Time)
+	for pid, peerData := range p.store.peers {
+		if p.isBanned(pid) {
+			bans[pid] = peerData.bannedUntil
+		}
+	}
+	return bans
 }
 
 // Connecting returns the peers that are connecting.
@@ -367,7 +477,15 @@ func (p *Status) Inactive() []peer.ID {
 
 // Bad returns the peers that are bad.
 func (p *Status) Bad() []peer.ID {
-	return p.scorers.BadResponsesScorer().BadPeers()
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peers := make([]peer.ID, 0)
+	for pid := range p.store.peers {
+		if p.isBad(pid) {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
 }
 
 // All returns all the peers regardless of state.
@@ -396,9 +514,9 @@ func (p *Status) Prune() {
 		badResp int
 	}
 	peersToPrune := make([]*peerResp, 0)
-	// Select disconnected peers with a smaller bad response count.
+	// Select disconnected peers with a smaller bad response count, trusted peers are never pruned.
 	for pid, peerData := range p.store.peers {
-		if peerData.connState == PeerDisconnected && !p.scorers.BadResponsesScorer().isBadPeer(pid) {
+		if peerData.connState == PeerDisconnected && !p.isBad(pid) && !p.store.trustedPeers[pid] {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: p.store.peers[pid].badResponses,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -374,6 +375,24 @@ func TestAddMetaData(t *testing.T) {
 	assert.Equal(t, newMetaData.SeqNumber, md.SeqNumber, "Unexpected sequence number")
 }
 
+func TestPeerGossipScore(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+
+	pid := addPeer(t, p, peers.PeerConnected)
+	p.SetGossipScore(pid, -12.5)
+	score, err := p.GossipScore(pid)
+	require.NoError(t, err)
+	assert.Equal(t, -12.5, score)
+
+	// Scores of unknown peers are not recorded.
+	p.SetGossipScore("unknown", 10)
+	_, err = p.GossipScore("unknown")
+	assert.ErrorContains(t, peers.ErrPeerUnknown.Error(), err)
+}
+
 func TestPeerConnectionStatuses(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
@@ -416,6 +435,133 @@ func TestPeerConnectionStatuses(t *testing.T) {
 	assert.Equal(t, numPeersAll, len(p.All()), "Unexpected number of peers")
 }
 
+func TestPeerBan(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+
+	id := addPeer(t, p, peers.PeerConnected)
+	assert.Equal(t, false, p.IsBanned(id))
+	assert.Equal(t, false, p.IsBad(id))
+	assert.ErrorContains(t, peers.ErrPeerUnknown.Error(), p.Unban("unknown"))
+
+	until := time.Now().Add(time.Hour)
+	p.Ban(id, until)
+	assert.Equal(t, true, p.IsBanned(id))
+	assert.Equal(t, true, p.IsBad(id), "Banned peer should be bad")
+	assert.DeepEqual(t, []peer.ID{id}, p.Bad())
+	assert.Equal(t, until, p.Banned()[id])
+
+	require.NoError(t, p.Unban(id))
+	assert.Equal(t, false, p.IsBanned(id))
+	assert.Equal(t, 0, len(p.Banned()))
+
+	// Bans expire.
+	p.Ban(id, time.Now().Add(-time.Second))
+	assert.Equal(t, false, p.IsBanned(id))
+}
+
+func TestPeerBook(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+
+	// Peers which have never been connected are not recorded.
+	_ = addPeer(t, p, peers.PeerDisconnected)
+	assert.Equal(t, 0, len(p.PeerBook(10)))
+
+	first := addPeer(t, p, peers.PeerConnected)
+	time.Sleep(10 * time.Millisecond)
+	second := addPeer(t, p, peers.PeerConnected)
+	banned := addPeer(t, p, peers.PeerDisconnected)
+	p.Ban(banned, time.Now().Add(time.Hour))
+
+	// Banned peers are kept over the limit, the most recently seen peers are kept first.
+	records := p.PeerBook(1)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, banned, records[0].ID)
+	assert.Equal(t, second, records[1].ID)
+	records = p.PeerBook(10)
+	require.Equal(t, 3, len(records))
+	assert.Equal(t, first, records[2].ID)
+
+	restored := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+	expired := &peers.PeerRecord{ID: "expired", BannedUntil: time.Now().Add(-time.Hour)}
+	restored.RestorePeerBook(append(records, expired))
+	assert.Equal(t, 4, len(restored.All()))
+	assert.Equal(t, 4, len(restored.Disconnected()))
+	assert.Equal(t, true, restored.IsBanned(banned))
+	assert.Equal(t, false, restored.IsBanned("expired"), "Expired ban should not be restored")
+}
+
+func TestPeerBook_ScorerBans(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &peers.PeerScorerConfig{
+			BadResponsesScorerConfig: &peers.BadResponsesScorerConfig{
+				Threshold:     2,
+				DecayInterval: time.Hour,
+			},
+		},
+	})
+	bad := addPeer(t, p, peers.PeerConnected)
+	for i := 0; i < 3; i++ {
+		p.Scorers().BadResponsesScorer().Increment(bad)
+	}
+	good := addPeer(t, p, peers.PeerConnected)
+
+	records := p.PeerBook(10)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, bad, records[0].ID, "Expected the bad peer to be recorded as banned")
+	// Two decays bring the bad responses back below the threshold.
+	assert.Equal(t, true, records[0].BannedUntil.After(time.Now().Add(time.Hour)))
+	assert.Equal(t, true, records[0].BannedUntil.Before(time.Now().Add(2*time.Hour+time.Minute)))
+	assert.Equal(t, good, records[1].ID)
+	assert.Equal(t, true, records[1].BannedUntil.IsZero())
+
+	restored := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &peers.PeerScorerConfig{},
+	})
+	restored.RestorePeerBook(records)
+	assert.Equal(t, true, restored.IsBad(bad), "Expected the scorer ban to survive a restart")
+	assert.Equal(t, false, restored.IsBad(good))
+}
+
+func TestTrustedPeers(t *testing.T) {
+	trusted := peer.ID("trusted")
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 0,
+		ScorerParams: &peers.PeerScorerConfig{
+			BadResponsesScorerConfig: &peers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+		TrustedPeers: []peer.ID{trusted},
+	})
+	assert.Equal(t, true, p.IsTrusted(trusted))
+	assert.DeepEqual(t, []peer.ID{trusted}, p.Trusted())
+
+	p.Add(nil, trusted, nil, network.DirOutbound)
+	p.Scorers().BadResponsesScorer().Increment(trusted)
+	assert.Equal(t, false, p.IsBad(trusted), "Trusted peer should never be bad")
+	assert.Equal(t, 0, len(p.Bad()))
+
+	// Trusted peers are never pruned.
+	for i := 0; i < p.MaxPeerLimit()+10; i++ {
+		_ = addPeer(t, p, peers.PeerDisconnected)
+	}
+	p.Prune()
+	assert.Equal(t, p.MaxPeerLimit(), len(p.All()))
+	_, err := p.ConnectionState(trusted)
+	assert.NoError(t, err, "Trusted peer should not be pruned")
+}
+
 func TestPrune(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
@@ -440,6 +586,7 @@ func TestPrune(t *testing.T) {
 	firstPID := disPeers[0]
 	secondPID := disPeers[1]
 	thirdPID := disPeers[2]
+	fourthPID := disPeers[3]
 
 	scorer := p.Scorers().BadResponsesScorer()
 
@@ -450,6 +597,11 @@ func TestPrune(t *testing.T) {
 	// Add bad response for p2.
 	scorer.Increment(secondPID)
 
+	// Make fourth peer a bad gossip peer.
+	for i := 0; i < peers.DefaultGossipRejectedThreshold; i++ {
+		p.Scorers().GossipScorer().Record(fourthPID, peers.GossipRejected)
+	}
+
 	// Prune peers
 	p.Prune()
 
@@ -466,6 +618,11 @@ func TestPrune(t *testing.T) {
 	// Last peer has been removed.
 	badRes, err = scorer.Count(thirdPID)
 	assert.NotNil(t, err, "error is supposed to be not nil")
+
+	// Bad gossip peer is expected to still be kept in handler.
+	_, _, rejected, err := p.Scorers().GossipScorer().Counts(fourthPID)
+	assert.NoError(t, err)
+	assert.Equal(t, peers.DefaultGossipRejectedThreshold, rejected)
 }
 
 func TestTrimmedOrderedPeers(t *testing.T) {
@@ -17,9 +17,10 @@ import (
 // different components rely on the very same peer map container.
 type peerDataStore struct {
 	sync.RWMutex
-	ctx    context.Context
-	config *peerDataStoreConfig
-	peers  map[peer.ID]*peerData
+	ctx          context.Context
+	config       *peerDataStoreConfig
+	peers        map[peer.ID]*peerData
+	trustedPeers map[peer.ID]bool
 }
 
 // peerDataStoreConfig holds peer store parameters.
@@ -39,13 +40,20 @@ type peerData struct {
 	badResponses          int
 	processedBlocks       uint64
 	blockProviderUpdated  time.Time
+	gossipScore           float64
+	gossipAccepted        float64
+	gossipIgnored         float64
+	gossipRejected        int
+	lastSeen              time.Time
+	bannedUntil           time.Time
 }
 
 // newPeerDataStore creates peer store.
 func newPeerDataStore(ctx context.Context, config *peerDataStoreConfig) *peerDataStore {
 	return &peerDataStore{
-		ctx:    ctx,
-		config: config,
-		peers:  make(map[peer.ID]*peerData),
+		ctx:          ctx,
+		config:       config,
+		peers:        make(map[peer.ID]*peerData),
+		trustedPeers: make(map[peer.ID]bool),
 	}
 }
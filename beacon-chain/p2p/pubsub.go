@@ -5,11 +5,18 @@ import (
 	"encoding/base64"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
 )
 
+// peerScoreInspectInterval is how often the gossipsub peer scores are recorded.
+var peerScoreInspectInterval = 10 * time.Second
+
 // JoinTopic will join PubSub topic, if not already joined.
 func (s *Service) JoinTopic(topic string, opts ...pubsub.TopicOpt) (*pubsub.Topic, error) {
 	s.joinedTopicsLock.Lock()
@@ -20,6 +27,13 @@ func (s *Service) JoinTopic(topic string, opts ...pubsub.TopicOpt) (*pubsub.Topi
 		if err != nil {
 			return nil, err
 		}
+		// The topic is only stored once its score params are set, so that a failed join can be retried.
+		if err := s.setTopicScoreParams(topic, topicHandle); err != nil {
+			if closeErr := topicHandle.Close(); closeErr != nil {
+				log.WithError(closeErr).Debug("Could not close topic")
+			}
+			return nil, err
+		}
 		s.joinedTopics[topic] = topicHandle
 	}
 
@@ -59,6 +73,93 @@ func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub
 	return topicHandle.Subscribe(opts...)
 }
 
+// TraceGossipMessage records a received gossip message, along with the result of its validation,
+// when gossip tracing is enabled. Messages published by this node are not recorded.
+func (s *Service) TraceGossipMessage(topic string, msg *pubsub.Message, result pubsub.ValidationResult) {
+	if s.gossipTracer == nil || msg.ReceivedFrom == s.PeerID() {
+		return
+	}
+	s.gossipTracer.trace(&GossipTraceRecord{
+		Topic:     topic,
+		Sender:    msg.ReceivedFrom.String(),
+		Timestamp: roughtime.Now(),
+		Result:    GossipTraceResult(result),
+		Data:      msg.Data,
+	})
+}
+
+// This sets the score parameters of a joined topic from the current number of active validators.
+func (s *Service) setTopicScoreParams(topic string, topicHandle *pubsub.Topic) error {
+	scoreParams := topicScoreParams(topic, s.activeValidators())
+	if scoreParams == nil {
+		return nil
+	}
+	return topicHandle.SetScoreParams(scoreParams)
+}
+
+// This recomputes the score parameters of the joined topics, as the expected message rates of the
+// attestation topics change with the number of active validators.
+func (s *Service) refreshTopicScoreParams() {
+	if err := s.updateActiveValidatorCount(); err != nil {
+		log.WithError(err).Debug("Could not update active validator count")
+		return
+	}
+	s.joinedTopicsLock.Lock()
+	defer s.joinedTopicsLock.Unlock()
+	for topic, topicHandle := range s.joinedTopics {
+		if err := s.setTopicScoreParams(topic, topicHandle); err != nil {
+			log.WithError(err).WithField("topic", topic).Error("Could not refresh topic score params")
+		}
+	}
+}
+
+// This returns the last known number of active validators.
+func (s *Service) activeValidators() uint64 {
+	s.activeValidatorsLock.RLock()
+	defer s.activeValidatorsLock.RUnlock()
+	return s.activeValidatorCount
+}
+
+// This updates the number of active validators from the last archived state, or the genesis state
+// when no state has been archived yet.
+func (s *Service) updateActiveValidatorCount() error {
+	if s.cfg.DB == nil {
+		return errors.New("no database configured")
+	}
+	st, err := s.cfg.DB.State(s.ctx, s.cfg.DB.LastArchivedRoot(s.ctx))
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		if st, err = s.cfg.DB.GenesisState(s.ctx); err != nil {
+			return err
+		}
+	}
+	if st == nil {
+		return errors.New("no state to count active validators from")
+	}
+	count, err := helpers.ActiveValidatorCount(st, helpers.CurrentEpoch(st))
+	if err != nil {
+		return err
+	}
+	s.activeValidatorsLock.Lock()
+	s.activeValidatorCount = count
+	s.activeValidatorsLock.Unlock()
+	return nil
+}
+
+// This records the gossipsub scores of the peers in the peer status and in metrics. The gauges of
+// disconnected peers are dropped.
+func (s *Service) inspectPeerScores(scores map[peer.ID]float64) {
+	gossipPeerScores.Reset()
+	for pid, score := range scores {
+		if s.peers != nil {
+			s.peers.SetGossipScore(pid, score)
+		}
+		gossipPeerScores.WithLabelValues(pid.String()).Set(score)
+	}
+}
+
 // Content addressable ID function.
 //
 // ETH2 spec defines the message ID as:
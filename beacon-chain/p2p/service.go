@@ -16,6 +16,7 @@ import (
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
@@ -29,6 +30,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/runutil"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
@@ -66,7 +68,12 @@ type Service struct {
 	cfg                   *Config
 	peers                 *peers.Status
 	addrFilter            *filter.Filters
+	allowedPeers          map[peer.ID]bool
+	deniedPeers           map[peer.ID]bool
+	trustedPeers          map[peer.ID]*peer.AddrInfo
 	ipLimiter             *leakybucket.Collector
+	bandwidthCounter      *metrics.BandwidthCounter
+	gossipTracer          *gossipTracer
 	privKey               *ecdsa.PrivateKey
 	exclusionList         *ristretto.Cache
 	metaData              *pb.MetaData
@@ -80,6 +87,8 @@ type Service struct {
 	host                  host.Host
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
+	activeValidatorCount  uint64
+	activeValidatorsLock  sync.RWMutex
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -127,7 +136,17 @@ func NewService(cfg *Config) (*Service, error) {
 		log.WithError(err).Error("Failed to create address filter")
 		return nil, err
 	}
+	s.allowedPeers, s.deniedPeers, s.trustedPeers, err = configurePeerLists(s.cfg)
+	if err != nil {
+		log.WithError(err).Error("Failed to configure peer lists")
+		return nil, err
+	}
+	trustedPeers := make([]peer.ID, 0, len(s.trustedPeers))
+	for pid := range s.trustedPeers {
+		trustedPeers = append(trustedPeers, pid)
+	}
 	s.ipLimiter = leakybucket.NewCollector(ipLimit, ipBurst, true /* deleteEmptyBuckets */)
+	s.bandwidthCounter = metrics.NewBandwidthCounter()
 
 	opts := s.buildOptions(ipAddr, s.privKey)
 	h, err := libp2p.New(s.ctx, opts...)
@@ -146,6 +165,8 @@ func NewService(cfg *Config) (*Service, error) {
 		pubsub.WithMessageSigning(false),
 		pubsub.WithStrictSignatureVerification(false),
 		pubsub.WithMessageIdFn(msgIDFunction),
+		pubsub.WithPeerScore(peerScoringParams()),
+		pubsub.WithPeerScoreInspect(s.inspectPeerScores, peerScoreInspectInterval),
 	}
 	// Set the pubsub global parameters that we require.
 	setPubSubParameters()
@@ -157,6 +178,14 @@ func NewService(cfg *Config) (*Service, error) {
 	}
 	s.pubsub = gs
 
+	if s.cfg.GossipTraceDir != "" {
+		s.gossipTracer, err = newGossipTracer(s.cfg.GossipTraceDir, s.cfg.GossipTraceSize, s.cfg.GossipTraceFiles)
+		if err != nil {
+			log.WithError(err).Error("Failed to create gossip tracer")
+			return nil, err
+		}
+	}
+
 	s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
 		PeerLimit: int(s.cfg.MaxPeers),
 		ScorerParams: &peers.PeerScorerConfig{
@@ -166,6 +195,7 @@ func NewService(cfg *Config) (*Service, error) {
 				DecayInterval: time.Hour,
 			},
 		},
+		TrustedPeers: trustedPeers,
 	})
 
 	return s, nil
@@ -182,6 +212,13 @@ func (s *Service) Start() {
 	// Used for fork-related data when connecting peers.
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
+	if err := s.updateActiveValidatorCount(); err != nil {
+		log.WithError(err).Debug("Could not update active validator count")
+	}
+	peerBook, err := s.loadPeerBook()
+	if err != nil {
+		log.WithError(err).Error("Could not load peer book")
+	}
 
 	var peersToWatch []string
 	if s.cfg.RelayNodeAddr != "" {
@@ -191,6 +228,9 @@ func (s *Service) Start() {
 		}
 	}
 
+	// Trusted peers are watched so that they are re-dialed on disconnect.
+	peersToWatch = append(peersToWatch, s.cfg.TrustedPeers...)
+
 	if !s.cfg.NoDiscovery && !s.cfg.DisableDiscv5 {
 		ipAddr := ipAddr()
 		listener, err := s.startDiscoveryV5(
@@ -221,6 +261,18 @@ func (s *Service) Start() {
 		}
 		s.connectWithAllPeers(addrs)
 	}
+	for _, info := range s.trustedPeers {
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(info); err != nil {
+				log.WithError(err).Errorf("Could not connect with trusted peer %s", info.String())
+			}
+		}(*info)
+	}
+	s.dialPeerBook(peerBook)
+
+	if s.gossipTracer != nil {
+		go s.gossipTracer.run(s.ctx)
+	}
 
 	// Periodic functions.
 	runutil.RunEvery(s.ctx, params.BeaconNetworkConfig().TtfbTimeout, func() {
@@ -231,6 +283,12 @@ func (s *Service) Start() {
 	runutil.RunEvery(s.ctx, refreshRate, func() {
 		s.RefreshENR()
 	})
+	runutil.RunEvery(s.ctx, oneEpochDuration(), s.refreshTopicScoreParams)
+	runutil.RunEvery(s.ctx, peerBookSaveInterval, func() {
+		if err := s.savePeerBook(); err != nil {
+			log.WithError(err).Error("Could not save peer book")
+		}
+	})
 
 	multiAddrs := s.host.Network().ListenAddresses()
 	logIPAddr(s.host.ID(), multiAddrs...)
@@ -256,6 +314,9 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	if err := s.savePeerBook(); err != nil {
+		log.WithError(err).Error("Could not save peer book")
+	}
 	return nil
 }
 
@@ -281,7 +342,9 @@ func (s *Service) Started() bool {
 
 // Encoding returns the configured networking encoding.
 func (s *Service) Encoding() encoder.NetworkEncoding {
-	return &encoder.SszNetworkEncoder{}
+	return &encoder.SszNetworkEncoder{
+		StrictSizeLimits: featureconfig.Get().EnableStrictSSZLimits,
+	}
 }
 
 // PubSub returns the p2p pubsub framework.
@@ -306,6 +369,11 @@ func (s *Service) PeerID() peer.ID {
 	return s.host.ID()
 }
 
+// BandwidthReporter returns the bandwidth counter of the p2p host.
+func (s *Service) BandwidthReporter() metrics.Reporter {
+	return s.bandwidthCounter
+}
+
 // Disconnect from a peer.
 func (s *Service) Disconnect(pid peer.ID) error {
 	return s.host.Network().ClosePeer(pid)
@@ -5,14 +5,16 @@ import (
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/peer"
 )
 
 // MockPeerManager is mock of the PeerManager interface.
 type MockPeerManager struct {
-	Enr   *enr.Record
-	PID   peer.ID
-	BHost host.Host
+	Enr       *enr.Record
+	PID       peer.ID
+	BHost     host.Host
+	Bandwidth metrics.Reporter
 }
 
 // Disconnect .
@@ -30,6 +32,14 @@ func (m *MockPeerManager) Host() host.Host {
 	return m.BHost
 }
 
+// BandwidthReporter .
+func (m *MockPeerManager) BandwidthReporter() metrics.Reporter {
+	if m.Bandwidth == nil {
+		return metrics.NewBandwidthCounter()
+	}
+	return m.Bandwidth
+}
+
 // ENR .
 func (m MockPeerManager) ENR() *enr.Record {
 	return m.Enr
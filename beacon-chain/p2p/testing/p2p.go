@@ -15,6 +15,7 @@ import (
 	core "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/control"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
@@ -25,6 +26,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/sirupsen/logrus"
 )
 
@@ -203,7 +205,9 @@ func (p *TestP2P) LeaveTopic(topic string) error {
 
 // Encoding returns ssz encoding.
 func (p *TestP2P) Encoding() encoder.NetworkEncoding {
-	return &encoder.SszNetworkEncoder{}
+	return &encoder.SszNetworkEncoder{
+		StrictSizeLimits: featureconfig.Get().EnableStrictSSZLimits,
+	}
 }
 
 // PubSub returns reference underlying floodsub. This test library uses floodsub
@@ -212,6 +216,10 @@ func (p *TestP2P) PubSub() *pubsub.PubSub {
 	return p.pubsub
 }
 
+// TraceGossipMessage does nothing, gossip messages are not recorded in tests.
+func (p *TestP2P) TraceGossipMessage(_ string, _ *pubsub.Message, _ pubsub.ValidationResult) {
+}
+
 // Disconnect from a peer.
 func (p *TestP2P) Disconnect(pid peer.ID) error {
 	return p.BHost.Network().ClosePeer(pid)
@@ -228,6 +236,11 @@ func (p *TestP2P) Host() host.Host {
 	return p.BHost
 }
 
+// BandwidthReporter returns a bandwidth counter for the test p2p host.
+func (p *TestP2P) BandwidthReporter() metrics.Reporter {
+	return metrics.NewBandwidthCounter()
+}
+
 // ENR returns the enr of the local peer.
 func (p *TestP2P) ENR() *enr.Record {
 	return new(enr.Record)
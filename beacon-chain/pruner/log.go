@@ -0,0 +1,7 @@
+package pruner
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "pruner")
@@ -0,0 +1,21 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prunedOrphanedBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_orphaned_blocks_total",
+		Help: "Number of blocks below the finalized checkpoint and off the canonical chain deleted from the database.",
+	})
+	prunedHistoryBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_history_blocks_total",
+		Help: "Number of canonical blocks older than the history retention window deleted from the database.",
+	})
+	historyStartSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pruner_history_start_slot",
+		Help: "The slot below which the canonical history has been pruned.",
+	})
+)
@@ -0,0 +1,313 @@
+// Package pruner deletes the data a beacon node no longer needs as the chain finalizes: the
+// blocks below the finalized checkpoint which are not part of the canonical chain and, when a
+// history retention window is set, the canonical history older than that window.
+package pruner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = shared.Service(&Service{})
+
+// Config to set up the pruning service.
+type Config struct {
+	BeaconDB      db.HeadAccessDatabase
+	StateNotifier statefeed.Notifier
+	// RetentionEpochs is the number of epochs of canonical history kept before the finalized
+	// checkpoint. Zero keeps the whole canonical history.
+	RetentionEpochs uint64
+}
+
+// Service prunes the database every time the finalized checkpoint advances.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	beaconDB        db.HeadAccessDatabase
+	stateNotifier   statefeed.Notifier
+	retentionEpochs uint64
+	finalized       chan struct{}
+	// Orphaned blocks below orphansPrunedSlot and canonical blocks below historyPrunedSlot
+	// have already been deleted.
+	orphansPrunedSlot uint64
+	historyPrunedSlot uint64
+}
+
+// NewService configures the pruning service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		beaconDB:        cfg.BeaconDB,
+		stateNotifier:   cfg.StateNotifier,
+		retentionEpochs: cfg.RetentionEpochs,
+		finalized:       make(chan struct{}, 1),
+	}
+}
+
+// Start the pruning service.
+func (s *Service) Start() {
+	go s.pruneRoutine()
+	go s.subscribeToFinalization()
+}
+
+// Stop the pruning service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruning service. Pruning failures never make the node unhealthy.
+func (s *Service) Status() error {
+	return nil
+}
+
+// HistoryStartSlot returns the lowest slot of the canonical history kept with the given retention
+// window in epochs, blocks below it are pruned. Zero is returned if the window is zero, which
+// keeps the whole history.
+func HistoryStartSlot(finalizedEpoch, retentionEpochs uint64) uint64 {
+	if retentionEpochs == 0 || finalizedEpoch <= retentionEpochs {
+		return 0
+	}
+	return helpers.StartSlot(finalizedEpoch - retentionEpochs)
+}
+
+// This signals the prune routine on finalization without ever blocking the state feed, which
+// would hold up block processing while the database is pruned.
+func (s *Service) subscribeToFinalization() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.FinalizedCheckpoint {
+				continue
+			}
+			select {
+			case s.finalized <- struct{}{}:
+			default:
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			return
+		}
+	}
+}
+
+func (s *Service) pruneRoutine() {
+	for {
+		select {
+		case <-s.finalized:
+			if err := s.prune(s.ctx); err != nil {
+				log.WithError(err).Error("Could not prune database")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// prune deletes the orphaned blocks below the finalized epoch and the canonical history below the
+// retention window, along with their states and state summaries.
+func (s *Service) prune(ctx context.Context) error {
+	cp, err := s.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalizedSlot := helpers.StartSlot(cp.Epoch)
+	if finalizedSlot > s.orphansPrunedSlot {
+		if err := s.pruneOrphans(ctx, s.orphansPrunedSlot, finalizedSlot); err != nil {
+			return errors.Wrap(err, "could not prune orphaned blocks")
+		}
+		s.orphansPrunedSlot = finalizedSlot
+	}
+	historyStart := HistoryStartSlot(cp.Epoch, s.retentionEpochs)
+	if historyStart > s.historyPrunedSlot {
+		prunedSlot, err := s.pruneHistory(ctx, s.historyPrunedSlot, historyStart, bytesutil.ToBytes32(cp.Root))
+		if err != nil {
+			return errors.Wrap(err, "could not prune history")
+		}
+		s.historyPrunedSlot = prunedSlot
+	}
+	return nil
+}
+
+// This deletes the blocks in [start, end) which are not part of the finalized chain. The finalized
+// block index only holds canonical blocks below the finalized epoch.
+func (s *Service) pruneOrphans(ctx context.Context, start, end uint64) error {
+	roots, err := s.blockRootsBetween(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	orphans := make([][32]byte, 0)
+	for _, root := range roots {
+		if !s.beaconDB.IsFinalizedBlock(ctx, root) {
+			orphans = append(orphans, root)
+		}
+	}
+	if err := s.deleteBlocks(ctx, orphans); err != nil {
+		return err
+	}
+	prunedOrphanedBlocks.Add(float64(len(orphans)))
+	if len(orphans) > 0 {
+		log.WithFields(logrus.Fields{
+			"blocks":    len(orphans),
+			"belowSlot": end,
+		}).Info("Pruned orphaned blocks")
+	}
+	return nil
+}
+
+// This deletes the canonical blocks in [start, end), except for the genesis and finalized blocks
+// and the base block of the retained state diffs, and moves the origin block root to the lowest
+// block left above the window start so the chain can be walked down to it. It returns the slot
+// below which every canonical block was deleted, which is the slot of the kept base block if any,
+// so the base block is pruned once a later full state replaces it.
+func (s *Service) pruneHistory(ctx context.Context, start, end uint64, finalizedRoot [32]byte) (uint64, error) {
+	roots, err := s.blockRootsBetween(ctx, start, end)
+	if err != nil {
+		return 0, err
+	}
+	baseRoot, baseSlot, err := s.diffBaseRoot(ctx, roots)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get base state of state diffs")
+	}
+	prunedSlot := end
+	pruned := make([][32]byte, 0, len(roots))
+	for _, root := range roots {
+		if root == baseRoot && baseSlot != 0 {
+			prunedSlot = baseSlot
+			continue
+		}
+		if root != finalizedRoot {
+			pruned = append(pruned, root)
+		}
+	}
+	if err := s.deleteBlocks(ctx, pruned); err != nil {
+		return 0, err
+	}
+	prunedHistoryBlocks.Add(float64(len(pruned)))
+
+	originRoot, err := s.lowestBlockRoot(ctx, end, finalizedRoot)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.beaconDB.SaveOriginBlockRoot(ctx, originRoot); err != nil {
+		return 0, errors.Wrap(err, "could not save origin block root")
+	}
+	// Backfilling resumes from the backfill block root, which must still be saved.
+	backfillRoot, err := s.beaconDB.BackfillBlockRoot(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFoundBackfillBlockRoot) {
+		return 0, err
+	}
+	if err == nil && !s.beaconDB.HasBlock(ctx, backfillRoot) {
+		if err := s.beaconDB.SaveBackfillBlockRoot(ctx, originRoot); err != nil {
+			return 0, errors.Wrap(err, "could not save backfill block root")
+		}
+	}
+	historyStartSlot.Set(float64(end))
+	log.WithFields(logrus.Fields{
+		"blocks":    len(pruned),
+		"belowSlot": end,
+	}).Info("Pruned canonical history outside of the retention window")
+	return prunedSlot, nil
+}
+
+// This returns the root and slot of the block of the highest full state among the given roots, which
+// the state diffs above it are applied to, or a zero slot if archived state diffs are disabled or
+// there is no full state. Archived points are saved under the root of the highest block at or
+// below them, so the highest block holds the highest state.
+func (s *Service) diffBaseRoot(ctx context.Context, roots [][32]byte) ([32]byte, uint64, error) {
+	if params.BeaconConfig().ArchivedStateDiffs == 0 {
+		return [32]byte{}, 0, nil
+	}
+	var baseRoot [32]byte
+	var baseSlot uint64
+	for _, root := range roots {
+		if !s.beaconDB.HasState(ctx, root) {
+			continue
+		}
+		summary, err := s.beaconDB.StateSummary(ctx, root)
+		if err != nil {
+			return [32]byte{}, 0, err
+		}
+		if summary == nil {
+			return [32]byte{}, 0, errors.New("state summary of saved state is not in db")
+		}
+		if summary.Slot > baseSlot {
+			baseRoot = root
+			baseSlot = summary.Slot
+		}
+	}
+	return baseRoot, baseSlot, nil
+}
+
+// This returns the root of the lowest canonical block at or above the slot, or the finalized root
+// if there is none between the slot and the finalized checkpoint.
+func (s *Service) lowestBlockRoot(ctx context.Context, slot uint64, finalizedRoot [32]byte) ([32]byte, error) {
+	finalizedBlock, err := s.beaconDB.Block(ctx, finalizedRoot)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if finalizedBlock == nil || finalizedBlock.Block == nil {
+		return [32]byte{}, errors.New("finalized block is not in db")
+	}
+	if finalizedBlock.Block.Slot <= slot {
+		return finalizedRoot, nil
+	}
+	roots, err := s.beaconDB.BlockRoots(ctx, filters.NewFilter().SetStartSlot(slot).SetEndSlot(finalizedBlock.Block.Slot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	for _, root := range roots {
+		if s.beaconDB.IsFinalizedBlock(ctx, root) {
+			return root, nil
+		}
+	}
+	return finalizedRoot, nil
+}
+
+// This returns the roots of the blocks in [start, end). The genesis block at slot 0 is never
+// returned.
+func (s *Service) blockRootsBetween(ctx context.Context, start, end uint64) ([][32]byte, error) {
+	if start == 0 {
+		start = 1
+	}
+	if end <= start {
+		return nil, nil
+	}
+	return s.beaconDB.BlockRoots(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end-1))
+}
+
+// This deletes the blocks with their states, state diffs and state summaries. States are deleted
+// first, as the state summaries are used to find the slot of the states.
+func (s *Service) deleteBlocks(ctx context.Context, roots [][32]byte) error {
+	if len(roots) == 0 {
+		return nil
+	}
+	if err := s.beaconDB.DeleteStates(ctx, roots); err != nil {
+		return errors.Wrap(err, "could not delete states")
+	}
+	if err := s.beaconDB.DeleteStateDiffs(ctx, roots); err != nil {
+		return errors.Wrap(err, "could not delete state diffs")
+	}
+	if err := s.beaconDB.DeleteStateSummaries(ctx, roots); err != nil {
+		return errors.Wrap(err, "could not delete state summaries")
+	}
+	return s.beaconDB.DeleteBlocks(ctx, roots)
+}
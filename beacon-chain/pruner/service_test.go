@@ -0,0 +1,180 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// This saves a canonical chain with a block at every slot up to the slot, finalized at the epoch,
+// and returns the canonical block roots indexed by slot.
+func setupChain(t *testing.T, beaconDB db.Database, slot, finalizedEpoch uint64) [][32]byte {
+	ctx := context.Background()
+	roots := make([][32]byte, slot+1)
+	parentRoot := make([]byte, 32)
+	for i := uint64(0); i <= slot; i++ {
+		roots[i] = saveBlock(t, beaconDB, i, parentRoot)
+		parentRoot = roots[i][:]
+	}
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, beaconDB.SaveState(ctx, testutil.NewBeaconState(), roots[0]))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, roots[slot]))
+	finalizedRoot := roots[helpers.StartSlot(finalizedEpoch)]
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: finalizedEpoch, Root: finalizedRoot[:]}))
+	return roots
+}
+
+func saveBlock(t *testing.T, beaconDB db.Database, slot uint64, parentRoot []byte) [32]byte {
+	ctx := context.Background()
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = parentRoot
+	root, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &pb.StateSummary{Slot: slot, Root: root[:]}))
+	return root
+}
+
+func TestHistoryStartSlot(t *testing.T) {
+	assert.Equal(t, uint64(0), HistoryStartSlot(10, 0))
+	assert.Equal(t, uint64(0), HistoryStartSlot(10, 10))
+	assert.Equal(t, helpers.StartSlot(2), HistoryStartSlot(10, 8))
+}
+
+func TestService_PruneOrphans(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	slotsPerEpoch := helpers.StartSlot(1)
+	roots := setupChain(t, beaconDB, 3*slotsPerEpoch, 2)
+	orphan := saveBlock(t, beaconDB, 5, roots[3][:])
+	st := testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(5))
+	require.NoError(t, beaconDB.SaveState(ctx, st, orphan))
+	// Forks above the finalized epoch may still become canonical.
+	fork := saveBlock(t, beaconDB, 2*slotsPerEpoch+1, roots[2*slotsPerEpoch-1][:])
+
+	s := NewService(ctx, &Config{BeaconDB: beaconDB})
+	require.NoError(t, s.prune(ctx))
+	assert.Equal(t, false, beaconDB.HasBlock(ctx, orphan))
+	assert.Equal(t, false, beaconDB.HasState(ctx, orphan))
+	assert.Equal(t, false, beaconDB.HasStateSummary(ctx, orphan))
+	assert.Equal(t, true, beaconDB.HasBlock(ctx, fork))
+	for _, root := range roots {
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, root))
+	}
+	_, err := beaconDB.OriginBlockRoot(ctx)
+	assert.ErrorContains(t, db.ErrNotFoundOriginBlockRoot.Error(), err)
+}
+
+func TestService_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	slotsPerEpoch := helpers.StartSlot(1)
+	roots := setupChain(t, beaconDB, 4*slotsPerEpoch, 3)
+	st := testutil.NewBeaconState()
+	require.NoError(t, st.SetSlot(slotsPerEpoch))
+	require.NoError(t, beaconDB.SaveState(ctx, st, roots[slotsPerEpoch]))
+
+	s := NewService(ctx, &Config{BeaconDB: beaconDB, RetentionEpochs: 1})
+	require.NoError(t, s.prune(ctx))
+	historyStart := helpers.StartSlot(2)
+	assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[0]), "Expected the genesis block to be kept")
+	assert.Equal(t, true, beaconDB.HasState(ctx, roots[0]), "Expected the genesis state to be kept")
+	for i := uint64(1); i < historyStart; i++ {
+		assert.Equal(t, false, beaconDB.HasBlock(ctx, roots[i]), "Expected block at slot %d to be pruned", i)
+		assert.Equal(t, false, beaconDB.HasStateSummary(ctx, roots[i]))
+	}
+	assert.Equal(t, false, beaconDB.HasState(ctx, roots[slotsPerEpoch]))
+	for i := historyStart; i < uint64(len(roots)); i++ {
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[i]), "Expected block at slot %d to be kept", i)
+	}
+	originRoot, err := beaconDB.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[historyStart], originRoot)
+}
+
+func TestService_PruneHistory_KeepsStateDiffBase(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	slotsPerEpoch := helpers.StartSlot(1)
+	cfg := params.BeaconConfig()
+	cfg.SlotsPerArchivedPoint = slotsPerEpoch
+	cfg.ArchivedStateDiffs = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	roots := setupChain(t, beaconDB, 5*slotsPerEpoch, 4)
+	sg := stategen.New(beaconDB, cache.NewStateSummaryCache())
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	sg.SaveFinalizedState(4*slotsPerEpoch, roots[4*slotsPerEpoch], base.Copy())
+
+	// Every other archived point is saved in full, the state of the first epoch of the retention
+	// window is saved as a diff against the full state of the epoch before it.
+	full := base.Copy()
+	require.NoError(t, full.SetSlot(2*slotsPerEpoch))
+	require.NoError(t, sg.SaveState(ctx, roots[2*slotsPerEpoch], full))
+	target := base.Copy()
+	require.NoError(t, target.SetSlot(3*slotsPerEpoch))
+	require.NoError(t, target.UpdateBalancesAtIndex(3, target.Balances()[3]-100))
+	require.NoError(t, sg.SaveState(ctx, roots[3*slotsPerEpoch], target))
+	require.Equal(t, true, beaconDB.HasStateDiff(ctx, roots[3*slotsPerEpoch]))
+
+	s := NewService(ctx, &Config{BeaconDB: beaconDB, RetentionEpochs: 1})
+	require.NoError(t, s.prune(ctx))
+	historyStart := helpers.StartSlot(3)
+	for i := uint64(1); i < historyStart; i++ {
+		if i == 2*slotsPerEpoch {
+			continue
+		}
+		assert.Equal(t, false, beaconDB.HasBlock(ctx, roots[i]), "Expected block at slot %d to be pruned", i)
+	}
+	assert.Equal(t, true, beaconDB.HasState(ctx, roots[2*slotsPerEpoch]), "Expected the base state of the diff to be kept")
+	assert.Equal(t, 2*slotsPerEpoch, s.historyPrunedSlot)
+
+	loaded, err := sg.StateByRoot(ctx, roots[3*slotsPerEpoch])
+	require.NoError(t, err)
+	assert.Equal(t, 3*slotsPerEpoch, loaded.Slot())
+	assert.DeepEqual(t, target.Balances(), loaded.Balances())
+}
+
+func TestService_PrunesOnFinalization(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	roots := setupChain(t, beaconDB, 2*helpers.StartSlot(1), 1)
+	orphan := saveBlock(t, beaconDB, 3, roots[1][:])
+
+	notifier := (&mock.ChainService{}).StateNotifier()
+	s := NewService(ctx, &Config{BeaconDB: beaconDB, StateNotifier: notifier})
+	s.Start()
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+	// Wait for the service to subscribe to the state feed.
+	for notifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalizedCheckpoint,
+		Data: &statefeed.FinalizedCheckpointData{Epoch: 1, BlockRoot: roots[helpers.StartSlot(1)]},
+	}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 100 && beaconDB.HasBlock(ctx, orphan); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, false, beaconDB.HasBlock(ctx, orphan))
+}
@@ -2,6 +2,8 @@ package debug
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -9,6 +11,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -36,6 +39,50 @@ func (ds *Server) ListPeers(ctx context.Context, _ *types.Empty) (*pbrpc.DebugPe
 	return &pbrpc.DebugPeerResponses{Responses: responses}, nil
 }
 
+// ListPeerBans returns the peers which are currently banned by the host node.
+func (ds *Server) ListPeerBans(ctx context.Context, _ *types.Empty) (*pbrpc.PeerBans, error) {
+	bans := make([]*pbrpc.PeerBan, 0)
+	for pid, until := range ds.PeersFetcher.Peers().Banned() {
+		bans = append(bans, &pbrpc.PeerBan{
+			PeerId:      pid.String(),
+			BannedUntil: uint64(until.Unix()),
+		})
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].PeerId < bans[j].PeerId
+	})
+	return &pbrpc.PeerBans{Bans: bans}, nil
+}
+
+// BanPeer bans the peer defined by the provided peer id for the provided duration, and disconnects
+// from it.
+func (ds *Server) BanPeer(ctx context.Context, req *pbrpc.PeerBanRequest) (*types.Empty, error) {
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	if req.Duration == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Expected a ban duration")
+	}
+	ds.PeersFetcher.Peers().Ban(pid, roughtime.Now().Add(time.Duration(req.Duration)*time.Second))
+	if err := ds.PeerManager.Disconnect(pid); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not disconnect from peer: %v", err)
+	}
+	return &types.Empty{}, nil
+}
+
+// UnbanPeer lifts the ban of the peer defined by the provided peer id.
+func (ds *Server) UnbanPeer(ctx context.Context, req *ethpb.PeerRequest) (*types.Empty, error) {
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	if err := ds.PeersFetcher.Peers().Unban(pid); err != nil {
+		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
+	}
+	return &types.Empty{}, nil
+}
+
 func (ds *Server) getPeer(pid peer.ID) (*pbrpc.DebugPeerResponse, error) {
 	peers := ds.PeersFetcher.Peers()
 	peerStore := ds.PeerManager.Host().Peerstore()
@@ -81,6 +128,10 @@ func (ds *Server) getPeer(pid peer.ID) (*pbrpc.DebugPeerResponse, error) {
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
 	}
+	gossipScore, err := peers.GossipScore(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
+	}
 
 	rawPversion, err := peerStore.Get(pid, "ProtocolVersion")
 	pVersion, ok := rawPversion.(string)
@@ -92,6 +143,7 @@ func (ds *Server) getPeer(pid peer.ID) (*pbrpc.DebugPeerResponse, error) {
 	if err != nil || !ok {
 		aVersion = ""
 	}
+	bandwidth := ds.PeerManager.BandwidthReporter().GetBandwidthForPeer(pid)
 	peerInfo := &pbrpc.DebugPeerResponse_PeerInfo{
 		Metadata:        metadata,
 		Protocols:       protocols,
@@ -99,6 +151,11 @@ func (ds *Server) getPeer(pid peer.ID) (*pbrpc.DebugPeerResponse, error) {
 		ProtocolVersion: pVersion,
 		AgentVersion:    aVersion,
 		PeerLatency:     uint64(peerStore.LatencyEWMA(pid).Milliseconds()),
+		GossipScore:     gossipScore,
+		BytesIn:         uint64(bandwidth.TotalIn),
+		BytesOut:        uint64(bandwidth.TotalOut),
+		RateIn:          bandwidth.RateIn,
+		RateOut:         bandwidth.RateOut,
 	}
 	addresses := peerStore.Addrs(pid)
 	stringAddrs := []string{}
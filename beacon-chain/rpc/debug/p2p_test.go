@@ -3,10 +3,14 @@ package debug
 import (
 	"context"
 	"testing"
+	"time"
 
 	ptypes "github.com/gogo/protobuf/types"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
@@ -14,15 +18,22 @@ import (
 func TestDebugServer_GetPeer(t *testing.T) {
 	peersProvider := &mockP2p.MockPeersProvider{}
 	mP2P := mockP2p.NewTestP2P(t)
+	bandwidth := &mockBandwidthReporter{stats: metrics.Stats{TotalIn: 100, TotalOut: 250, RateIn: 1.5, RateOut: 2.5}}
 	ds := &Server{
 		PeersFetcher: peersProvider,
-		PeerManager:  &mockP2p.MockPeerManager{BHost: mP2P.BHost},
+		PeerManager:  &mockP2p.MockPeerManager{BHost: mP2P.BHost, Bandwidth: bandwidth},
 	}
 	firstPeer := peersProvider.Peers().All()[0]
+	peersProvider.Peers().SetGossipScore(firstPeer, 3.5)
 
 	res, err := ds.GetPeer(context.Background(), &ethpb.PeerRequest{PeerId: firstPeer.String()})
 	require.NoError(t, err)
 	require.Equal(t, firstPeer.String(), res.PeerId, "Unexpected peer ID")
+	assert.Equal(t, 3.5, res.PeerInfo.GossipScore, "Unexpected gossip score")
+	assert.Equal(t, uint64(100), res.PeerInfo.BytesIn, "Unexpected bytes received")
+	assert.Equal(t, uint64(250), res.PeerInfo.BytesOut, "Unexpected bytes sent")
+	assert.Equal(t, 1.5, res.PeerInfo.RateIn, "Unexpected receive rate")
+	assert.Equal(t, 2.5, res.PeerInfo.RateOut, "Unexpected send rate")
 
 	assert.Equal(t, int(ethpb.PeerDirection_INBOUND), int(res.Direction), "Expected 1st peer to be an inbound connection")
 	assert.Equal(t, ethpb.ConnectionState_CONNECTED, res.ConnectionState, "Expected peer to be connected")
@@ -49,3 +60,41 @@ func TestDebugServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2nd peer to have a multiaddress, instead they have no addresses")
 	}
 }
+
+func TestDebugServer_PeerBans(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	ds := &Server{
+		PeersFetcher: peersProvider,
+		PeerManager:  &mockP2p.MockPeerManager{},
+	}
+	firstPeer := peersProvider.Peers().All()[0]
+
+	_, err := ds.BanPeer(context.Background(), &pbrpc.PeerBanRequest{PeerId: firstPeer.String()})
+	assert.ErrorContains(t, "Expected a ban duration", err)
+	_, err = ds.BanPeer(context.Background(), &pbrpc.PeerBanRequest{PeerId: "foo", Duration: 60})
+	assert.ErrorContains(t, "Unable to parse provided peer id", err)
+
+	_, err = ds.BanPeer(context.Background(), &pbrpc.PeerBanRequest{PeerId: firstPeer.String(), Duration: 60})
+	require.NoError(t, err)
+	assert.Equal(t, true, peersProvider.Peers().IsBad(firstPeer))
+	res, err := ds.ListPeerBans(context.Background(), &ptypes.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Bans))
+	assert.Equal(t, firstPeer.String(), res.Bans[0].PeerId)
+	assert.Equal(t, true, res.Bans[0].BannedUntil > uint64(time.Now().Unix()))
+
+	_, err = ds.UnbanPeer(context.Background(), &ethpb.PeerRequest{PeerId: firstPeer.String()})
+	require.NoError(t, err)
+	res, err = ds.ListPeerBans(context.Background(), &ptypes.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(res.Bans))
+}
+
+type mockBandwidthReporter struct {
+	metrics.Reporter
+	stats metrics.Stats
+}
+
+func (m *mockBandwidthReporter) GetBandwidthForPeer(peer.ID) metrics.Stats {
+	return m.stats
+}
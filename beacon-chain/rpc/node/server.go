@@ -17,17 +17,30 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/shared/version"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+var log = logrus.WithField("prefix", "rpc/node")
+
+const (
+	// BackfillLowestSlotHeader is the response header holding the lowest slot backfilled so far.
+	BackfillLowestSlotHeader = "x-backfill-lowest-slot"
+	// BackfillCompleteHeader is the response header set to true once backfilling reached genesis.
+	BackfillCompleteHeader = "x-backfill-complete"
+)
+
 // Server defines a server implementation of the gRPC Node service,
 // providing RPC endpoints for verifying a beacon node's sync status, genesis and
 // version information, and services the node implements and runs.
 type Server struct {
 	SyncChecker        sync.Checker
+	BackfillFetcher    backfill.StatusFetcher
 	Server             *grpc.Server
 	BeaconDB           db.ReadOnlyDatabase
 	PeersFetcher       p2p.PeersProvider
@@ -36,8 +49,20 @@ type Server struct {
 	GenesisFetcher     blockchain.GenesisFetcher
 }
 
-// GetSyncStatus checks the current network sync status of the node.
+// GetSyncStatus checks the current network sync status of the node. The progress of
+// historical block backfilling is returned in the response headers, as the sync status
+// message is defined by the external ethereumapis repository. Failing to set the headers
+// does not fail the request, as validator clients check the health of the node with it.
 func (ns *Server) GetSyncStatus(ctx context.Context, _ *ptypes.Empty) (*ethpb.SyncStatus, error) {
+	if ns.BackfillFetcher != nil {
+		md := metadata.Pairs(
+			BackfillLowestSlotHeader, fmt.Sprintf("%d", ns.BackfillFetcher.LowestBackfilledSlot()),
+			BackfillCompleteHeader, fmt.Sprintf("%t", ns.BackfillFetcher.BackfillComplete()),
+		)
+		if err := grpc.SetHeader(ctx, md); err != nil {
+			log.WithError(err).Debug("Could not set backfill status headers")
+		}
+	}
 	return &ethpb.SyncStatus{
 		Syncing: ns.SyncChecker.Syncing(),
 	}, nil
@@ -31,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
@@ -74,6 +75,7 @@ type Service struct {
 	exitPool                *voluntaryexits.Pool
 	slashingsPool           *slashings.Pool
 	syncService             chainSync.Checker
+	backfillService         backfill.StatusFetcher
 	host                    string
 	port                    string
 	listener                net.Listener
@@ -123,6 +125,7 @@ type Config struct {
 	ExitPool                *voluntaryexits.Pool
 	SlashingsPool           *slashings.Pool
 	SyncService             chainSync.Checker
+	BackfillService         backfill.StatusFetcher
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
 	PeerManager             p2p.PeerManager
@@ -161,6 +164,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		exitPool:                cfg.ExitPool,
 		slashingsPool:           cfg.SlashingsPool,
 		syncService:             cfg.SyncService,
+		backfillService:         cfg.BackfillService,
 		host:                    cfg.Host,
 		port:                    cfg.Port,
 		withCert:                cfg.CertFlag,
@@ -255,6 +259,7 @@ func (s *Service) Start() {
 		BeaconDB:           s.beaconDB,
 		Server:             s.grpcServer,
 		SyncChecker:        s.syncService,
+		BackfillFetcher:    s.backfillService,
 		GenesisTimeFetcher: s.genesisTimeFetcher,
 		PeersFetcher:       s.peersFetcher,
 		PeerManager:        s.peerManager,
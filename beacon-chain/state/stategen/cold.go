@@ -3,6 +3,7 @@ package stategen
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -22,17 +23,63 @@ func (s *State) saveColdState(ctx context.Context, blockRoot [32]byte, state *st
 		return nil
 	}
 
-	if err := s.beaconDB.SaveState(ctx, state, blockRoot); err != nil {
+	isDiff, err := s.saveArchivedState(ctx, blockRoot, state)
+	if err != nil {
 		return err
 	}
 
+	msg := "Saved full state on archived point"
+	if isDiff {
+		msg = "Saved state diff on archived point"
+	}
 	log.WithFields(logrus.Fields{
 		"slot":      slot,
-		"blockRoot": hex.EncodeToString(bytesutil.Trunc(blockRoot[:]))}).Info("Saved full state on archived point")
+		"blockRoot": hex.EncodeToString(bytesutil.Trunc(blockRoot[:]))}).Info(msg)
 
 	return nil
 }
 
+// This saves the state of an archived point, in full or as a diff against the previous full
+// archived state, and returns true if a diff was saved. Every archived point is saved in full
+// unless archived state diffs are enabled, in which case a full state is saved once every
+// archivedStateDiffs+1 archived points.
+func (s *State) saveArchivedState(ctx context.Context, blockRoot [32]byte, st *state.BeaconState) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.saveArchivedState")
+	defer span.End()
+
+	if s.archivedStateDiffs != 0 && (st.Slot()/s.slotsPerArchivedPoint)%(s.archivedStateDiffs+1) != 0 {
+		saved, err := s.saveArchivedStateDiff(ctx, blockRoot, st)
+		if err != nil || saved {
+			return saved, err
+		}
+	}
+	return false, s.beaconDB.SaveState(ctx, st, blockRoot)
+}
+
+// This saves the state as a diff against the highest full state below it. It returns false
+// without saving anything if there is no such state to use as the base of the diff.
+func (s *State) saveArchivedStateDiff(ctx context.Context, blockRoot [32]byte, st *state.BeaconState) (bool, error) {
+	sts, err := s.beaconDB.HighestSlotStatesBelow(ctx, st.Slot())
+	if err != nil {
+		return false, errors.Wrap(err, "could not get base state of diff")
+	}
+	if len(sts) == 0 || sts[0] == nil {
+		return false, nil
+	}
+	diff, err := computeStateDiff(sts[0], st)
+	if err != nil {
+		return false, errors.Wrap(err, "could not compute state diff")
+	}
+	enc, err := diff.marshal()
+	if err != nil {
+		return false, errors.Wrap(err, "could not encode state diff")
+	}
+	if err := s.beaconDB.SaveStateDiff(ctx, blockRoot, st.Slot(), diff.baseSlot, enc); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // This loads the cold state by block root.
 func (s *State) loadColdStateByRoot(ctx context.Context, blockRoot [32]byte) (*state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "stateGen.loadColdStateByRoot")
@@ -46,7 +93,8 @@ func (s *State) loadColdStateByRoot(ctx context.Context, blockRoot [32]byte) (*s
 	return s.loadColdStateBySlot(ctx, summary.Slot)
 }
 
-// This loads a cold state by slot.
+// This loads a cold state by slot. The state is replayed from the closest state available at or
+// below the slot and kept in the cold state cache, so a query for a later slot can replay from it.
 func (s *State) loadColdStateBySlot(ctx context.Context, slot uint64) (*state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "stateGen.loadColdStateBySlot")
 	defer span.End()
@@ -55,6 +103,22 @@ func (s *State) loadColdStateBySlot(ctx context.Context, slot uint64) (*state.Be
 		return s.beaconDB.GenesisState(ctx)
 	}
 
+	startState, err := s.replayStartState(ctx, slot, true /* cold */)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.processStateUpTo(ctx, startState, slot)
+	if err != nil {
+		return nil, err
+	}
+	s.coldStateCache.Put(st)
+
+	return st, nil
+}
+
+// This loads the archived state with the highest slot at or below the input slot, from a full
+// state or a state diff.
+func (s *State) loadArchivedState(ctx context.Context, slot uint64) (*state.BeaconState, error) {
 	archivedState, err := s.archivedState(ctx, slot)
 	if err != nil {
 		return nil, err
@@ -65,6 +129,45 @@ func (s *State) loadColdStateBySlot(ctx context.Context, slot uint64) (*state.Be
 			return nil, err
 		}
 	}
+	// A node started from a checkpoint has neither a genesis state nor archived states
+	// below its origin checkpoint.
+	if archivedState == nil {
+		return nil, errUnknownState
+	}
+	diffState, err := s.archivedStateFromDiff(ctx, slot, archivedState.Slot())
+	if err != nil {
+		return nil, err
+	}
+	if diffState != nil {
+		return diffState, nil
+	}
+	return archivedState, nil
+}
 
-	return s.processStateUpTo(ctx, archivedState, slot)
+// This returns the state of the highest archived state diff at or below the slot, applied to its
+// base state. Nil is returned if there is no diff above the slot of the full state which would
+// be replayed otherwise.
+func (s *State) archivedStateFromDiff(ctx context.Context, slot uint64, fullStateSlot uint64) (*state.BeaconState, error) {
+	encs, err := s.beaconDB.HighestSlotStateDiffsBelow(ctx, slot+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state diff")
+	}
+	if len(encs) == 0 {
+		return nil, nil
+	}
+	diff, err := unmarshalStateDiff(encs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode state diff")
+	}
+	if diff.state.Slot <= fullStateSlot {
+		return nil, nil
+	}
+	base, err := s.archivedState(ctx, diff.baseSlot)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil || base.Slot() != diff.baseSlot {
+		return nil, fmt.Errorf("base state of diff at slot %d is not in db", diff.baseSlot)
+	}
+	return diff.apply(base)
 }
@@ -95,3 +95,12 @@ func TestLoadColdStateBySlot_CanGet(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, uint64(200), loadedState.Slot(), "Did not correctly save state")
 }
+
+func TestLoadColdStateBySlot_NoGenesisState(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+
+	service := New(db, cache.NewStateSummaryCache())
+	_, err := service.loadColdStateBySlot(ctx, 10)
+	assert.ErrorContains(t, errUnknownState.Error(), err)
+}
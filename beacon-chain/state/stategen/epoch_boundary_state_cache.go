@@ -2,6 +2,7 @@ package stategen
 
 import (
 	"errors"
+	"sort"
 	"strconv"
 	"sync"
 
@@ -108,6 +109,28 @@ func (e *epochBoundaryState) getBySlot(s uint64) (*rootStateInfo, bool, error) {
 	return e.getByRoot(info.root)
 }
 
+// get the slot root infos of the epoch boundary states below the input slot, sorted by decreasing slot.
+func (e *epochBoundaryState) slotRootsBelow(slot uint64) ([]*slotRootInfo, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	infos := make([]*slotRootInfo, 0)
+	for _, obj := range e.slotRootCache.List() {
+		info, ok := obj.(*slotRootInfo)
+		if !ok {
+			return nil, errNotSlotRootInfo
+		}
+		if info.slot < slot {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].slot > infos[j].slot
+	})
+
+	return infos, nil
+}
+
 // put adds a state to the epoch boundary state cache. This method also trims the
 // least recently added state info if the cache size has reached the max cache
 // size limit.
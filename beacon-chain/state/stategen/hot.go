@@ -122,8 +122,11 @@ func (s *State) loadHotStateBySlot(ctx context.Context, slot uint64) (*state.Bea
 		return s.beaconDB.GenesisState(ctx)
 	}
 
-	// Gather last saved state, that is where node starts to replay the blocks.
-	startState, err := s.lastSavedState(ctx, slot)
+	// Gather the closest state available, that is where node starts to replay the blocks.
+	startState, err := s.replayStartState(ctx, slot, false /* cold */)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state to replay from for hot state using slot")
+	}
 
 	// Gather the last saved block root and the slot number.
 	lastValidRoot, lastValidSlot, err := s.lastSavedBlock(ctx, slot)
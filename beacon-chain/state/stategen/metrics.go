@@ -0,0 +1,28 @@
+package stategen
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	replayStartStates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stategen_replay_start_states_total",
+		Help: "The number of states regenerated by slot, labeled by where the state replayed from came from.",
+	}, []string{"source"})
+	replaySlots = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stategen_replay_slots",
+		Help:    "The number of slots processed to regenerate a state.",
+		Buckets: []float64{0, 1, 8, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192},
+	})
+	replayBlocks = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stategen_replay_blocks",
+		Help:    "The number of blocks replayed to regenerate a state.",
+		Buckets: []float64{0, 1, 8, 32, 64, 128, 256, 512, 1024, 2048},
+	})
+	replayDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stategen_replay_milliseconds",
+		Help:    "The time taken to replay blocks and slots to regenerate a state.",
+		Buckets: []float64{1, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000},
+	})
+)
@@ -76,17 +76,19 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				aRoot = missingRoot
 				aState = missingState
 			}
-			if s.beaconDB.HasState(ctx, aRoot) {
+			if s.beaconDB.HasState(ctx, aRoot) || s.beaconDB.HasStateDiff(ctx, aRoot) {
 				continue
 			}
 
-			if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
+			isDiff, err := s.saveArchivedState(ctx, aRoot, aState)
+			if err != nil {
 				return err
 			}
 			log.WithFields(
 				logrus.Fields{
 					"slot": aState.Slot(),
 					"root": hex.EncodeToString(bytesutil.Trunc(aRoot[:])),
+					"diff": isDiff,
 				}).Info("Saved state in DB")
 		}
 	}
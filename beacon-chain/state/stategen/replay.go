@@ -3,6 +3,7 @@ package stategen
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -68,6 +69,9 @@ func (s *State) ReplayBlocks(ctx context.Context, state *state.BeaconState, sign
 	ctx, span := trace.StartSpan(ctx, "stateGen.ReplayBlocks")
 	defer span.End()
 
+	startSlot := state.Slot()
+	start := time.Now()
+	replayed := 0
 	var err error
 	// The input block list is sorted in decreasing slots order.
 	if len(signed) > 0 {
@@ -75,6 +79,7 @@ func (s *State) ReplayBlocks(ctx context.Context, state *state.BeaconState, sign
 			if state.Slot() >= targetSlot {
 				break
 			}
+			replayed++
 
 			if featureconfig.Get().EnableStateGenSigVerify {
 				state, err = transition.ExecuteStateTransition(ctx, state, signed[i])
@@ -105,6 +110,9 @@ func (s *State) ReplayBlocks(ctx context.Context, state *state.BeaconState, sign
 		}
 	}
 
+	replaySlots.Observe(float64(state.Slot() - startSlot))
+	replayBlocks.Observe(float64(replayed))
+	replayDuration.Observe(float64(time.Since(start).Milliseconds()))
 	return state, nil
 }
 
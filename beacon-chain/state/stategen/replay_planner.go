@@ -0,0 +1,104 @@
+package stategen
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"go.opencensus.io/trace"
+)
+
+// The sources of the state a state regenerated by slot replays from.
+const (
+	coldStateCacheSource     = "cold_state_cache"
+	epochBoundaryCacheSource = "epoch_boundary_cache"
+	hotStateCacheSource      = "hot_state_cache"
+	dbSource                 = "db"
+)
+
+// This plans the regeneration of the canonical state at the input slot by returning the
+// materialized state with the highest slot at or below it, which the fewest slots and blocks
+// have to be replayed from. The states in the caches are preferred, the closest saved state in
+// the DB is only loaded when none of the cached states is at or above the slot it is expected at.
+//
+// The cold state cache is only used for cold states, as hot states below a non finalized block
+// may still be reorged out.
+func (s *State) replayStartState(ctx context.Context, slot uint64, cold bool) (*state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.replayStartState")
+	defer span.End()
+
+	var best *state.BeaconState
+	source := ""
+	consider := func(st *state.BeaconState, src string) {
+		if st != nil && st.Slot() <= slot && (best == nil || st.Slot() > best.Slot()) {
+			best = st
+			source = src
+		}
+	}
+
+	if cold {
+		consider(s.coldStateCache.HighestSlotBelow(slot+1), coldStateCacheSource)
+	}
+	// The post state of the last block at or below the slot only needs slots to be processed. The
+	// replay fails later on if the last block cannot be found.
+	lastRoot, lastSlot, err := s.lastSavedBlock(ctx, slot)
+	if err == nil && (best == nil || lastSlot > best.Slot()) {
+		consider(s.hotStateCache.Get(lastRoot), hotStateCacheSource)
+	}
+	boundaries, err := s.epochBoundaryStateCache.slotRootsBelow(slot + 1)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range boundaries {
+		if best != nil && b.slot <= best.Slot() {
+			break
+		}
+		if !s.isCanonicalBoundary(ctx, b) {
+			continue
+		}
+		info, ok, err := s.epochBoundaryStateCache.getByRoot(b.root)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			consider(info.state, epochBoundaryCacheSource)
+			break
+		}
+	}
+
+	if best == nil || best.Slot() < s.expectedSavedStateSlot(slot, cold) {
+		var saved *state.BeaconState
+		if cold {
+			saved, err = s.loadArchivedState(ctx, slot)
+		} else {
+			saved, err = s.lastSavedState(ctx, slot)
+		}
+		// A saved state is only required when nothing was cached.
+		if err != nil && best == nil {
+			return nil, err
+		}
+		consider(saved, dbSource)
+	}
+	if best == nil {
+		return nil, errUnknownState
+	}
+
+	replayStartStates.WithLabelValues(source).Inc()
+	return best, nil
+}
+
+// This returns the slot of the closest state saved in the DB at or below the input slot, assuming
+// it is on the archived point boundary for cold states and on the epoch boundary for hot states.
+func (s *State) expectedSavedStateSlot(slot uint64, cold bool) uint64 {
+	if cold {
+		return slot - slot%s.slotsPerArchivedPoint
+	}
+	return helpers.StartSlot(helpers.SlotToEpoch(slot))
+}
+
+// This returns true if the epoch boundary state was produced by the canonical chain, as the cache
+// also holds the boundary states of forks.
+func (s *State) isCanonicalBoundary(ctx context.Context, info *slotRootInfo) bool {
+	root, _, err := s.lastSavedBlock(ctx, info.slot)
+	return err == nil && root == info.root
+}
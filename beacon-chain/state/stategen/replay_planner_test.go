@@ -0,0 +1,70 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestLoadColdStateBySlot_ReplaysFromColdStateCache(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+
+	service := New(db, cache.NewStateSummaryCache())
+	beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	blkRoot, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveGenesisBlockRoot(ctx, blkRoot))
+	require.NoError(t, service.beaconDB.SaveBlock(ctx, blk))
+	require.NoError(t, service.beaconDB.SaveState(ctx, beaconState, blkRoot))
+
+	loadedState, err := service.loadColdStateBySlot(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), loadedState.Slot())
+	assert.Equal(t, true, service.coldStateCache.Has(100), "Did not cache cold state")
+
+	startState, err := service.replayStartState(ctx, 132, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), startState.Slot(), "Did not replay from cached state")
+	// Hot states are not regenerated from the cold state cache.
+	startState, err = service.replayStartState(ctx, 132, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), startState.Slot())
+
+	loadedState, err = service.loadColdStateBySlot(ctx, 132)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(132), loadedState.Slot())
+}
+
+func TestReplayStartState_CanonicalEpochBoundaryState(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+
+	service := New(db, cache.NewStateSummaryCache())
+	beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	blkRoot, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveGenesisBlockRoot(ctx, blkRoot))
+	require.NoError(t, service.beaconDB.SaveBlock(ctx, blk))
+	require.NoError(t, service.beaconDB.SaveState(ctx, beaconState, blkRoot))
+
+	boundaryState := beaconState.Copy()
+	require.NoError(t, boundaryState.SetSlot(64))
+	require.NoError(t, service.epochBoundaryStateCache.put(blkRoot, boundaryState))
+	forkState := beaconState.Copy()
+	require.NoError(t, forkState.SetSlot(96))
+	require.NoError(t, service.epochBoundaryStateCache.put([32]byte{'f'}, forkState))
+
+	startState, err := service.replayStartState(ctx, 100, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64), startState.Slot(), "Did not replay from canonical epoch boundary state")
+}
@@ -19,7 +19,9 @@ import (
 type State struct {
 	beaconDB                db.NoHeadAccessDatabase
 	slotsPerArchivedPoint   uint64
+	archivedStateDiffs      uint64
 	hotStateCache           *cache.HotStateCache
+	coldStateCache          *cache.ColdStateCache
 	finalizedInfo           *finalizedInfo
 	stateSummaryCache       *cache.StateSummaryCache
 	epochBoundaryStateCache *epochBoundaryState
@@ -39,8 +41,10 @@ func New(db db.NoHeadAccessDatabase, stateSummaryCache *cache.StateSummaryCache)
 	return &State{
 		beaconDB:                db,
 		hotStateCache:           cache.NewHotStateCache(),
+		coldStateCache:          cache.NewColdStateCache(),
 		finalizedInfo:           &finalizedInfo{slot: 0, root: params.BeaconConfig().ZeroHash},
 		slotsPerArchivedPoint:   params.BeaconConfig().SlotsPerArchivedPoint,
+		archivedStateDiffs:      params.BeaconConfig().ArchivedStateDiffs,
 		stateSummaryCache:       stateSummaryCache,
 		epochBoundaryStateCache: newBoundaryStateCache(),
 	}
@@ -0,0 +1,327 @@
+package stategen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// stateDiffVersion prefixes every encoded state diff so the format can evolve.
+const stateDiffVersion = 1
+
+var errInvalidStateDiff = errors.New("invalid state diff")
+
+// stateDiff describes a beacon state relative to a full base state. The large lists and vectors
+// of the state are stored as the entries which differ from the base state, or as deltas for the
+// balances and slashings which change at every epoch, while the rest of the state is kept whole.
+type stateDiff struct {
+	// baseSlot is the slot of the full state the diff applies to.
+	baseSlot uint64
+	// state is the target state with the fields below cleared.
+	state           *pb.BeaconState
+	blockRoots      *sparseList
+	stateRoots      *sparseList
+	historicalRoots *sparseList
+	randaoMixes     *sparseList
+	validators      *sparseList
+	balances        []int64
+	slashings       []int64
+}
+
+// sparseList holds the length of a list of byte values and the entries differing from a base list.
+type sparseList struct {
+	length  uint64
+	indices []uint64
+	values  [][]byte
+}
+
+// computeStateDiff returns the diff of the target state against the base state.
+func computeStateDiff(base, target *state.BeaconState) (*stateDiff, error) {
+	baseState := base.InnerStateUnsafe()
+	targetState := target.CloneInnerState()
+	baseValidators, err := marshalValidators(baseState.Validators)
+	if err != nil {
+		return nil, err
+	}
+	targetValidators, err := marshalValidators(targetState.Validators)
+	if err != nil {
+		return nil, err
+	}
+	d := &stateDiff{
+		baseSlot:        baseState.Slot,
+		state:           targetState,
+		blockRoots:      diffList(baseState.BlockRoots, targetState.BlockRoots),
+		stateRoots:      diffList(baseState.StateRoots, targetState.StateRoots),
+		historicalRoots: diffList(baseState.HistoricalRoots, targetState.HistoricalRoots),
+		randaoMixes:     diffList(baseState.RandaoMixes, targetState.RandaoMixes),
+		validators:      diffList(baseValidators, targetValidators),
+		balances:        diffUint64s(baseState.Balances, targetState.Balances),
+		slashings:       diffUint64s(baseState.Slashings, targetState.Slashings),
+	}
+	targetState.BlockRoots = nil
+	targetState.StateRoots = nil
+	targetState.HistoricalRoots = nil
+	targetState.RandaoMixes = nil
+	targetState.Validators = nil
+	targetState.Balances = nil
+	targetState.Slashings = nil
+	return d, nil
+}
+
+// apply returns the target state of the diff from its base state.
+func (d *stateDiff) apply(base *state.BeaconState) (*state.BeaconState, error) {
+	if base.Slot() != d.baseSlot {
+		return nil, fmt.Errorf("state diff applies to a state at slot %d, not %d", d.baseSlot, base.Slot())
+	}
+	baseState := base.InnerStateUnsafe()
+	target := proto.Clone(d.state).(*pb.BeaconState)
+	var err error
+	if target.BlockRoots, err = d.blockRoots.apply(baseState.BlockRoots); err != nil {
+		return nil, err
+	}
+	if target.StateRoots, err = d.stateRoots.apply(baseState.StateRoots); err != nil {
+		return nil, err
+	}
+	if target.HistoricalRoots, err = d.historicalRoots.apply(baseState.HistoricalRoots); err != nil {
+		return nil, err
+	}
+	if target.RandaoMixes, err = d.randaoMixes.apply(baseState.RandaoMixes); err != nil {
+		return nil, err
+	}
+	baseValidators, err := marshalValidators(baseState.Validators)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := d.validators.apply(baseValidators)
+	if err != nil {
+		return nil, err
+	}
+	target.Validators = make([]*ethpb.Validator, len(validators))
+	for i, enc := range validators {
+		target.Validators[i] = &ethpb.Validator{}
+		if err := proto.Unmarshal(enc, target.Validators[i]); err != nil {
+			return nil, err
+		}
+	}
+	target.Balances = applyUint64s(baseState.Balances, d.balances)
+	target.Slashings = applyUint64s(baseState.Slashings, d.slashings)
+	return state.InitializeFromProtoUnsafe(target)
+}
+
+// This returns the entries of the target list which differ from the base list.
+func diffList(base, target [][]byte) *sparseList {
+	l := &sparseList{length: uint64(len(target))}
+	for i, v := range target {
+		if i < len(base) && bytes.Equal(base[i], v) {
+			continue
+		}
+		l.indices = append(l.indices, uint64(i))
+		l.values = append(l.values, v)
+	}
+	return l
+}
+
+// This returns the target list from the base list. Indices are strictly increasing, and every entry
+// past the end of the base list must be in the diff.
+func (l *sparseList) apply(base [][]byte) ([][]byte, error) {
+	list := make([][]byte, l.length)
+	copy(list, base)
+	appended := uint64(0)
+	for i, idx := range l.indices {
+		if idx >= l.length || (i > 0 && idx <= l.indices[i-1]) {
+			return nil, errInvalidStateDiff
+		}
+		if idx >= uint64(len(base)) {
+			appended++
+		}
+		list[idx] = l.values[i]
+	}
+	if l.length > uint64(len(base)) && appended != l.length-uint64(len(base)) {
+		return nil, errors.Wrap(errInvalidStateDiff, "missing list entries")
+	}
+	return list, nil
+}
+
+// This returns the difference of each target value with the base value at the same index, or with
+// zero past the end of the base list. Balances mostly change by small amounts, which the varint
+// encoding of the deltas stores in a few bytes.
+func diffUint64s(base, target []uint64) []int64 {
+	deltas := make([]int64, len(target))
+	for i, v := range target {
+		var b uint64
+		if i < len(base) {
+			b = base[i]
+		}
+		deltas[i] = int64(v - b)
+	}
+	return deltas
+}
+
+func applyUint64s(base []uint64, deltas []int64) []uint64 {
+	values := make([]uint64, len(deltas))
+	for i, delta := range deltas {
+		var b uint64
+		if i < len(base) {
+			b = base[i]
+		}
+		values[i] = b + uint64(delta)
+	}
+	return values
+}
+
+func marshalValidators(validators []*ethpb.Validator) ([][]byte, error) {
+	encs := make([][]byte, len(validators))
+	for i, v := range validators {
+		enc, err := proto.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		encs[i] = enc
+	}
+	return encs, nil
+}
+
+// marshal encodes the diff as its version, the base slot, the partial state and each list in
+// turn, compressed with snappy.
+func (d *stateDiff) marshal() ([]byte, error) {
+	enc, err := proto.Marshal(d.state)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	writeUvarint(buf, stateDiffVersion)
+	writeUvarint(buf, d.baseSlot)
+	writeBytes(buf, enc)
+	for _, l := range []*sparseList{d.blockRoots, d.stateRoots, d.historicalRoots, d.randaoMixes, d.validators} {
+		writeUvarint(buf, l.length)
+		writeUvarint(buf, uint64(len(l.indices)))
+		for i, idx := range l.indices {
+			writeUvarint(buf, idx)
+			writeBytes(buf, l.values[i])
+		}
+	}
+	for _, deltas := range [][]int64{d.balances, d.slashings} {
+		writeUvarint(buf, uint64(len(deltas)))
+		for _, delta := range deltas {
+			writeVarint(buf, delta)
+		}
+	}
+	return snappy.Encode(nil, buf.Bytes()), nil
+}
+
+func unmarshalStateDiff(enc []byte) (*stateDiff, error) {
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	r := bytes.NewReader(dec)
+	version, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if version != stateDiffVersion {
+		return nil, fmt.Errorf("unsupported state diff version %d", version)
+	}
+	d := &stateDiff{state: &pb.BeaconState{}}
+	if d.baseSlot, err = binary.ReadUvarint(r); err != nil {
+		return nil, err
+	}
+	stateEnc, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(stateEnc, d.state); err != nil {
+		return nil, err
+	}
+	lists := []**sparseList{&d.blockRoots, &d.stateRoots, &d.historicalRoots, &d.randaoMixes, &d.validators}
+	for _, l := range lists {
+		if *l, err = readSparseList(r); err != nil {
+			return nil, err
+		}
+	}
+	for _, deltas := range []*[]int64{&d.balances, &d.slashings} {
+		n, err := readLength(r)
+		if err != nil {
+			return nil, err
+		}
+		*deltas = make([]int64, n)
+		for i := range *deltas {
+			if (*deltas)[i], err = binary.ReadVarint(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r.Len() != 0 {
+		return nil, errInvalidStateDiff
+	}
+	return d, nil
+}
+
+func readSparseList(r *bytes.Reader) (*sparseList, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	n, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+	l := &sparseList{length: length, indices: make([]uint64, n), values: make([][]byte, n)}
+	for i := uint64(0); i < n; i++ {
+		if l.indices[i], err = binary.ReadUvarint(r); err != nil {
+			return nil, err
+		}
+		if l.values[i], err = readBytes(r); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// This reads a length which cannot exceed the remaining bytes, as every counted item takes at
+// least a byte, so a corrupted diff never makes a huge allocation.
+func readLength(r *bytes.Reader) (uint64, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(r.Len()) {
+		return 0, errInvalidStateDiff
+	}
+	return n, nil
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func writeVarint(buf *bytes.Buffer, v int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func writeBytes(buf *bytes.Buffer, v []byte) {
+	writeUvarint(buf, uint64(len(v)))
+	buf.Write(v)
+}
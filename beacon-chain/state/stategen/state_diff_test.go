@@ -0,0 +1,114 @@
+package stategen
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// This returns a copy of the state advanced to the slot with a few of its lists modified.
+func modifiedState(t *testing.T, base *state.BeaconState, slot uint64) *state.BeaconState {
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(slot))
+	require.NoError(t, st.UpdateBalancesAtIndex(3, st.Balances()[3]-100))
+	require.NoError(t, st.UpdateBalancesAtIndex(5, st.Balances()[5]+100))
+	require.NoError(t, st.UpdateBlockRootAtIndex(slot, [32]byte{'b'}))
+	require.NoError(t, st.UpdateRandaoMixesAtIndex(1, bytes.Repeat([]byte{'r'}, 32)))
+	require.NoError(t, st.UpdateSlashingsAtIndex(0, 32))
+	require.NoError(t, st.AppendHistoricalRoots([32]byte{'h'}))
+	require.NoError(t, st.AppendValidator(&ethpb.Validator{PublicKey: bytes.Repeat([]byte{'p'}, 48), ExitEpoch: 10}))
+	require.NoError(t, st.AppendBalance(32))
+	return st
+}
+
+func TestStateDiff_RoundTrip(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target := modifiedState(t, base, 64)
+
+	diff, err := computeStateDiff(base, target)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(diff.blockRoots.indices))
+	assert.Equal(t, 1, len(diff.validators.indices))
+	enc, err := diff.marshal()
+	require.NoError(t, err)
+	full, err := target.InnerStateUnsafe().Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, true, len(enc) < len(full), "Expected the diff to be smaller than the state")
+
+	decoded, err := unmarshalStateDiff(enc)
+	require.NoError(t, err)
+	applied, err := decoded.apply(base)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(target.InnerStateUnsafe(), applied.InnerStateUnsafe()), "Did not apply state diff")
+	wantRoot, err := target.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotRoot, err := applied.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
+
+func TestStateDiff_Invalid(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	diff, err := computeStateDiff(base, modifiedState(t, base, 64))
+	require.NoError(t, err)
+	other := base.Copy()
+	require.NoError(t, other.SetSlot(1))
+	_, err = diff.apply(other)
+	assert.ErrorContains(t, "state diff applies to a state at slot 0", err)
+
+	enc, err := diff.marshal()
+	require.NoError(t, err)
+	_, err = unmarshalStateDiff(enc[:len(enc)/2])
+	assert.NotNil(t, err)
+	_, err = unmarshalStateDiff([]byte("not a diff"))
+	assert.NotNil(t, err)
+
+	// A list longer than its base needs every appended entry.
+	l := &sparseList{length: 3, indices: []uint64{2}, values: [][]byte{{'c'}}}
+	_, err = l.apply([][]byte{{'a'}})
+	assert.ErrorContains(t, "missing list entries", err)
+}
+
+func TestLoadColdStateBySlot_FromStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db, _ := testDB.SetupDB(t)
+
+	service := New(db, cache.NewStateSummaryCache())
+	service.slotsPerArchivedPoint = 1
+	service.archivedStateDiffs = 1
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	blkRoot, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveGenesisBlockRoot(ctx, blkRoot))
+	require.NoError(t, service.beaconDB.SaveBlock(ctx, blk))
+	require.NoError(t, service.beaconDB.SaveState(ctx, beaconState, blkRoot))
+
+	target := modifiedState(t, beaconState, 1)
+	r := [32]byte{'a'}
+	require.NoError(t, service.saveColdState(ctx, r, target))
+	assert.Equal(t, false, service.beaconDB.HasState(ctx, r), "Expected the archived point to be saved as a diff")
+	assert.Equal(t, true, service.beaconDB.HasStateDiff(ctx, r))
+
+	loadedState, err := service.loadColdStateBySlot(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(target.InnerStateUnsafe(), loadedState.InnerStateUnsafe()), "Did not load state from diff")
+
+	// Every other archived point is saved in full.
+	full := modifiedState(t, beaconState, 2)
+	r = [32]byte{'b'}
+	require.NoError(t, service.saveColdState(ctx, r, full))
+	assert.Equal(t, true, service.beaconDB.HasState(ctx, r))
+	assert.Equal(t, false, service.beaconDB.HasStateDiff(ctx, r))
+}
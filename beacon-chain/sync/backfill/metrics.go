@@ -0,0 +1,21 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillLowestSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_lowest_slot",
+		Help: "The slot of the lowest block saved by backfilling from the checkpoint origin.",
+	})
+	backfillBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_blocks_total",
+		Help: "Number of historical blocks saved by the backfill service.",
+	})
+	backfillComplete = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_complete",
+		Help: "Set to 1 once all blocks down to genesis have been backfilled.",
+	})
+)
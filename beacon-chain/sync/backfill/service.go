@@ -0,0 +1,356 @@
+// Package backfill downloads the historical blocks below the checkpoint a beacon node
+// was started from, walking backwards from the oldest known block down to genesis.
+package backfill
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	streamhelpers "github.com/libp2p/go-libp2p-core/helpers"
+	"github.com/libp2p/go-libp2p-core/mux"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/pruner"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = shared.Service(&Service{})
+
+const (
+	// pollingInterval is how often the service checks for initial sync completion and peers.
+	pollingInterval = 6 * time.Second
+	// batchSize is the number of slots requested from a peer at a time.
+	batchSize = 64
+)
+
+var errNoPeersAvailable = errors.New("no peers available for backfilling")
+
+// StatusFetcher reports the progress of historical block backfilling.
+type StatusFetcher interface {
+	LowestBackfilledSlot() uint64
+	BackfillComplete() bool
+}
+
+// Config to set up the backfill service.
+type Config struct {
+	P2P         p2p.P2P
+	DB          db.NoHeadAccessDatabase
+	InitialSync prysmsync.Checker
+	// HistoryRetentionEpochs stops backfilling at the history retention window of a pruned
+	// database. Zero backfills down to genesis.
+	HistoryRetentionEpochs uint64
+}
+
+// Service backfills the blocks below a node's checkpoint origin. Blocks are verified
+// against the parent root of their child and saved without running the state transition.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	p2p         p2p.P2P
+	db          db.NoHeadAccessDatabase
+	initialSync prysmsync.Checker
+	retention   uint64
+	lowestSlot  uint64
+	complete    bool
+	lock        sync.RWMutex
+}
+
+// NewService configures the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		p2p:         cfg.P2P,
+		db:          cfg.DB,
+		initialSync: cfg.InitialSync,
+		retention:   cfg.HistoryRetentionEpochs,
+	}
+}
+
+// Start the backfill service.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service. Backfilling never makes the node unhealthy.
+func (s *Service) Status() error {
+	return nil
+}
+
+// LowestBackfilledSlot returns the slot of the lowest block known to the node.
+func (s *Service) LowestBackfilledSlot() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.lowestSlot
+}
+
+// BackfillComplete returns true once the node has every block down to genesis, or down to the
+// history retention window when one is set, which is also the case for nodes which were not
+// started from a checkpoint.
+func (s *Service) BackfillComplete() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.complete
+}
+
+func (s *Service) run() {
+	lowest, err := s.startBlock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not determine where to resume backfilling from")
+		return
+	}
+	if lowest == nil {
+		s.setProgress(0, true)
+		return
+	}
+	s.setProgress(lowest.Block.Slot, isGenesisBlock(lowest))
+
+	// Initial sync has priority over backfilling for peer bandwidth.
+	ticker := time.NewTicker(pollingInterval)
+	defer ticker.Stop()
+	for s.initialSync.Syncing() {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+
+	log.WithField("slot", lowest.Block.Slot).Info("Backfilling historical blocks")
+	searchEnd := lowest.Block.Slot
+	for !s.BackfillComplete() {
+		if s.ctx.Err() != nil {
+			return
+		}
+		historyStart, err := s.historyStartSlot(s.ctx)
+		if err != nil {
+			log.WithError(err).Error("Could not determine the history retention window")
+			return
+		}
+		if searchEnd <= historyStart {
+			s.setProgress(lowest.Block.Slot, true)
+			break
+		}
+		start := uint64(0)
+		if searchEnd > batchSize {
+			start = searchEnd - batchSize
+		}
+		if start < historyStart {
+			start = historyStart
+		}
+		blks, err := s.fetchBatch(s.ctx, start, searchEnd-start, lowest)
+		if err != nil {
+			log.WithError(err).Debug("Could not backfill batch of blocks")
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			continue
+		}
+		// The range consists of skipped slots only, keep searching below it.
+		if len(blks) == 0 {
+			if start == historyStart && historyStart > 0 {
+				s.setProgress(lowest.Block.Slot, true)
+				break
+			}
+			if start == 0 {
+				log.WithField("slot", lowest.Block.Slot).Error("Could not find the parent of the lowest block")
+				return
+			}
+			searchEnd = start
+			continue
+		}
+		if err := s.saveBatch(s.ctx, blks); err != nil {
+			log.WithError(err).Error("Could not save backfilled blocks")
+			return
+		}
+		lowest = blks[0]
+		searchEnd = lowest.Block.Slot
+		s.setProgress(lowest.Block.Slot, isGenesisBlock(lowest))
+	}
+	log.Info("Backfilled all historical blocks")
+}
+
+// This returns the slot below which blocks are not backfilled, as they would be pruned again.
+func (s *Service) historyStartSlot(ctx context.Context) (uint64, error) {
+	if s.retention == 0 {
+		return 0, nil
+	}
+	cp, err := s.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return pruner.HistoryStartSlot(cp.Epoch, s.retention), nil
+}
+
+// This returns the lowest block the node has saved, where backfilling resumes from. A nil
+// block is returned if the node has no blocks to backfill.
+func (s *Service) startBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error) {
+	root, err := s.db.BackfillBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFoundBackfillBlockRoot) {
+		root, err = s.db.OriginBlockRoot(ctx)
+		if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+			// Nodes synced from genesis have every block.
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.db.Block(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil || blk.Block == nil {
+		return nil, fmt.Errorf("lowest block %#x is not in db", root)
+	}
+	return blk, nil
+}
+
+// This requests blocks by range from the available peers until a peer returns a batch
+// which correctly chains up to the lowest known block.
+func (s *Service) fetchBatch(
+	ctx context.Context,
+	start, count uint64,
+	child *ethpb.SignedBeaconBlock,
+) ([]*ethpb.SignedBeaconBlock, error) {
+	_, peers := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, helpers.SlotToEpoch(child.Block.Slot))
+	if len(peers) == 0 {
+		return nil, errNoPeersAvailable
+	}
+	req := &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     count,
+		Step:      1,
+	}
+	for _, pid := range peers {
+		blks, err := s.requestBlocks(ctx, req, pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not request blocks")
+			continue
+		}
+		verified, err := verifyBatch(blks, child)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Peer returned invalid blocks")
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			continue
+		}
+		return verified, nil
+	}
+	return nil, errors.New("no peer returned a valid batch of blocks")
+}
+
+func (s *Service) requestBlocks(
+	ctx context.Context,
+	req *p2ppb.BeaconBlocksByRangeRequest,
+	pid peer.ID,
+) ([]*ethpb.SignedBeaconBlock, error) {
+	stream, err := s.p2p.Send(ctx, req, p2p.RPCBlocksByRangeTopic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := streamhelpers.FullClose(stream); err != nil && err.Error() != mux.ErrReset.Error() {
+			log.WithError(err).Debugf("Failed to close stream with protocol %s", stream.Protocol())
+		}
+	}()
+
+	resp := make([]*ethpb.SignedBeaconBlock, 0, req.Count)
+	for i := uint64(0); ; i++ {
+		blk, err := prysmsync.ReadChunkedBlock(stream, s.p2p, i == 0)
+		if err == io.EOF {
+			break
+		}
+		if i >= req.Count {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, blk)
+	}
+	return resp, nil
+}
+
+// verifyBatch checks that the blocks of a batch, sorted by ascending slot, form a chain ending
+// in the parent of the child block. The returned blocks are in ascending slot order.
+func verifyBatch(blks []*ethpb.SignedBeaconBlock, child *ethpb.SignedBeaconBlock) ([]*ethpb.SignedBeaconBlock, error) {
+	expectedRoot := child.Block.ParentRoot
+	lowestSlot := child.Block.Slot
+	for i := len(blks) - 1; i >= 0; i-- {
+		blk := blks[i]
+		if blk == nil || blk.Block == nil {
+			return nil, errors.New("nil block in batch")
+		}
+		if blk.Block.Slot >= lowestSlot {
+			return nil, fmt.Errorf("block slot %d is not lower than its child slot %d", blk.Block.Slot, lowestSlot)
+		}
+		root, err := stateutil.BlockRoot(blk.Block)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(root[:], expectedRoot) {
+			return nil, fmt.Errorf("block root %#x at slot %d does not match child parent root %#x", root, blk.Block.Slot, expectedRoot)
+		}
+		expectedRoot = blk.Block.ParentRoot
+		lowestSlot = blk.Block.Slot
+	}
+	return blks, nil
+}
+
+// This saves the backfilled blocks and persists the root of the lowest one, so backfilling can resume
+// from it after a restart.
+func (s *Service) saveBatch(ctx context.Context, blks []*ethpb.SignedBeaconBlock) error {
+	if err := s.db.SaveBlocks(ctx, blks); err != nil {
+		return err
+	}
+	lowestRoot, err := stateutil.BlockRoot(blks[0].Block)
+	if err != nil {
+		return err
+	}
+	if err := s.db.SaveBackfillBlockRoot(ctx, lowestRoot); err != nil {
+		return err
+	}
+	backfillBlocksCount.Add(float64(len(blks)))
+	log.WithFields(logrus.Fields{
+		"slot":   blks[0].Block.Slot,
+		"blocks": len(blks),
+	}).Debug("Backfilled blocks")
+	return nil
+}
+
+func (s *Service) setProgress(slot uint64, complete bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lowestSlot = slot
+	s.complete = complete
+	backfillLowestSlot.Set(float64(slot))
+	if complete {
+		backfillComplete.Set(1)
+	}
+}
+
+func isGenesisBlock(blk *ethpb.SignedBeaconBlock) bool {
+	return blk.Block.Slot == 0
+}
@@ -0,0 +1,101 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// This builds a chain of blocks at the given slots, each linked to the previous one.
+func chainOfBlocks(t *testing.T, slots ...uint64) []*ethpb.SignedBeaconBlock {
+	blks := make([]*ethpb.SignedBeaconBlock, len(slots))
+	parentRoot := make([]byte, 32)
+	for i, slot := range slots {
+		blk := testutil.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ParentRoot = parentRoot
+		root, err := stateutil.BlockRoot(blk.Block)
+		require.NoError(t, err)
+		parentRoot = root[:]
+		blks[i] = blk
+	}
+	return blks
+}
+
+func TestVerifyBatch(t *testing.T) {
+	blks := chainOfBlocks(t, 0, 1, 3, 4, 7)
+	child := blks[len(blks)-1]
+
+	verified, err := verifyBatch(blks[:len(blks)-1], child)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(verified))
+
+	_, err = verifyBatch([]*ethpb.SignedBeaconBlock{blks[0], blks[1], blks[3]}, child)
+	assert.ErrorContains(t, "does not match child parent root", err)
+
+	_, err = verifyBatch([]*ethpb.SignedBeaconBlock{blks[0], blks[1], child}, blks[2])
+	assert.ErrorContains(t, "is not lower than its child slot", err)
+
+	verified, err = verifyBatch([]*ethpb.SignedBeaconBlock{}, child)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(verified))
+}
+
+func TestService_StartBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	s := NewService(ctx, &Config{DB: beaconDB})
+
+	// No origin root, the node was synced from genesis.
+	blk, err := s.startBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpb.SignedBeaconBlock)(nil), blk)
+
+	blks := chainOfBlocks(t, 10, 20, 30)
+	require.NoError(t, beaconDB.SaveBlock(ctx, blks[2]))
+	originRoot, err := stateutil.BlockRoot(blks[2].Block)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, originRoot))
+	blk, err = s.startBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(30), blk.Block.Slot)
+
+	// Resumes from the lowest backfilled block.
+	require.NoError(t, s.saveBatch(ctx, blks[:2]))
+	blk, err = s.startBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), blk.Block.Slot)
+	assert.Equal(t, true, beaconDB.HasBlock(ctx, blockRoot(t, blks[1])))
+}
+
+func blockRoot(t *testing.T, blk *ethpb.SignedBeaconBlock) [32]byte {
+	root, err := stateutil.BlockRoot(blk.Block)
+	require.NoError(t, err)
+	return root
+}
+
+func TestService_HistoryStartSlot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, _ := dbtest.SetupDB(t)
+	blks := chainOfBlocks(t, 0, helpers.StartSlot(5))
+	require.NoError(t, beaconDB.SaveBlocks(ctx, blks))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, blockRoot(t, blks[0])))
+	finalizedRoot := blockRoot(t, blks[1])
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &p2ppb.StateSummary{Slot: helpers.StartSlot(5), Root: finalizedRoot[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 5, Root: finalizedRoot[:]}))
+
+	slot, err := NewService(ctx, &Config{DB: beaconDB}).historyStartSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), slot)
+	slot, err = NewService(ctx, &Config{DB: beaconDB, HistoryRetentionEpochs: 2}).historyStartSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.StartSlot(3), slot)
+}
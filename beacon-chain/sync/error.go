@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
@@ -22,6 +23,7 @@ var errInvalidEpoch = errors.New("invalid epoch")
 var errInvalidFinalizedRoot = errors.New("invalid finalized root")
 var errInvalidSequenceNum = errors.New(seqError)
 var errGeneric = errors.New(genericError)
+var errUploadLimitReached = errors.New("upload limit reached")
 
 var responseCodeSuccess = byte(0x00)
 var responseCodeInvalidRequest = byte(0x01)
@@ -104,3 +106,15 @@ func readStatusCodeNoDeadline(stream network.Stream, encoding encoder.NetworkEnc
 
 	return b[0], string(msg.Message), nil
 }
+
+// penalizeDecodeError increments the bad responses count of the remote peer, when the error is a
+// result of the peer sending a malformed or oversized message. Peers are only penalized with strict
+// ssz size limits enabled, so that enabling the encoder limits does not change peer scoring on its own.
+func penalizeDecodeError(provider p2p.PeersProvider, stream network.Stream, err error) {
+	if !featureconfig.Get().EnableStrictSSZLimits {
+		return
+	}
+	if encoder.IsDecodeError(err) {
+		provider.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+	}
+}
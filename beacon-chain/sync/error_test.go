@@ -2,10 +2,17 @@ package sync
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
@@ -27,3 +34,38 @@ func TestRegularSync_generateErrorResponse(t *testing.T) {
 	require.NoError(t, r.p2p.Encoding().DecodeWithMaxLength(buf, msg))
 	assert.Equal(t, "something bad happened", string(msg.Message), "Received the wrong message")
 }
+
+func TestPenalizeDecodeError(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.BHost.ID(), p2.BHost.Addrs()[0], network.DirUnknown)
+
+	pcl := protocol.ID("/testing")
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	scorer := p1.Peers().Scorers().BadResponsesScorer()
+
+	// Decode errors are not penalized without strict size limits.
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{EnableStrictSSZLimits: false})
+	penalizeDecodeError(p1, stream, &encoder.DecodeError{Err: encoder.ErrExceedsMaxSize})
+	res, err := scorer.Count(p2.BHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+	resetCfg()
+
+	resetCfg = featureconfig.InitWithReset(&featureconfig.Flags{EnableStrictSSZLimits: true})
+	defer resetCfg()
+
+	// Failures of the stream are not penalized.
+	penalizeDecodeError(p1, stream, errors.New("stream reset"))
+	res, err = scorer.Count(p2.BHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+
+	penalizeDecodeError(p1, stream, &encoder.DecodeError{Err: encoder.ErrExceedsMaxSize})
+	res, err = scorer.Count(p2.BHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, res, "Peer wasn't penalized for sending a malformed message")
+}
@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// gossipHandler is the validator and the subscriber of a gossip topic.
+type gossipHandler struct {
+	validate pubsub.ValidatorEx
+	handle   subHandler
+}
+
+// gossipHandlers returns the handlers of each of the gossip topic formats.
+func (s *Service) gossipHandlers() map[string]gossipHandler {
+	return map[string]gossipHandler{
+		p2p.BlockSubnetTopicFormat:             {s.validateBeaconBlockPubSub, s.beaconBlockSubscriber},
+		p2p.AggregateAndProofSubnetTopicFormat: {s.validateAggregateAndProof, s.beaconAggregateProofSubscriber},
+		p2p.ExitSubnetTopicFormat:              {s.validateVoluntaryExit, s.voluntaryExitSubscriber},
+		p2p.ProposerSlashingSubnetTopicFormat:  {s.validateProposerSlashing, s.proposerSlashingSubscriber},
+		p2p.AttesterSlashingSubnetTopicFormat:  {s.validateAttesterSlashing, s.attesterSlashingSubscriber},
+		p2p.AttestationSubnetTopicFormat:       {s.validateCommitteeIndexBeaconAttestation, s.committeeIndexBeaconAttestationSubscriber},
+	}
+}
+
+// gossipTopicFormat returns the topic format of a full gossip topic, which includes the fork
+// digest, the subnet index for attestations and the encoding suffix.
+func (s *Service) gossipTopicFormat(topic string) (string, error) {
+	topic = strings.TrimSuffix(topic, s.p2p.Encoding().ProtocolSuffix())
+	if len(strings.Split(topic, "/")) < 4 {
+		return "", fmt.Errorf("invalid gossip topic %s", topic)
+	}
+	topic = s.replaceForkDigest(topic)
+	attestationPrefix := strings.TrimSuffix(p2p.AttestationSubnetTopicFormat, "%d")
+	if strings.HasPrefix(topic, attestationPrefix) {
+		return p2p.AttestationSubnetTopicFormat, nil
+	}
+	return topic, nil
+}
+
+// ReplayGossipMessage runs a gossip message received from the given peer through the validator
+// of its topic and, once accepted, through the subscriber of the topic. This is used to replay
+// recorded gossip traces against a local database. The result of the validation is returned.
+func (s *Service) ReplayGossipMessage(ctx context.Context, topic string, pid peer.ID, data []byte) (pubsub.ValidationResult, error) {
+	if s.seenBlockCache == nil {
+		return pubsub.ValidationIgnore, errors.New("sync service has not been started")
+	}
+	format, err := s.gossipTopicFormat(topic)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	handler, ok := s.gossipHandlers()[format]
+	if !ok {
+		return pubsub.ValidationIgnore, fmt.Errorf("no handler for gossip topic %s", topic)
+	}
+	msg := &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:     data,
+			TopicIDs: []string{topic},
+		},
+		ReceivedFrom: pid,
+	}
+	result := handler.validate(ctx, pid, msg)
+	if result != pubsub.ValidationAccept {
+		return result, nil
+	}
+	m, ok := msg.ValidatorData.(proto.Message)
+	if !ok {
+		return result, errors.New("accepted message was not decoded by the validator")
+	}
+	return result, handler.handle(ctx, m)
+}
@@ -0,0 +1,53 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_gossipTopicFormat(t *testing.T) {
+	s := &Service{p2p: p2ptest.NewTestP2P(t)}
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{topic: "/eth2/abcdef01/beacon_block/ssz_snappy", want: p2p.BlockSubnetTopicFormat},
+		{topic: "/eth2/abcdef01/beacon_attestation_12/ssz_snappy", want: p2p.AttestationSubnetTopicFormat},
+		{topic: "/eth2/abcdef01/beacon_aggregate_and_proof/ssz_snappy", want: p2p.AggregateAndProofSubnetTopicFormat},
+		{topic: "/eth2/abcdef01/voluntary_exit/ssz_snappy", want: p2p.ExitSubnetTopicFormat},
+	}
+	for _, tt := range tests {
+		format, err := s.gossipTopicFormat(tt.topic)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, format)
+	}
+	_, err := s.gossipTopicFormat("beacon_block")
+	assert.ErrorContains(t, "invalid gossip topic", err)
+}
+
+func TestService_ReplayGossipMessage(t *testing.T) {
+	ctx := context.Background()
+	s := &Service{
+		p2p:         p2ptest.NewTestP2P(t),
+		initialSync: &mockSync.Sync{IsSyncing: false},
+	}
+	topic := "/eth2/abcdef01/proposer_slashing/ssz_snappy"
+	_, err := s.ReplayGossipMessage(ctx, topic, "peer", []byte{})
+	assert.ErrorContains(t, "sync service has not been started", err)
+	require.NoError(t, s.initCaches())
+
+	_, err = s.ReplayGossipMessage(ctx, "/eth2/abcdef01/unknown/ssz_snappy", "peer", []byte{})
+	assert.ErrorContains(t, "no handler for gossip topic", err)
+
+	// Messages which cannot be decoded are rejected by the validator.
+	result, err := s.ReplayGossipMessage(ctx, topic, "peer", []byte("invalid"))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationReject, result)
+}
@@ -58,8 +58,8 @@ type blocksFetcherConfig struct {
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
-// On an incoming requests, requested block range is evenly divided
-// among available peers (for fair network load distribution).
+// On an incoming requests, requested block range is divided among
+// the best performing peers, proportionally to their throughput.
 type blocksFetcher struct {
 	sync.Mutex
 	ctx             context.Context
@@ -70,6 +70,7 @@ type blocksFetcher struct {
 	blocksPerSecond uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
+	peerStats       *peerStats
 	fetchRequests   chan *fetchRequestParams
 	fetchResponses  chan *fetchRequestResponse
 	quit            chan struct{} // termination notifier
@@ -115,6 +116,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		blocksPerSecond: uint64(blocksPerSecond),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
+		peerStats:       newPeerStats(),
 		fetchRequests:   make(chan *fetchRequestParams, maxPendingRequests),
 		fetchResponses:  make(chan *fetchRequestResponse, maxPendingRequests),
 		quit:            make(chan struct{}),
@@ -167,6 +169,7 @@ func (f *blocksFetcher) loop() {
 			select {
 			case <-ticker.C:
 				f.removeStalePeerLocks(peerLockMaxAge)
+				f.peerStats.removeStale(peerLockMaxAge)
 			case <-f.ctx.Done():
 				ticker.Stop()
 				return
@@ -253,7 +256,8 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start, count uint64)
 	return response
 }
 
-// fetchBlocksFromPeer fetches blocks from a single randomly selected peer.
+// fetchBlocksFromPeer fetches blocks from the best ranked peers, splitting the range between them
+// proportionally to their throughput.
 func (f *blocksFetcher) fetchBlocksFromPeer(
 	ctx context.Context,
 	start, count uint64,
@@ -271,17 +275,112 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	if len(peers) == 0 {
 		return blocks, errNoPeersAvailable
 	}
+	peers = f.rankPeers(peers, count)
+	batches := f.allocateBatches(start, count, peers)
+
+	results := make([][]*eth.SignedBeaconBlock, len(batches))
+	errs := make([]error, len(batches))
+	wg := &sync.WaitGroup{}
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch *peerBatch) {
+			defer wg.Done()
+			results[i], errs[i] = f.fetchBatch(ctx, batch, peers)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	for i := range batches {
+		if errs[i] != nil {
+			return []*eth.SignedBeaconBlock{}, errs[i]
+		}
+		blocks = append(blocks, results[i]...)
+	}
+	return blocks, nil
+}
+
+// fetchBatch fetches a batch of blocks from the peer it is allocated to, falling back to the rest
+// of the peers on failure. Slow requests are hedged with the next peer in line.
+func (f *blocksFetcher) fetchBatch(
+	ctx context.Context,
+	batch *peerBatch,
+	peers []peer.ID,
+) ([]*eth.SignedBeaconBlock, error) {
 	req := &p2ppb.BeaconBlocksByRangeRequest{
-		StartSlot: start,
-		Count:     count,
+		StartSlot: batch.start,
+		Count:     batch.count,
 		Step:      1,
 	}
-	for i := 0; i < len(peers); i++ {
-		if blocks, err = f.requestBlocks(ctx, req, peers[i]); err == nil {
-			return blocks, err
+	candidates := make([]peer.ID, 0, len(peers))
+	candidates = append(candidates, batch.pid)
+	for _, pid := range peers {
+		if pid != batch.pid {
+			candidates = append(candidates, pid)
 		}
 	}
-	return blocks, nil
+	var err error
+	for i, pid := range candidates {
+		var hedgePID peer.ID
+		if i+1 < len(candidates) {
+			hedgePID = candidates[i+1]
+		}
+		var blocks []*eth.SignedBeaconBlock
+		if blocks, err = f.requestBlocksWithHedging(ctx, req, pid, hedgePID); err == nil {
+			return blocks, nil
+		}
+	}
+	return nil, errors.Wrapf(err, "could not fetch blocks in range [%d, %d)", batch.start, batch.start+batch.count)
+}
+
+// requestBlocksWithHedging requests blocks from the given peer. If the peer does not respond in time,
+// the same range is re-requested from the hedge peer, and the first successful response is returned.
+func (f *blocksFetcher) requestBlocksWithHedging(
+	ctx context.Context,
+	req *p2ppb.BeaconBlocksByRangeRequest,
+	pid, hedgePID peer.ID,
+) ([]*eth.SignedBeaconBlock, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type requestResult struct {
+		blocks []*eth.SignedBeaconBlock
+		err    error
+	}
+	results := make(chan *requestResult, 2)
+	request := func(pid peer.ID) {
+		blocks, err := f.requestBlocks(ctx, req, pid)
+		results <- &requestResult{blocks: blocks, err: err}
+	}
+
+	go request(pid)
+	pending := 1
+	hedgeTimer := time.NewTimer(f.hedgeDelay(pid))
+	defer hedgeTimer.Stop()
+
+	var err error
+	for pending > 0 {
+		select {
+		case <-hedgeTimer.C:
+			if hedgePID == "" {
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"peer":      pid,
+				"hedgePeer": hedgePID,
+				"start":     req.StartSlot,
+				"count":     req.Count,
+			}).Debug("Hedging slow blocks request")
+			go request(hedgePID)
+			pending++
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				return result.blocks, nil
+			}
+			err = result.err
+		}
+	}
+	return nil, err
 }
 
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
@@ -318,6 +417,26 @@ func (f *blocksFetcher) requestBlocks(
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
+
+	requestStart := time.Now()
+	resp, err := f.sendBlocksRequest(ctx, req, pid)
+	if err != nil {
+		// Requests cancelled by the fetcher are not the peer's fault.
+		if ctx.Err() == nil {
+			f.peerStats.recordFailure(pid)
+		}
+		return nil, err
+	}
+	f.peerStats.recordResponse(pid, time.Since(requestStart), req.Count)
+	return resp, nil
+}
+
+// sendBlocksRequest sends a BeaconBlocksByRangeRequest to the peer and reads the blocks from the response stream.
+func (f *blocksFetcher) sendBlocksRequest(
+	ctx context.Context,
+	req *p2ppb.BeaconBlocksByRangeRequest,
+	pid peer.ID,
+) ([]*eth.SignedBeaconBlock, error) {
 	stream, err := f.p2p.Send(ctx, req, p2p.RPCBlocksByRangeTopic, pid)
 	if err != nil {
 		return nil, err
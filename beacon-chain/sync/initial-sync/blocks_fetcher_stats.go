@@ -0,0 +1,220 @@
+package initialsync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+const (
+	// peerStatsWeight is the weight of the latest observation in peer performance moving averages.
+	peerStatsWeight = 0.3
+	// hedgeDelayFactor is a multiplier applied to the expected response time of a peer, to obtain
+	// the delay after which a request is hedged by re-requesting the same range from another peer.
+	hedgeDelayFactor = 2
+	// minHedgeDelay is the minimum delay before a request is hedged.
+	minHedgeDelay = 1 * time.Second
+	// maxHedgeDelay is the maximum delay before a request is hedged.
+	maxHedgeDelay = 10 * time.Second
+	// maxPeersPerRequest caps the number of peers a single fetch request is split between.
+	maxPeersPerRequest = 4
+	// minPeerBatchSize is the minimum number of slots requested from a single peer.
+	minPeerBatchSize = 16
+)
+
+// peerPerformance holds the observed response time and throughput of a peer.
+type peerPerformance struct {
+	responseTime    time.Duration
+	blocksPerSecond float64
+	updated         time.Time
+}
+
+// peerStats tracks the performance of the peers blocks are fetched from. It complements the block
+// provider scorer, which rewards processed blocks, with estimates of how fast peers respond.
+type peerStats struct {
+	sync.RWMutex
+	peers map[peer.ID]*peerPerformance
+}
+
+// peerBatch is a range of slots allocated to a single peer.
+type peerBatch struct {
+	pid   peer.ID
+	start uint64
+	count uint64
+}
+
+// newPeerStats creates an empty peer performance tracker.
+func newPeerStats() *peerStats {
+	return &peerStats{
+		peers: make(map[peer.ID]*peerPerformance),
+	}
+}
+
+// recordResponse updates the moving averages of a peer, which has served the given number of slots.
+// Throughput is measured in requested slots, so that skipped slots do not penalize a peer.
+func (s *peerStats) recordResponse(pid peer.ID, elapsed time.Duration, slots uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	blocksPerSecond := float64(slots)
+	if elapsed > 0 {
+		blocksPerSecond = float64(slots) / elapsed.Seconds()
+	}
+	stats, ok := s.peers[pid]
+	if !ok {
+		s.peers[pid] = &peerPerformance{
+			responseTime:    elapsed,
+			blocksPerSecond: blocksPerSecond,
+			updated:         roughtime.Now(),
+		}
+		return
+	}
+	stats.responseTime = time.Duration(peerStatsWeight*float64(elapsed) + (1-peerStatsWeight)*float64(stats.responseTime))
+	stats.blocksPerSecond = peerStatsWeight*blocksPerSecond + (1-peerStatsWeight)*stats.blocksPerSecond
+	stats.updated = roughtime.Now()
+}
+
+// recordFailure halves the throughput estimate of a peer which has failed to serve a request.
+func (s *peerStats) recordFailure(pid peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+
+	stats, ok := s.peers[pid]
+	if !ok {
+		s.peers[pid] = &peerPerformance{
+			responseTime: maxHedgeDelay,
+			updated:      roughtime.Now(),
+		}
+		return
+	}
+	stats.blocksPerSecond /= 2
+	stats.updated = roughtime.Now()
+}
+
+// estimate returns the expected response time and throughput of a peer. Peers without observations
+// are given the best estimates among known peers, so that they get a chance to be measured.
+func (s *peerStats) estimate(pid peer.ID) (time.Duration, float64) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.estimateNoLock(pid)
+}
+
+// estimateNoLock is a lock-free version of estimate.
+func (s *peerStats) estimateNoLock(pid peer.ID) (time.Duration, float64) {
+	if stats, ok := s.peers[pid]; ok {
+		return stats.responseTime, stats.blocksPerSecond
+	}
+	var responseTime time.Duration
+	var blocksPerSecond float64
+	for _, stats := range s.peers {
+		if stats.blocksPerSecond > blocksPerSecond {
+			blocksPerSecond = stats.blocksPerSecond
+		}
+		if responseTime == 0 || stats.responseTime < responseTime {
+			responseTime = stats.responseTime
+		}
+	}
+	return responseTime, blocksPerSecond
+}
+
+// removeStale removes peers which have not been observed for the given period.
+func (s *peerStats) removeStale(age time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	for pid, stats := range s.peers {
+		if time.Since(stats.updated) >= age {
+			delete(s.peers, pid)
+		}
+	}
+}
+
+// rankPeers orders peers by the expected time to serve the given number of slots, which accounts
+// for both the rate limiting delay and the observed throughput of a peer. Peers with the same
+// expectations keep their relative order.
+func (f *blocksFetcher) rankPeers(peers []peer.ID, count uint64) []peer.ID {
+	expected := make(map[peer.ID]time.Duration, len(peers))
+	f.peerStats.RLock()
+	for _, pid := range peers {
+		var wait time.Duration
+		if f.rateLimiter.Remaining(pid.String()) < int64(count) {
+			wait = f.rateLimiter.TillEmpty(pid.String())
+		}
+		responseTime, blocksPerSecond := f.peerStats.estimateNoLock(pid)
+		if blocksPerSecond > 0 {
+			responseTime = time.Duration(float64(count) / blocksPerSecond * float64(time.Second))
+		}
+		expected[pid] = wait + responseTime
+	}
+	f.peerStats.RUnlock()
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return expected[peers[i]] < expected[peers[j]]
+	})
+	return peers
+}
+
+// allocateBatches splits the range of slots between the best ranked peers, proportionally to their
+// throughput. The remainder of the division goes to the first (best ranked) peer.
+func (f *blocksFetcher) allocateBatches(start, count uint64, peers []peer.ID) []*peerBatch {
+	if len(peers) == 0 || count == 0 {
+		return []*peerBatch{}
+	}
+	n := count / minPeerBatchSize
+	if n > uint64(len(peers)) {
+		n = uint64(len(peers))
+	}
+	if n > maxPeersPerRequest {
+		n = maxPeersPerRequest
+	}
+	if n <= 1 {
+		return []*peerBatch{{pid: peers[0], start: start, count: count}}
+	}
+
+	weights := make([]float64, n)
+	totalWeight := float64(0)
+	for i := uint64(0); i < n; i++ {
+		_, weights[i] = f.peerStats.estimate(peers[i])
+		totalWeight += weights[i]
+	}
+	// Without any observations, the range is split evenly.
+	if totalWeight == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		totalWeight = float64(n)
+	}
+
+	sizes := make([]uint64, n)
+	allocated := uint64(0)
+	for i := range sizes {
+		sizes[i] = uint64(float64(count) * weights[i] / totalWeight)
+		allocated += sizes[i]
+	}
+	sizes[0] += count - allocated
+
+	batches := make([]*peerBatch, 0, n)
+	for i, size := range sizes {
+		if size == 0 {
+			continue
+		}
+		batches = append(batches, &peerBatch{pid: peers[i], start: start, count: size})
+		start += size
+	}
+	return batches
+}
+
+// hedgeDelay returns the time to wait for a response from the peer before the request is hedged.
+func (f *blocksFetcher) hedgeDelay(pid peer.ID) time.Duration {
+	responseTime, _ := f.peerStats.estimate(pid)
+	delay := hedgeDelayFactor * responseTime
+	if delay < minHedgeDelay {
+		return minHedgeDelay
+	}
+	if delay > maxHedgeDelay {
+		return maxHedgeDelay
+	}
+	return delay
+}
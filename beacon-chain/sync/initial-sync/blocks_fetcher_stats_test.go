@@ -0,0 +1,146 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kevinms/leakybucket-go"
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	p2pm "github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	beaconsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestBlocksFetcher_peerStats(t *testing.T) {
+	stats := newPeerStats()
+
+	// No observations.
+	responseTime, blocksPerSecond := stats.estimate("abc")
+	assert.Equal(t, time.Duration(0), responseTime)
+	assert.Equal(t, float64(0), blocksPerSecond)
+
+	stats.recordResponse("abc", 2*time.Second, 64)
+	responseTime, blocksPerSecond = stats.estimate("abc")
+	assert.Equal(t, 2*time.Second, responseTime)
+	assert.Equal(t, float64(32), blocksPerSecond)
+
+	// Moving averages are updated with the latest observation.
+	stats.recordResponse("abc", 1*time.Second, 64)
+	responseTime, blocksPerSecond = stats.estimate("abc")
+	assert.Equal(t, true, responseTime > 1*time.Second && responseTime < 2*time.Second)
+	assert.Equal(t, true, blocksPerSecond > 32 && blocksPerSecond < 64)
+
+	// Unknown peers are given the best estimates.
+	stats.recordResponse("def", 500*time.Millisecond, 64)
+	responseTime, blocksPerSecond = stats.estimate("xyz")
+	assert.Equal(t, 500*time.Millisecond, responseTime)
+	assert.Equal(t, float64(128), blocksPerSecond)
+
+	// Failures halve the throughput.
+	stats.recordFailure("def")
+	_, blocksPerSecond = stats.estimate("def")
+	assert.Equal(t, float64(64), blocksPerSecond)
+
+	stats.removeStale(0)
+	assert.Equal(t, 0, len(stats.peers))
+}
+
+func TestBlocksFetcher_allocateBatches(t *testing.T) {
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{})
+
+	t.Run("no observations", func(t *testing.T) {
+		fetcher.peerStats = newPeerStats()
+		batches := fetcher.allocateBatches(100, 64, []peer.ID{"a", "b", "c", "d", "e"})
+		require.Equal(t, maxPeersPerRequest, len(batches))
+		for i, batch := range batches {
+			assert.Equal(t, uint64(100+i*16), batch.start)
+			assert.Equal(t, uint64(16), batch.count)
+		}
+	})
+
+	t.Run("proportional to throughput", func(t *testing.T) {
+		fetcher.peerStats = newPeerStats()
+		fetcher.peerStats.recordResponse("a", time.Second, 300)
+		fetcher.peerStats.recordResponse("b", time.Second, 100)
+		batches := fetcher.allocateBatches(100, 64, []peer.ID{"a", "b"})
+		require.Equal(t, 2, len(batches))
+		assert.DeepEqual(t, &peerBatch{pid: "a", start: 100, count: 48}, batches[0])
+		assert.DeepEqual(t, &peerBatch{pid: "b", start: 148, count: 16}, batches[1])
+	})
+
+	t.Run("small range", func(t *testing.T) {
+		fetcher.peerStats = newPeerStats()
+		batches := fetcher.allocateBatches(100, minPeerBatchSize, []peer.ID{"a", "b"})
+		require.Equal(t, 1, len(batches))
+		assert.DeepEqual(t, &peerBatch{pid: "a", start: 100, count: minPeerBatchSize}, batches[0])
+	})
+}
+
+func TestBlocksFetcher_rankPeers(t *testing.T) {
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{})
+	// Non-leaking bucket, with initial capacity of 100.
+	fetcher.rateLimiter = leakybucket.NewCollector(0.000001, 100, false)
+	fetcher.peerStats.recordResponse("slow", 4*time.Second, 64)
+	fetcher.peerStats.recordResponse("fast", 1*time.Second, 64)
+	fetcher.peerStats.recordResponse("limited", 500*time.Millisecond, 64)
+	fetcher.rateLimiter.Add("limited", 90)
+
+	peers := fetcher.rankPeers([]peer.ID{"limited", "slow", "fast"}, 64)
+	assert.DeepEqual(t, []peer.ID{"fast", "slow", "limited"}, peers)
+}
+
+func TestBlocksFetcher_requestBlocksWithHedging(t *testing.T) {
+	p1 := p2pt.NewTestP2P(t)
+	p2 := p2pt.NewTestP2P(t)
+	p3 := p2pt.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Connect(p3)
+	require.Equal(t, 2, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	req := &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: 100,
+		Step:      1,
+		Count:     16,
+	}
+
+	protocol := core.ProtocolID(p2pm.RPCBlocksByRangeTopic + p2.Encoding().ProtocolSuffix())
+	serveBlocks := func(p *p2pt.TestP2P, delay time.Duration) func(stream network.Stream) {
+		return func(stream network.Stream) {
+			// The slow stream is reset once the hedged request completes, so close errors are ignored.
+			defer func() {
+				_ = stream.Close()
+			}()
+			m := &p2ppb.BeaconBlocksByRangeRequest{}
+			assert.NoError(t, p.Encoding().DecodeWithMaxLength(stream, m))
+			time.Sleep(delay)
+			for slot := m.StartSlot; slot < m.StartSlot+m.Count; slot++ {
+				blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: slot}}
+				if err := beaconsync.WriteChunk(stream, p.Encoding(), blk); err != nil {
+					return
+				}
+			}
+		}
+	}
+	p2.BHost.SetStreamHandler(protocol, serveBlocks(p2, 5*time.Second))
+	p3.BHost.SetStreamHandler(protocol, serveBlocks(p3, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{p2p: p1})
+
+	start := time.Now()
+	blocks, err := fetcher.requestBlocksWithHedging(ctx, req, p2.PeerID(), p3.PeerID())
+	require.NoError(t, err)
+	assert.Equal(t, int(req.Count), len(blocks))
+	if time.Since(start) >= 5*time.Second {
+		t.Error("Hedged request has not been served by the fast peer")
+	}
+	_, blocksPerSecond := fetcher.peerStats.estimate(p3.PeerID())
+	assert.Equal(t, true, blocksPerSecond > 0, "Expected throughput of the fast peer to be recorded")
+}
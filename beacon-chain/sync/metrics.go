@@ -41,6 +41,12 @@ var (
 		},
 		[]string{"topic"},
 	)
+	truncatedBlockRangeResponses = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "p2p_block_range_responses_truncated_total",
+			Help: "Count of block range responses cut short because the upload limit of the peer was reached.",
+		},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
@@ -15,8 +15,9 @@ import (
 const defaultBurstLimit = 5
 
 type limiter struct {
-	limiterMap map[string]*leakybucket.Collector
-	p2p        p2p.P2P
+	limiterMap      map[string]*leakybucket.Collector
+	uploadCollector *leakybucket.Collector
+	p2p             p2p.P2P
 	sync.RWMutex
 }
 
@@ -51,7 +52,17 @@ func newRateLimiter(p2pProvider p2p.P2P) *limiter {
 	// BlockByRange requests
 	topicMap[addEncoding(p2p.RPCBlocksByRangeTopic)] = blockCollector
 
-	return &limiter{limiterMap: topicMap, p2p: p2pProvider}
+	// BlockHeadersByRange requests
+	topicMap[addEncoding(p2p.RPCBlockHeadersByRangeTopic)] = blockCollector
+
+	// Limit the bytes uploaded to each peer in block responses, if configured.
+	var uploadCollector *leakybucket.Collector
+	if uploadLimit := flags.Get().BlockBatchUploadLimit; uploadLimit > 0 {
+		uploadBurst := int64(flags.Get().BlockBatchLimitBurstFactor * uploadLimit)
+		uploadCollector = leakybucket.NewCollector(float64(uploadLimit), uploadBurst, false /* deleteEmptyBuckets */)
+	}
+
+	return &limiter{limiterMap: topicMap, uploadCollector: uploadCollector, p2p: p2pProvider}
 }
 
 // Returns the current topic collector for the provided topic.
@@ -108,11 +119,33 @@ func (l *limiter) add(stream network.Stream, amt int64) {
 	collector.Add(key, amt)
 }
 
+// reserves the given number of bytes to be uploaded to the remote peer of the stream. Returns
+// false if the upload limit of the peer has been reached.
+func (l *limiter) reserveUpload(stream network.Stream, amt int64) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.uploadCollector == nil {
+		return true
+	}
+	key := stream.Conn().RemotePeer().String()
+	if amt > l.uploadCollector.Remaining(key) {
+		return false
+	}
+	l.uploadCollector.Add(key, amt)
+	return true
+}
+
 // frees all the collectors and removes them.
 func (l *limiter) free() {
 	l.Lock()
 	defer l.Unlock()
 
+	if l.uploadCollector != nil {
+		l.uploadCollector.Free()
+		l.uploadCollector = nil
+	}
+
 	tempMap := map[uintptr]bool{}
 	for t, collector := range l.limiterMap {
 		// Check if collector has already been cleared off
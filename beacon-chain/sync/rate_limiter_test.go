@@ -8,6 +8,7 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -17,7 +18,7 @@ import (
 
 func TestNewRateLimiter(t *testing.T) {
 	rlimiter := newRateLimiter(mockp2p.NewTestP2P(t))
-	assert.Equal(t, len(rlimiter.limiterMap), 6, "correct number of topics not registered")
+	assert.Equal(t, len(rlimiter.limiterMap), 7, "correct number of topics not registered")
 }
 
 func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
@@ -62,3 +63,35 @@ func TestRateLimiter_ExceedCapacity(t *testing.T) {
 		t.Fatal("Did not receive stream within 1 sec")
 	}
 }
+
+func TestRateLimiter_UploadLimit(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		BlockBatchLimit:            64,
+		BlockBatchLimitBurstFactor: 10,
+		BlockBatchUploadLimit:      100,
+	})
+	defer func() {
+		flags.Init(resetFlags)
+	}()
+
+	p1 := mockp2p.NewTestP2P(t)
+	p2 := mockp2p.NewTestP2P(t)
+	p1.Connect(p2)
+	rlimiter := newRateLimiter(p1)
+	require.NotNil(t, rlimiter.uploadCollector, "upload collector not configured")
+
+	topic := p2p.RPCBlocksByRangeTopic + p1.Encoding().ProtocolSuffix()
+	p2.BHost.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.PeerID(), protocol.ID(topic))
+	require.NoError(t, err, "could not create stream")
+
+	// The upload burst is the upload limit multiplied by the burst factor.
+	assert.Equal(t, true, rlimiter.reserveUpload(stream, 600), "could not reserve upload")
+	assert.Equal(t, false, rlimiter.reserveUpload(stream, 600), "reserved upload above the limit")
+	assert.Equal(t, true, rlimiter.reserveUpload(stream, 300), "could not reserve remaining upload")
+
+	require.NoError(t, stream.Close(), "could not close stream")
+	rlimiter.free()
+	assert.Equal(t, true, rlimiter.uploadCollector == nil, "upload collector not freed")
+}
@@ -55,6 +55,10 @@ func (s *Service) registerRPCHandlers() {
 		p2p.RPCMetaDataTopic,
 		s.metaDataHandler,
 	)
+	s.registerRPC(
+		p2p.RPCBlockHeadersByRangeTopic,
+		s.beaconBlockHeadersByRangeRPCHandler,
+	)
 }
 
 // registerRPC for a given topic with an expected protobuf message type.
@@ -111,6 +115,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		if t.Kind() == reflect.Ptr {
 			msg := reflect.New(t.Elem())
 			if err := s.p2p.Encoding().DecodeWithMaxLength(stream, msg.Interface()); err != nil {
+				penalizeDecodeError(s.p2p, stream, err)
 				// Debug logs for goodbye/status errors
 				if strings.Contains(topic, p2p.RPCGoodByeTopic) || strings.Contains(topic, p2p.RPCStatusTopic) {
 					log.WithError(err).Debug("Failed to decode goodbye stream message")
@@ -131,6 +136,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		} else {
 			msg := reflect.New(t)
 			if err := s.p2p.Encoding().DecodeWithMaxLength(stream, msg.Interface()); err != nil {
+				penalizeDecodeError(s.p2p, stream, err)
 				log.WithError(err).Warn("Failed to decode stream message")
 				traceutil.AnnotateError(span, err)
 				return
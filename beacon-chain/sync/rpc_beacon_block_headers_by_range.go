@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"context"
+	"io"
+
+	libp2pcore "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/helpers"
+	"github.com/libp2p/go-libp2p-core/mux"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// beaconBlockHeadersByRangeRPCHandler looks up the request blocks from the database from a given start block
+// and responds with their signed headers.
+func (s *Service) beaconBlockHeadersByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.BeaconBlockHeadersByRangeHandler")
+	defer span.End()
+	defer func() {
+		if err := stream.Close(); err != nil {
+			log.WithError(err).Debug("Failed to close stream")
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+
+	m, ok := msg.(*pb.BeaconBlocksByRangeRequest)
+	if !ok {
+		return errors.New("message is not type *pb.BeaconBlockByRangeRequest")
+	}
+	return s.streamBlockRange(ctx, m, stream, s.writeBlockHeaderRangeToStream)
+}
+
+func (s *Service) writeBlockHeaderRangeToStream(ctx context.Context, startSlot, endSlot, step uint64, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.WriteBlockHeaderRangeToStream")
+	defer span.End()
+
+	blks, err := s.canonicalBlockRange(ctx, startSlot, endSlot, step, stream)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	for _, b := range blks {
+		header, err := blockutil.SignedBeaconBlockHeaderFromBlock(b)
+		if err != nil {
+			log.WithError(err).Debug("Failed to compute block header")
+			s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := s.chunkWriter(stream, header); err != nil {
+			log.WithError(err).Debug("Failed to send a chunked response")
+			s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// SendBeaconBlockHeadersByRangeRequest sends a block headers by range request to the given peer
+// and returns the signed block headers received in response.
+func SendBeaconBlockHeadersByRangeRequest(
+	ctx context.Context, p2pProvider p2p.P2P, pid peer.ID, req *pb.BeaconBlocksByRangeRequest,
+) ([]*ethpb.SignedBeaconBlockHeader, error) {
+	stream, err := p2pProvider.Send(ctx, req, p2p.RPCBlockHeadersByRangeTopic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := helpers.FullClose(stream); err != nil && err.Error() != mux.ErrReset.Error() {
+			log.WithError(err).Debugf("Failed to close stream with protocol %s", stream.Protocol())
+		}
+	}()
+
+	headers := make([]*ethpb.SignedBeaconBlockHeader, 0, req.Count)
+	for i := uint64(0); ; i++ {
+		isFirstChunk := i == 0
+		header, err := ReadChunkedBlockHeader(stream, p2pProvider, isFirstChunk)
+		if err == io.EOF {
+			break
+		}
+		// Exit if peer sends more than requested or max request blocks.
+		if i >= req.Count || i >= params.BeaconNetworkConfig().MaxRequestBlocks {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
@@ -0,0 +1,64 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	db "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestRPCBeaconBlockHeadersByRange_SendAndHandle(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d, _ := db.SetupDB(t)
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 100,
+		Step:      4,
+		Count:     16,
+	}
+	blocks := make([]*ethpb.SignedBeaconBlock, 0, req.Count)
+	// Populate the database with blocks that would match the request.
+	for i := req.StartSlot; i < req.StartSlot+(req.Step*req.Count); i += req.Step {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i, ProposerIndex: i}}
+		require.NoError(t, d.SaveBlock(context.Background(), blk))
+		blocks = append(blocks, blk)
+	}
+
+	r := &Service{p2p: p2, db: d, chain: &chainMock.ChainService{}, rateLimiter: newRateLimiter(p2)}
+	topic := p2p.RPCBlockHeadersByRangeTopic + p2.Encoding().ProtocolSuffix()
+	p2.BHost.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {
+		msg := &pb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, msg))
+		assert.NoError(t, r.beaconBlockHeadersByRangeRPCHandler(context.Background(), msg, stream))
+	})
+
+	headers, err := SendBeaconBlockHeadersByRangeRequest(context.Background(), p1, p2.PeerID(), req)
+	require.NoError(t, err)
+	require.Equal(t, int(req.Count), len(headers))
+	for i, header := range headers {
+		want, err := blockutil.SignedBeaconBlockHeaderFromBlock(blocks[i])
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, header)
+	}
+
+	// Block headers are rate limited together with blocks.
+	remaining := r.rateLimiter.limiterMap[topic].Remaining(p1.PeerID().String())
+	blocksTopic := p2p.RPCBlocksByRangeTopic + p2.Encoding().ProtocolSuffix()
+	assert.Equal(t, remaining, r.rateLimiter.limiterMap[blocksTopic].Remaining(p1.PeerID().String()))
+	capacity := int64(flags.Get().BlockBatchLimit * flags.Get().BlockBatchLimitBurstFactor)
+	assert.Equal(t, capacity-int64(req.Count), remaining)
+}
@@ -13,6 +13,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -29,14 +30,23 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	defer cancel()
 	SetRPCStreamDeadlines(stream)
 
-	// Ticker to stagger out large requests.
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
-
 	m, ok := msg.(*pb.BeaconBlocksByRangeRequest)
 	if !ok {
 		return errors.New("message is not type *pb.BeaconBlockByRangeRequest")
 	}
+	return s.streamBlockRange(ctx, m, stream, s.writeBlockRangeToStream)
+}
+
+// blockRangeWriter writes the responses for the given range of slots to the stream.
+type blockRangeWriter func(ctx context.Context, startSlot, endSlot, step uint64, stream libp2pcore.Stream) error
+
+// streamBlockRange validates a range request and streams it to the remote peer in rate limited batches.
+func (s *Service) streamBlockRange(ctx context.Context, m *pb.BeaconBlocksByRangeRequest, stream libp2pcore.Stream, writeRange blockRangeWriter) error {
+	span := trace.FromContext(ctx)
+
+	// Ticker to stagger out large requests.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 
 	// The initial count for the first batch to be returned back.
 	count := m.Count
@@ -78,7 +88,20 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 			return err
 		}
 
-		if err := s.writeBlockRangeToStream(ctx, startSlot, endSlot, m.Step, stream); err != nil {
+		if err := writeRange(ctx, startSlot, endSlot, m.Step, stream); err != nil {
+			// The response is cut short once the peer's upload limit is reached, the error chunk tells
+			// the peer that the blocks it did not receive were not skipped.
+			if err == errUploadLimitReached {
+				truncatedBlockRangeResponses.Inc()
+				log.WithFields(logrus.Fields{
+					"peer":        stream.Conn().RemotePeer().Pretty(),
+					"startSlot":   m.StartSlot,
+					"count":       m.Count,
+					"truncatedAt": startSlot,
+				}).Debug("Upload limit reached, truncating block range response")
+				s.writeErrorResponseToStream(responseCodeInvalidRequest, rateLimitedError, stream)
+				break
+			}
 			return err
 		}
 
@@ -109,20 +132,40 @@ func (s *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 	ctx, span := trace.StartSpan(ctx, "sync.WriteBlockRangeToStream")
 	defer span.End()
 
+	blks, err := s.canonicalBlockRange(ctx, startSlot, endSlot, step, stream)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	for _, b := range blks {
+		if !s.rateLimiter.reserveUpload(stream, int64(b.SizeSSZ())) {
+			return errUploadLimitReached
+		}
+		if err := s.chunkWriter(stream, b); err != nil {
+			log.WithError(err).Debug("Failed to send a chunked response")
+			s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// canonicalBlockRange retrieves the sorted canonical blocks at the requested slot steps of the
+// range. An error response is written to the stream if the blocks cannot be retrieved.
+func (s *Service) canonicalBlockRange(ctx context.Context, startSlot, endSlot, step uint64, stream libp2pcore.Stream) ([]*ethpb.SignedBeaconBlock, error) {
 	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot).SetSlotStep(step)
 	blks, err := s.db.Blocks(ctx, filter)
 	if err != nil {
 		log.WithError(err).Debug("Failed to retrieve blocks")
 		s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-		traceutil.AnnotateError(span, err)
-		return err
+		return nil, err
 	}
 	roots, err := s.db.BlockRoots(ctx, filter)
 	if err != nil {
 		log.WithError(err).Debug("Failed to retrieve block roots")
 		s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-		traceutil.AnnotateError(span, err)
-		return err
+		return nil, err
 	}
 	// handle genesis case
 	if startSlot == 0 {
@@ -130,8 +173,7 @@ func (s *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 		if err != nil {
 			log.WithError(err).Debug("Failed to retrieve genesis block")
 			s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-			traceutil.AnnotateError(span, err)
-			return err
+			return nil, err
 		}
 		blks = append([]*ethpb.SignedBeaconBlock{genBlock}, blks...)
 		roots = append([][32]byte{genRoot}, roots...)
@@ -140,6 +182,7 @@ func (s *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 	// we only return valid sets of blocks.
 	blks, roots = s.dedupBlocksAndRoots(blks, roots)
 	blks, roots = s.sortBlocksAndRoots(blks, roots)
+	canonicalBlks := make([]*ethpb.SignedBeaconBlock, 0, len(blks))
 	for i, b := range blks {
 		if b == nil || b.Block == nil {
 			continue
@@ -153,21 +196,15 @@ func (s *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 			if err != nil {
 				log.WithError(err).Debug("Failed to determine canonical block")
 				s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-				traceutil.AnnotateError(span, err)
-				return err
+				return nil, err
 			}
 			if !canonical {
 				continue
 			}
-			if err := s.chunkWriter(stream, b); err != nil {
-				log.WithError(err).Debug("Failed to send a chunked response")
-				s.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-				traceutil.AnnotateError(span, err)
-				return err
-			}
+			canonicalBlks = append(canonicalBlks, b)
 		}
 	}
-	return nil
+	return canonicalBlks, nil
 }
 
 func (s *Service) writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream) {
@@ -180,6 +180,52 @@ func TestRPCBeaconBlocksByRange_ReturnsGenesisBlock(t *testing.T) {
 	}
 }
 
+func TestRPCBeaconBlocksByRange_UploadLimitTruncatesResponse(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d, _ := db.SetupDB(t)
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 100,
+		Step:      1,
+		Count:     8,
+	}
+	var blockSize int
+	for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i}}
+		blockSize = blk.SizeSSZ()
+		require.NoError(t, d.SaveBlock(context.Background(), blk))
+	}
+
+	// The upload limit only allows 3 of the blocks to be sent.
+	r := &Service{p2p: p1, db: d, chain: &chainMock.ChainService{}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID("/testing")
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(0.000001, int64(req.Count*10), false)
+	r.rateLimiter.uploadCollector = leakybucket.NewCollector(0.000001, int64(3*blockSize), false)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for i := 0; i < 3; i++ {
+			expectSuccess(t, r, stream)
+			res := &ethpb.SignedBeaconBlock{}
+			assert.NoError(t, r.p2p.Encoding().DecodeWithMaxLength(stream, res))
+			assert.Equal(t, req.StartSlot+uint64(i), res.Block.Slot)
+		}
+		expectFailure(t, responseCodeInvalidRequest, rateLimitedError, stream)
+	})
+
+	stream1, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.beaconBlocksByRangeRPCHandler(context.Background(), req, stream1))
+
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
 func TestRPCBeaconBlocksByRange_RPCHandlerRateLimitOverflow(t *testing.T) {
 	d, _ := db.SetupDB(t)
 	hook := logTest.NewGlobal()
@@ -47,26 +47,54 @@ func readFirstChunkedBlock(stream libp2pcore.Stream, p2p p2p.P2P) (*eth.SignedBe
 	blk := &eth.SignedBeaconBlock{}
 	code, errMsg, err := ReadStatusCode(stream, p2p.Encoding())
 	if err != nil {
+		penalizeDecodeError(p2p, stream, err)
 		return nil, err
 	}
 	if code != 0 {
 		return nil, errors.New(errMsg)
 	}
 	err = p2p.Encoding().DecodeWithMaxLength(stream, blk)
+	penalizeDecodeError(p2p, stream, err)
 	return blk, err
 }
 
+// ReadChunkedBlockHeader handles each response chunk that is sent by the
+// peer and converts it into a signed beacon block header.
+func ReadChunkedBlockHeader(stream libp2pcore.Stream, p2p p2p.P2P, isFirstChunk bool) (*eth.SignedBeaconBlockHeader, error) {
+	header := &eth.SignedBeaconBlockHeader{}
+	if isFirstChunk {
+		code, errMsg, err := ReadStatusCode(stream, p2p.Encoding())
+		if err != nil {
+			penalizeDecodeError(p2p, stream, err)
+			return nil, err
+		}
+		if code != 0 {
+			return nil, errors.New(errMsg)
+		}
+		err = p2p.Encoding().DecodeWithMaxLength(stream, header)
+		penalizeDecodeError(p2p, stream, err)
+		return header, err
+	}
+	if err := readResponseChunk(stream, p2p, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
 // readResponseChunk reads the response from the stream and decodes it into the
 // provided message type.
 func readResponseChunk(stream libp2pcore.Stream, p2p p2p.P2P, to interface{}) error {
 	SetStreamReadDeadline(stream, respTimeout)
 	code, errMsg, err := readStatusCodeNoDeadline(stream, p2p.Encoding())
 	if err != nil {
+		penalizeDecodeError(p2p, stream, err)
 		return err
 	}
 
 	if code != 0 {
 		return errors.New(errMsg)
 	}
-	return p2p.Encoding().DecodeWithMaxLength(stream, to)
+	err = p2p.Encoding().DecodeWithMaxLength(stream, to)
+	penalizeDecodeError(p2p, stream, err)
+	return err
 }
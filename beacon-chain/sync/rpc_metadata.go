@@ -61,6 +61,7 @@ func (s *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (*pb.Meta
 	}
 	msg := new(pb.MetaData)
 	if err := s.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+		penalizeDecodeError(s.p2p, stream, err)
 		return nil, err
 	}
 	return msg, nil
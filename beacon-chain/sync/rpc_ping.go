@@ -112,6 +112,7 @@ func (s *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 	}
 	msg := new(uint64)
 	if err := s.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+		penalizeDecodeError(s.p2p, stream, err)
 		return err
 	}
 	valid, err := s.validateSequenceNum(*msg, stream.Conn().RemotePeer())
@@ -143,6 +143,7 @@ func (s *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 
 	msg := &pb.Status{}
 	if err := s.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+		penalizeDecodeError(s.p2p, stream, err)
 		return err
 	}
 	s.p2p.Peers().SetChainState(stream.Conn().RemotePeer(), msg)
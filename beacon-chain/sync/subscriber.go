@@ -14,6 +14,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/messagehandler"
 	"github.com/prysmaticlabs/prysm/shared/p2putils"
@@ -101,7 +102,7 @@ func (s *Service) subscribeWithBase(base proto.Message, topic string, validator
 	topic += s.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
 
-	if err := s.p2p.PubSub().RegisterTopicValidator(wrapAndReportValidation(topic, validator)); err != nil {
+	if err := s.p2p.PubSub().RegisterTopicValidator(s.wrapAndReportValidation(topic, validator)); err != nil {
 		log.WithError(err).Error("Failed to register validator")
 	}
 
@@ -170,8 +171,9 @@ func (s *Service) subscribeWithBase(base proto.Message, topic string, validator
 }
 
 // Wrap the pubsub validator with a metric monitoring function. This function increments the
-// appropriate counter if the particular message fails to validate.
-func wrapAndReportValidation(topic string, v pubsub.ValidatorEx) (string, pubsub.ValidatorEx) {
+// appropriate counter if the particular message fails to validate, records the validation
+// result against the peer which forwarded the message, and traces the message if enabled.
+func (s *Service) wrapAndReportValidation(topic string, v pubsub.ValidatorEx) (string, pubsub.ValidatorEx) {
 	return topic, func(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 		defer messagehandler.HandlePanic(ctx, msg)
 		ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
@@ -181,10 +183,29 @@ func wrapAndReportValidation(topic string, v pubsub.ValidatorEx) (string, pubsub
 		if b == pubsub.ValidationReject {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
 		}
+		s.recordGossipValidation(pid, b)
+		s.p2p.TraceGossipMessage(topic, msg, b)
 		return b
 	}
 }
 
+// This records the result of the validation of a gossip message forwarded by the given peer with the
+// gossip scorer. Messages published by this node are not recorded.
+func (s *Service) recordGossipValidation(pid peer.ID, result pubsub.ValidationResult) {
+	if pid == s.p2p.PeerID() {
+		return
+	}
+	scorer := s.p2p.Peers().Scorers().GossipScorer()
+	switch result {
+	case pubsub.ValidationAccept:
+		scorer.Record(pid, peers.GossipAccepted)
+	case pubsub.ValidationIgnore:
+		scorer.Record(pid, peers.GossipIgnored)
+	case pubsub.ValidationReject:
+		scorer.Record(pid, peers.GossipRejected)
+	}
+}
+
 // subscribe to a static subnet  with the given topic and index.A given validator and subscription handler is
 // used to handle messages from the subnet. The base protobuf message is used to initialize new messages for decoding.
 func (s *Service) subscribeStaticWithSubnets(topic string, validator pubsub.ValidatorEx, handle subHandler) {
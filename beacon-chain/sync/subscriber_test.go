@@ -10,6 +10,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
@@ -192,6 +193,34 @@ func TestSubscribe_HandlesPanic(t *testing.T) {
 	}
 }
 
+func TestSubscribe_RecordsGossipValidation(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	r := Service{
+		ctx: context.Background(),
+		p2p: p,
+	}
+	result := pubsub.ValidationAccept
+	_, validator := r.wrapAndReportValidation("topic", func(_ context.Context, _ peer.ID, _ *pubsub.Message) pubsub.ValidationResult {
+		return result
+	})
+
+	pid := peer.ID("peer1")
+	assert.Equal(t, pubsub.ValidationAccept, validator(context.Background(), pid, &pubsub.Message{}))
+	result = pubsub.ValidationIgnore
+	assert.Equal(t, pubsub.ValidationIgnore, validator(context.Background(), pid, &pubsub.Message{}))
+	result = pubsub.ValidationReject
+	assert.Equal(t, pubsub.ValidationReject, validator(context.Background(), pid, &pubsub.Message{}))
+	assert.Equal(t, pubsub.ValidationReject, validator(context.Background(), p.PeerID(), &pubsub.Message{}))
+
+	accepted, ignored, rejected, err := p.Peers().Scorers().GossipScorer().Counts(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, accepted)
+	assert.Equal(t, 1.0, ignored)
+	assert.Equal(t, 1, rejected)
+	_, _, _, err = p.Peers().Scorers().GossipScorer().Counts(p.PeerID())
+	assert.NotNil(t, err, "Messages published by this node should not be recorded")
+}
+
 func TestRevalidateSubscription_CorrectlyFormatsTopic(t *testing.T) {
 	p := p2ptest.NewTestP2P(t)
 	hook := logTest.NewGlobal()
@@ -97,12 +97,20 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPWeb3ProviderFlag,
 			flags.SetGCPercent,
 			flags.UnsafeSync,
+			flags.CheckpointStateFlag,
+			flags.CheckpointBlockFlag,
 			flags.SlasherCertFlag,
 			flags.SlasherProviderFlag,
 			flags.SlotsPerArchivedPoint,
+			flags.ArchivedStateDiffs,
+			flags.InMemoryDBFlag,
+			flags.InMemoryDBSnapshotIntervalFlag,
+			flags.PruneDBFlag,
+			flags.HistoryRetentionEpochsFlag,
 			flags.DisableDiscv5,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.BlockBatchUploadLimit,
 			flags.EnableDebugRPCEndpoints,
 			flags.SlotsPerArchivedPoint,
 			flags.HistoricalSlasherNode,
@@ -121,6 +129,12 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
+			cmd.P2PAllowListPeers,
+			cmd.P2PDenyListPeers,
+			cmd.P2PTrustedPeers,
+			cmd.P2PGossipTraceDir,
+			cmd.P2PGossipTraceMaxFileSize,
+			cmd.P2PGossipTraceMaxFiles,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
@@ -0,0 +1,69 @@
+package fuzz
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// Message types which are received from peers, the first byte of the fuzz input selects one of them.
+var encoderFuzzTypes = []func() interface{}{
+	func() interface{} { return &ethpb.SignedBeaconBlock{} },
+	func() interface{} { return &ethpb.SignedBeaconBlockHeader{} },
+	func() interface{} { return &ethpb.Attestation{} },
+	func() interface{} { return &ethpb.SignedAggregateAttestationAndProof{} },
+	func() interface{} { return &ethpb.AttesterSlashing{} },
+	func() interface{} { return &ethpb.ProposerSlashing{} },
+	func() interface{} { return &ethpb.SignedVoluntaryExit{} },
+	func() interface{} { return &pb.Status{} },
+	func() interface{} { return &pb.MetaData{} },
+	func() interface{} { return &pb.BeaconBlocksByRangeRequest{} },
+	func() interface{} { return &pb.BeaconBlocksByRootRequest{} },
+	func() interface{} { return new(uint64) },
+}
+
+var (
+	strictEncoder    = &encoder.SszNetworkEncoder{StrictSizeLimits: true}
+	nonStrictEncoder = &encoder.SszNetworkEncoder{StrictSizeLimits: false}
+)
+
+// BeaconFuzzSSZEncoderWithLength implements libfuzzer and beacon fuzz interface.
+func BeaconFuzzSSZEncoderWithLength(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	newMsg := encoderFuzzTypes[int(b[0])%len(encoderFuzzTypes)]
+	// Without strict size limits, a decoded message is only checked for not crashing the encoder.
+	_ = nonStrictEncoder.DecodeWithMaxLength(bytes.NewReader(b[1:]), newMsg())
+
+	msg := newMsg()
+	if err := strictEncoder.DecodeWithMaxLength(bytes.NewReader(b[1:]), msg); err != nil {
+		return
+	}
+	// Any message which has been decoded must be within the limits of the encoder.
+	if _, err := strictEncoder.EncodeWithMaxLength(new(bytes.Buffer), msg); err != nil {
+		panic(errors.Wrap(err, "could not encode decoded message"))
+	}
+}
+
+// BeaconFuzzSSZEncoderGossip implements libfuzzer and beacon fuzz interface.
+func BeaconFuzzSSZEncoderGossip(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	newMsg := encoderFuzzTypes[int(b[0])%len(encoderFuzzTypes)]
+	// Without strict size limits, a decoded message is only checked for not crashing the encoder.
+	_ = nonStrictEncoder.DecodeGossip(b[1:], newMsg())
+
+	msg := newMsg()
+	if err := strictEncoder.DecodeGossip(b[1:], msg); err != nil {
+		return
+	}
+	// Any message which has been decoded must be within the limits of the encoder.
+	if _, err := strictEncoder.EncodeGossip(new(bytes.Buffer), msg); err != nil {
+		panic(errors.Wrap(err, "could not encode decoded message"))
+	}
+}
@@ -646,6 +646,11 @@ type DebugPeerResponse_PeerInfo struct {
 	ProtocolVersion      string       `protobuf:"bytes,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
 	AgentVersion         string       `protobuf:"bytes,5,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
 	PeerLatency          uint64       `protobuf:"varint,6,opt,name=peer_latency,json=peerLatency,proto3" json:"peer_latency,omitempty"`
+	GossipScore          float64      `protobuf:"fixed64,7,opt,name=gossip_score,json=gossipScore,proto3" json:"gossip_score,omitempty"`
+	BytesIn              uint64       `protobuf:"varint,8,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`
+	BytesOut             uint64       `protobuf:"varint,9,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`
+	RateIn               float64      `protobuf:"fixed64,10,opt,name=rate_in,json=rateIn,proto3" json:"rate_in,omitempty"`
+	RateOut              float64      `protobuf:"fixed64,11,opt,name=rate_out,json=rateOut,proto3" json:"rate_out,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -718,6 +723,174 @@ func (m *DebugPeerResponse_PeerInfo) GetPeerLatency() uint64 {
 	return 0
 }
 
+func (m *DebugPeerResponse_PeerInfo) GetGossipScore() float64 {
+	if m != nil {
+		return m.GossipScore
+	}
+	return 0
+}
+
+func (m *DebugPeerResponse_PeerInfo) GetBytesIn() uint64 {
+	if m != nil {
+		return m.BytesIn
+	}
+	return 0
+}
+
+func (m *DebugPeerResponse_PeerInfo) GetBytesOut() uint64 {
+	if m != nil {
+		return m.BytesOut
+	}
+	return 0
+}
+
+func (m *DebugPeerResponse_PeerInfo) GetRateIn() float64 {
+	if m != nil {
+		return m.RateIn
+	}
+	return 0
+}
+
+func (m *DebugPeerResponse_PeerInfo) GetRateOut() float64 {
+	if m != nil {
+		return m.RateOut
+	}
+	return 0
+}
+
+type PeerBanRequest struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Duration             uint64   `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerBanRequest) Reset()         { *m = PeerBanRequest{} }
+func (m *PeerBanRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerBanRequest) ProtoMessage()    {}
+func (*PeerBanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_851e5cb2de3d61dd, []int{10}
+}
+
+func (m *PeerBanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerBanRequest.Unmarshal(m, b)
+}
+func (m *PeerBanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerBanRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerBanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerBanRequest.Merge(m, src)
+}
+func (m *PeerBanRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerBanRequest.Size(m)
+}
+func (m *PeerBanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerBanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerBanRequest proto.InternalMessageInfo
+
+func (m *PeerBanRequest) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *PeerBanRequest) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type PeerBan struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	BannedUntil          uint64   `protobuf:"varint,2,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerBan) Reset()         { *m = PeerBan{} }
+func (m *PeerBan) String() string { return proto.CompactTextString(m) }
+func (*PeerBan) ProtoMessage()    {}
+func (*PeerBan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_851e5cb2de3d61dd, []int{11}
+}
+
+func (m *PeerBan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerBan.Unmarshal(m, b)
+}
+func (m *PeerBan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerBan.Marshal(b, m, deterministic)
+}
+func (m *PeerBan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerBan.Merge(m, src)
+}
+func (m *PeerBan) XXX_Size() int {
+	return xxx_messageInfo_PeerBan.Size(m)
+}
+func (m *PeerBan) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerBan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerBan proto.InternalMessageInfo
+
+func (m *PeerBan) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *PeerBan) GetBannedUntil() uint64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+type PeerBans struct {
+	Bans                 []*PeerBan `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PeerBans) Reset()         { *m = PeerBans{} }
+func (m *PeerBans) String() string { return proto.CompactTextString(m) }
+func (*PeerBans) ProtoMessage()    {}
+func (*PeerBans) Descriptor() ([]byte, []int) {
+	return fileDescriptor_851e5cb2de3d61dd, []int{12}
+}
+
+func (m *PeerBans) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerBans.Unmarshal(m, b)
+}
+func (m *PeerBans) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerBans.Marshal(b, m, deterministic)
+}
+func (m *PeerBans) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerBans.Merge(m, src)
+}
+func (m *PeerBans) XXX_Size() int {
+	return xxx_messageInfo_PeerBans.Size(m)
+}
+func (m *PeerBans) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerBans.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerBans proto.InternalMessageInfo
+
+func (m *PeerBans) GetBans() []*PeerBan {
+	if m != nil {
+		return m.Bans
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("ethereum.beacon.rpc.v1.LoggingLevelRequest_Level", LoggingLevelRequest_Level_name, LoggingLevelRequest_Level_value)
 	proto.RegisterType((*InclusionSlotRequest)(nil), "ethereum.beacon.rpc.v1.InclusionSlotRequest")
@@ -732,6 +905,9 @@ func init() {
 	proto.RegisterType((*DebugPeerResponses)(nil), "ethereum.beacon.rpc.v1.DebugPeerResponses")
 	proto.RegisterType((*DebugPeerResponse)(nil), "ethereum.beacon.rpc.v1.DebugPeerResponse")
 	proto.RegisterType((*DebugPeerResponse_PeerInfo)(nil), "ethereum.beacon.rpc.v1.DebugPeerResponse.PeerInfo")
+	proto.RegisterType((*PeerBanRequest)(nil), "ethereum.beacon.rpc.v1.PeerBanRequest")
+	proto.RegisterType((*PeerBan)(nil), "ethereum.beacon.rpc.v1.PeerBan")
+	proto.RegisterType((*PeerBans)(nil), "ethereum.beacon.rpc.v1.PeerBans")
 }
 
 func init() { proto.RegisterFile("proto/beacon/rpc/v1/debug.proto", fileDescriptor_851e5cb2de3d61dd) }
@@ -834,6 +1010,9 @@ type DebugClient interface {
 	ListPeers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DebugPeerResponses, error)
 	GetPeer(ctx context.Context, in *v1alpha1.PeerRequest, opts ...grpc.CallOption) (*DebugPeerResponse, error)
 	GetInclusionSlot(ctx context.Context, in *InclusionSlotRequest, opts ...grpc.CallOption) (*InclusionSlotResponse, error)
+	ListPeerBans(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PeerBans, error)
+	BanPeer(ctx context.Context, in *PeerBanRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UnbanPeer(ctx context.Context, in *v1alpha1.PeerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type debugClient struct {
@@ -907,6 +1086,33 @@ func (c *debugClient) GetInclusionSlot(ctx context.Context, in *InclusionSlotReq
 	return out, nil
 }
 
+func (c *debugClient) ListPeerBans(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PeerBans, error) {
+	out := new(PeerBans)
+	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.Debug/ListPeerBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) BanPeer(ctx context.Context, in *PeerBanRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.Debug/BanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) UnbanPeer(ctx context.Context, in *v1alpha1.PeerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.Debug/UnbanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	GetBeaconState(context.Context, *BeaconStateRequest) (*SSZResponse, error)
@@ -916,6 +1122,9 @@ type DebugServer interface {
 	ListPeers(context.Context, *empty.Empty) (*DebugPeerResponses, error)
 	GetPeer(context.Context, *v1alpha1.PeerRequest) (*DebugPeerResponse, error)
 	GetInclusionSlot(context.Context, *InclusionSlotRequest) (*InclusionSlotResponse, error)
+	ListPeerBans(context.Context, *empty.Empty) (*PeerBans, error)
+	BanPeer(context.Context, *PeerBanRequest) (*empty.Empty, error)
+	UnbanPeer(context.Context, *v1alpha1.PeerRequest) (*empty.Empty, error)
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -943,6 +1152,15 @@ func (*UnimplementedDebugServer) GetPeer(ctx context.Context, req *v1alpha1.Peer
 func (*UnimplementedDebugServer) GetInclusionSlot(ctx context.Context, req *InclusionSlotRequest) (*InclusionSlotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionSlot not implemented")
 }
+func (*UnimplementedDebugServer) ListPeerBans(ctx context.Context, req *empty.Empty) (*PeerBans, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerBans not implemented")
+}
+func (*UnimplementedDebugServer) BanPeer(ctx context.Context, req *PeerBanRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
+}
+func (*UnimplementedDebugServer) UnbanPeer(ctx context.Context, req *v1alpha1.PeerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -1074,6 +1292,60 @@ func _Debug_GetInclusionSlot_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_ListPeerBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).ListPeerBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.beacon.rpc.v1.Debug/ListPeerBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).ListPeerBans(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.beacon.rpc.v1.Debug/BanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).BanPeer(ctx, req.(*PeerBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.PeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.beacon.rpc.v1.Debug/UnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).UnbanPeer(ctx, req.(*v1alpha1.PeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ethereum.beacon.rpc.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -1106,6 +1378,18 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInclusionSlot",
 			Handler:    _Debug_GetInclusionSlot_Handler,
 		},
+		{
+			MethodName: "ListPeerBans",
+			Handler:    _Debug_ListPeerBans_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _Debug_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _Debug_UnbanPeer_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/beacon/rpc/v1/debug.proto",
@@ -140,10 +140,10 @@ var (
 		Usage: "The max number of p2p peers to maintain.",
 		Value: 30,
 	}
-	// P2PAllowList defines a CIDR subnet to exclusively allow connections.
-	P2PAllowList = &cli.StringFlag{
+	// P2PAllowList defines a list of CIDR subnets to exclusively allow connections.
+	P2PAllowList = &cli.StringSliceFlag{
 		Name: "p2p-allowlist",
-		Usage: "The CIDR subnet for allowing only certain peer connections. Example: " +
+		Usage: "The CIDR subnets for allowing only certain peer connections. Example: " +
 			"192.168.0.0/16 would permit connections to peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
@@ -154,6 +154,42 @@ var (
 			"192.168.0.0/16 would deny connections from peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
+	// P2PAllowListPeers defines a list of peer ids to exclusively allow connections.
+	P2PAllowListPeers = &cli.StringSliceFlag{
+		Name: "p2p-allowlist-peer",
+		Usage: "The peer id of a peer to exclusively allow connections with. This flag may be used " +
+			"multiple times. The default is to accept all peers.",
+	}
+	// P2PDenyListPeers defines a list of peer ids to disallow connections with.
+	P2PDenyListPeers = &cli.StringSliceFlag{
+		Name:  "p2p-denylist-peer",
+		Usage: "The peer id of a peer to deny connections with. This flag may be used multiple times.",
+	}
+	// P2PTrustedPeers specifies a set of peers which are always kept connected.
+	P2PTrustedPeers = &cli.StringSliceFlag{
+		Name: "p2p-trusted-peer",
+		Usage: "The multiaddr, including the peer id, of a trusted peer. Trusted peers are always allowed, " +
+			"are re-dialed on disconnect, are never scored as bad and do not count towards the peer limit. " +
+			"This flag may be used multiple times.",
+	}
+	// P2PGossipTraceDir defines a directory to record the received gossip messages to.
+	P2PGossipTraceDir = &cli.StringFlag{
+		Name: "p2p-gossip-trace-dir",
+		Usage: "The directory to record every received gossip message, along with the result of its validation, " +
+			"to. The recorded traces may be replayed with the gossip-replay tool. Tracing is disabled by default.",
+	}
+	// P2PGossipTraceMaxFileSize defines the size at which gossip trace files are rotated.
+	P2PGossipTraceMaxFileSize = &cli.Uint64Flag{
+		Name:  "p2p-gossip-trace-max-file-size",
+		Usage: "The size, in megabytes, at which a gossip trace file is rotated.",
+		Value: 100,
+	}
+	// P2PGossipTraceMaxFiles defines the number of gossip trace files which are kept.
+	P2PGossipTraceMaxFiles = &cli.IntFlag{
+		Name:  "p2p-gossip-trace-max-files",
+		Usage: "The number of gossip trace files which are kept, the oldest files are removed on rotation.",
+		Value: 10,
+	}
 	// ForceClearDB removes any previously stored data at the data directory.
 	ForceClearDB = &cli.BoolFlag{
 		Name:  "force-clear-db",
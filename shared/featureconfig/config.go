@@ -44,6 +44,7 @@ type Flags struct {
 	PruneEpochBoundaryStates                   bool // PruneEpochBoundaryStates prunes the epoch boundary state before last finalized check point.
 	EnableSnappyDBCompression                  bool // EnableSnappyDBCompression in the database.
 	LocalProtection                            bool // LocalProtection prevents the validator client from signing any messages that would be considered a slashable offense from the validators view.
+	MinimalSlashingProtection                  bool // MinimalSlashingProtection only keeps the highest signed slot, source and target epochs of validators for local protection.
 	SlasherProtection                          bool // SlasherProtection protects validator fron sending over a slashable offense over the network using external slasher.
 	DisableStrictAttestationPubsubVerification bool // DisableStrictAttestationPubsubVerification will disabling strict signature verification in pubsub.
 	DisableUpdateHeadPerAttestation            bool // DisableUpdateHeadPerAttestation will disabling update head on per attestation basis.
@@ -60,6 +61,7 @@ type Flags struct {
 	InitSyncVerbose                            bool // InitSyncVerbose logs every processed block during initial syncing.
 	EnableFinalizedDepositsCache               bool // EnableFinalizedDepositsCache enables utilization of cached finalized deposits.
 	EnableEth1DataMajorityVote                 bool // EnableEth1DataMajorityVote uses the Voting With The Majority algorithm to vote for eth1data.
+	EnableStrictSSZLimits                      bool // EnableStrictSSZLimits enforces the maximum ssz size of each p2p message type when decoding.
 
 	// DisableForkChoice disables using LMD-GHOST fork choice to update
 	// the head of the chain based on attestations and instead accepts any valid received block
@@ -252,6 +254,10 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.Warn("Enabling eth1data majority vote")
 		cfg.EnableEth1DataMajorityVote = true
 	}
+	if ctx.Bool(enableStrictSSZLimits.Name) {
+		log.Warn("Enabling strict ssz size limits for p2p messages")
+		cfg.EnableStrictSSZLimits = true
+	}
 	Init(cfg)
 }
 
@@ -295,6 +301,10 @@ func ConfigureValidator(ctx *cli.Context) {
 	} else {
 		log.Warn("Validator slashing protection not enabled!")
 	}
+	if ctx.Bool(enableMinimalSlashingProtectionFlag.Name) {
+		log.Warn("Enabled minimal slashing protection, only the highest signed slot and epochs of validators are kept.")
+		cfg.MinimalSlashingProtection = true
+	}
 	cfg.EnableAccountsV2 = true
 	if ctx.Bool(disableAccountsV2.Name) {
 		log.Warn("Disabling v2 of Prysm validator accounts")
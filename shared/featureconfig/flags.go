@@ -77,6 +77,11 @@ var (
 			"broadcasting any messages that could be considered slashable according to its own history.",
 		Value: true,
 	}
+	enableMinimalSlashingProtectionFlag = &cli.BoolFlag{
+		Name: "enable-minimal-slashing-protection",
+		Usage: "Enables a minimal local slashing protection which only keeps the highest signed slot, source and " +
+			"target epochs of each validator instead of their full history, which scales to many validator keys.",
+	}
 	enableExternalSlasherProtectionFlag = &cli.BoolFlag{
 		Name: "enable-external-slasher-protection",
 		Usage: "Enables the validator to connect to external slasher to prevent it from " +
@@ -162,6 +167,10 @@ var (
 		Name:  "enable-eth1-data-majority-vote",
 		Usage: "When enabled, voting on eth1 data will use the Voting With The Majority algorithm.",
 	}
+	enableStrictSSZLimits = &cli.BoolFlag{
+		Name:  "enable-strict-ssz-limits",
+		Usage: "Enforces the maximum ssz size of each p2p message type before decoding messages received from peers, and penalizes peers sending messages which cannot be decoded.",
+	}
 	disableAccountsV2 = &cli.BoolFlag{
 		Name:  "disable-accounts-v2",
 		Usage: "Disables usage of v2 for Prysm validator accounts",
@@ -590,6 +599,7 @@ var deprecatedFlags = []cli.Flag{
 // ValidatorFlags contains a list of all the feature flags that apply to the validator client.
 var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	enableLocalProtectionFlag,
+	enableMinimalSlashingProtectionFlag,
 	enableExternalSlasherProtectionFlag,
 	disableDomainDataCacheFlag,
 	waitForSyncedFlag,
@@ -643,6 +653,7 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	initSyncVerbose,
 	enableFinalizedDepositsCache,
 	enableEth1DataMajorityVote,
+	enableStrictSSZLimits,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -98,6 +98,7 @@ type BeaconChainConfig struct {
 	DefaultPageSize           int           // DefaultPageSize defines the default page size for RPC server request.
 	MaxPeersToSync            int           // MaxPeersToSync describes the limit for number of peers in round robin sync.
 	SlotsPerArchivedPoint     uint64        // SlotsPerArchivedPoint defines the number of slots per one archived point.
+	ArchivedStateDiffs        uint64        // ArchivedStateDiffs defines the number of archived points saved as diffs between two archived full states.
 	GenesisCountdownInterval  time.Duration // How often to log the countdown until the genesis time is reached.
 
 	// Slasher constants.
@@ -37,3 +37,8 @@ func GetBuildData() string {
 	}
 	return fmt.Sprintf("Prysm/%s/%s", gitTag, gitCommit)
 }
+
+// GetSemanticVersion returns the git tag of the current build, such as v1.0.0.
+func GetSemanticVersion() string {
+	return gitTag
+}
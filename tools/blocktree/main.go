@@ -4,6 +4,9 @@
  * Given a DB, start slot and end slot. This tool computes the graphviz data
  * needed to construct the block tree in graphviz data format. Then one can paste
  * the data in a Graph rendering engine (ie. http://www.webgraphviz.com/) to see the visual format.
+ *
+ * With --forkchoice, the fork choice store saved in the DB is rendered instead, including the
+ * weight, votes, best child and checkpoints of every node, in DOT or in JSON with --format=json.
 
  */
 package main
@@ -11,14 +14,17 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/emicklei/dot"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 )
@@ -28,6 +34,9 @@ var (
 	datadir   = flag.String("datadir", "", "Path to data directory.")
 	startSlot = flag.Uint("startSlot", 0, "Start slot of the block tree")
 	endSlot   = flag.Uint("endSlot", 0, "Start slot of the block tree")
+	// Optional fields
+	forkChoice = flag.Bool("forkchoice", false, "Render the fork choice store saved in the DB instead of the saved blocks")
+	format     = flag.String("format", "dot", "Output format of the fork choice store, dot or json")
 )
 
 // Used for tree, each node is a representation of a node in the graph
@@ -39,17 +48,26 @@ type node struct {
 
 func main() {
 	flag.Parse()
+	if *format != "dot" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown format %q, expected dot or json\n", *format)
+		os.Exit(1)
+	}
 	db, err := db.NewDB(*datadir, cache.NewStateSummaryCache())
 	if err != nil {
 		panic(err)
 	}
 
+	startSlot := uint64(*startSlot)
+	endSlot := uint64(*endSlot)
+	if *forkChoice {
+		printForkChoice(db, startSlot, endSlot)
+		return
+	}
+
 	graph := dot.NewGraph(dot.Directed)
 	graph.Attr("rankdir", "RL")
 	graph.Attr("labeljust", "l")
 
-	startSlot := uint64(*startSlot)
-	endSlot := uint64(*endSlot)
 	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot)
 	blks, err := db.Blocks(context.Background(), filter)
 	if err != nil {
@@ -110,3 +128,35 @@ func main() {
 
 	fmt.Println(graph.String())
 }
+
+// Prints the fork choice store saved in the DB with the nodes between the start and end slots. The
+// end slot is unbounded when it is not set.
+func printForkChoice(beaconDB db.Database, startSlot uint64, endSlot uint64) {
+	enc, err := beaconDB.ForkChoiceStore(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if len(enc) == 0 {
+		fmt.Println("No fork choice store saved in DB")
+		os.Exit(1)
+	}
+	store, err := protoarray.Unmarshal(enc)
+	if err != nil {
+		panic(err)
+	}
+	if endSlot == 0 {
+		endSlot = ^uint64(0)
+	}
+	graph := store.Graph(startSlot, endSlot)
+
+	switch *format {
+	case "json":
+		out, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(out))
+	default:
+		fmt.Println(graph.DOT())
+	}
+}
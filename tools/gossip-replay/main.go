@@ -0,0 +1,266 @@
+/**
+ * Gossip replay
+ *
+ * Replays the gossip traces recorded by a beacon node with --p2p-gossip-trace-dir against a local
+ * database, by running every recorded message through the validator and the subscriber of its
+ * topic. The result of each validation is compared to the recorded result, which allows to
+ * reproduce validation issues seen on the network.
+ *
+ * Replayed blocks and operations are saved to the database, so the tool should be run against a
+ * copy of the data directory of the node.
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+// The database directory and file of the beacon node within its data directory.
+const (
+	beaconChainDBName = "beaconchaindata"
+	databaseFileName  = "beaconchain.db"
+)
+
+var (
+	// Required fields
+	datadir = flag.String("datadir", "", "Path to the data directory of the beacon node.")
+	trace   = flag.String("trace", "", "Path to a gossip trace file or to a gossip trace directory.")
+	// Optional fields
+	topic      = flag.String("topic", "", "Only replay the messages of the topics which contain this string.")
+	recordTime = flag.Bool("record-time", true, "Evaluate the time based checks of the validators at the time messages were recorded.")
+	debug      = flag.Bool("debug", false, "Enable debug logging")
+	log        = logrus.WithField("prefix", "gossip-replay")
+)
+
+// notifier provides the event feeds of the services.
+type notifier struct {
+	stateFeed *event.Feed
+	blockFeed *event.Feed
+	opFeed    *event.Feed
+}
+
+func (n *notifier) StateFeed() *event.Feed {
+	return n.stateFeed
+}
+
+func (n *notifier) BlockFeed() *event.Feed {
+	return n.blockFeed
+}
+
+func (n *notifier) OperationFeed() *event.Feed {
+	return n.opFeed
+}
+
+// synced reports the node as synced, so that the validators do not ignore the replayed messages.
+type synced struct{}
+
+func (synced) Syncing() bool {
+	return false
+}
+
+func (synced) Status() error {
+	return nil
+}
+
+func (synced) Resync() error {
+	return nil
+}
+
+// replayChain shifts the genesis time of the chain by an offset, so that the time based checks
+// of the validators are evaluated at the time a message was recorded.
+type replayChain struct {
+	*blockchain.Service
+	offset time.Duration
+}
+
+// GenesisTime of the chain, as seen at the time of the replayed message.
+func (c *replayChain) GenesisTime() time.Time {
+	return c.Service.GenesisTime().Add(c.offset)
+}
+
+func main() {
+	flag.Parse()
+	if *debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if *datadir == "" || *trace == "" {
+		log.Fatal("Both --datadir and --trace are required")
+	}
+	files, err := traceFiles(*trace)
+	if err != nil {
+		log.WithError(err).Fatal("Could not find gossip trace files")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Opening the database would otherwise create an empty one when the data directory is wrong.
+	dbPath := filepath.Join(*datadir, beaconChainDBName)
+	if _, err := os.Stat(filepath.Join(dbPath, databaseFileName)); err != nil {
+		log.WithError(err).Fatal("Could not find the database of the beacon node in the data directory")
+	}
+	stateSummaryCache := cache.NewStateSummaryCache()
+	beaconDB, err := db.NewDB(dbPath, stateSummaryCache)
+	if err != nil {
+		log.WithError(err).Fatal("Could not open database")
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	n := &notifier{stateFeed: new(event.Feed), blockFeed: new(event.Feed), opFeed: new(event.Feed)}
+	attPool := attestations.NewPool()
+	exitPool := voluntaryexits.NewPool()
+	slashingPool := slashings.NewPool()
+	depositCache, err := depositcache.NewDepositCache()
+	if err != nil {
+		log.WithError(err).Fatal("Could not create deposit cache")
+	}
+	// The p2p service is never started, messages are only broadcast to the in memory gossipsub.
+	p2pService, err := p2p.NewService(&p2p.Config{
+		NoDiscovery:   true,
+		DisableDiscv5: true,
+		StateNotifier: n,
+		DB:            beaconDB,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("Could not create p2p service")
+	}
+	opsService, err := attestations.NewService(ctx, &attestations.Config{Pool: attPool})
+	if err != nil {
+		log.WithError(err).Fatal("Could not create attestation service")
+	}
+	stateGen := stategen.New(beaconDB, stateSummaryCache)
+	chainService, err := blockchain.NewService(ctx, &blockchain.Config{
+		BeaconDB:        beaconDB,
+		DepositCache:    depositCache,
+		AttPool:         attPool,
+		ExitPool:        exitPool,
+		SlashingPool:    slashingPool,
+		P2p:             p2pService,
+		MaxRoutines:     cmd.MaxGoroutines.Value,
+		StateNotifier:   n,
+		ForkChoiceStore: protoarray.New(0, 0, params.BeaconConfig().ZeroHash),
+		OpsService:      opsService,
+		StateGen:        stateGen,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("Could not create blockchain service")
+	}
+	chain := &replayChain{Service: chainService}
+	syncService := sync.NewRegularSync(&sync.Config{
+		DB:                  beaconDB,
+		P2P:                 p2pService,
+		Chain:               chain,
+		InitialSync:         synced{},
+		StateNotifier:       n,
+		BlockNotifier:       n,
+		AttestationNotifier: n,
+		AttPool:             attPool,
+		ExitPool:            exitPool,
+		SlashingPool:        slashingPool,
+		StateSummaryCache:   stateSummaryCache,
+		StateGen:            stateGen,
+	})
+	chainService.Start()
+	syncService.Start()
+	defer func() {
+		if err := syncService.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop sync service")
+		}
+		if err := chainService.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop blockchain service")
+		}
+	}()
+
+	var replayed, mismatches, failures int
+	for _, file := range files {
+		log.WithField("file", file).Info("Replaying gossip trace file")
+		if err := p2p.ReadGossipTraceFile(file, func(record *p2p.GossipTraceRecord) error {
+			if !strings.Contains(record.Topic, *topic) {
+				return nil
+			}
+			pid, err := peer.Decode(record.Sender)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode sender %s", record.Sender)
+			}
+			if *recordTime {
+				chain.offset = roughtime.Since(record.Timestamp)
+			}
+			result, err := syncService.ReplayGossipMessage(ctx, record.Topic, pid, record.Data)
+			fields := log.WithFields(logrus.Fields{
+				"topic":     record.Topic,
+				"sender":    record.Sender,
+				"timestamp": record.Timestamp,
+				"recorded":  record.Result,
+				"replayed":  p2p.GossipTraceResult(result),
+			})
+			replayed++
+			if err != nil {
+				failures++
+				fields.WithError(err).Error("Could not replay gossip message")
+				return nil
+			}
+			if p2p.GossipTraceResult(result) != record.Result {
+				mismatches++
+				fields.Warn("Validation result differs from the recorded result")
+				return nil
+			}
+			fields.Debug("Replayed gossip message")
+			return nil
+		}); err != nil {
+			log.WithError(err).Fatal("Could not replay gossip trace file")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"replayed":   replayed,
+		"mismatches": mismatches,
+		"failures":   failures,
+	}).Info("Finished replaying gossip traces")
+}
+
+// traceFiles returns the gossip trace files of a directory, from the oldest to the most recent
+// file, or the given path when it is a single file.
+func traceFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	files, err := p2p.GossipTraceFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no gossip trace files in %s", path)
+	}
+	return files, nil
+}
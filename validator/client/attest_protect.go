@@ -2,9 +2,9 @@ package client
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
@@ -18,6 +18,15 @@ var failedPostAttSignExternalErr = "external slasher service detected a submitte
 func (v *validator) preAttSignValidations(ctx context.Context, indexedAtt *ethpb.IndexedAttestation, pubKey [48]byte) error {
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	if featureconfig.Get().LocalProtection {
+		if err := v.checkHighestSignedAttestation(ctx, pubKey, indexedAtt.Data.Source.Epoch, indexedAtt.Data.Target.Epoch); err != nil {
+			if v.emitAccountMetrics {
+				ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return err
+		}
+	}
+
+	if featureconfig.Get().LocalProtection && !featureconfig.Get().MinimalSlashingProtection {
 		v.attesterHistoryByPubKeyLock.RLock()
 		attesterHistory, ok := v.attesterHistoryByPubKey[pubKey]
 		v.attesterHistoryByPubKeyLock.RUnlock()
@@ -44,7 +53,14 @@ func (v *validator) preAttSignValidations(ctx context.Context, indexedAtt *ethpb
 
 func (v *validator) postAttSignUpdate(ctx context.Context, indexedAtt *ethpb.IndexedAttestation, pubKey [48]byte) error {
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
-	if featureconfig.Get().LocalProtection {
+	if featureconfig.Get().LocalProtection && featureconfig.Get().MinimalSlashingProtection {
+		if err := v.db.SaveHighestSignedAttestation(ctx, pubKey, indexedAtt.Data.Source.Epoch, indexedAtt.Data.Target.Epoch); err != nil {
+			if v.emitAccountMetrics {
+				ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return errors.Wrap(err, "failed to save highest signed attestation")
+		}
+	} else if featureconfig.Get().LocalProtection {
 		v.attesterHistoryByPubKeyLock.Lock()
 		attesterHistory, ok := v.attesterHistoryByPubKey[pubKey]
 		if ok {
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// A synced beacon node keeps being used unless another one has a head at least this many slots higher,
+// so that nodes of about the same head do not take turns.
+const failoverHeadSlotTolerance = 2
+
+// beaconNode is the connection to one of the configured beacon nodes.
+type beaconNode struct {
+	endpoint        string
+	validatorClient ethpb.BeaconNodeValidatorClient
+	beaconClient    ethpb.BeaconChainClient
+	nodeClient      ethpb.NodeClient
+}
+
+// beaconNodes routes the requests of the validator to the healthiest of the configured beacon nodes,
+// which is the synced node with the highest head. The health of the nodes is checked every half slot,
+// and the requests fail over to another node as soon as the active node is unhealthy.
+type beaconNodes struct {
+	nodes  []*beaconNode
+	active *beaconNode
+	lock   sync.RWMutex
+}
+
+// beaconNodeHealth is the result of a health check of a beacon node.
+type beaconNodeHealth struct {
+	synced   bool
+	headSlot uint64
+}
+
+func newBeaconNodes(endpoints []string, conns []*grpc.ClientConn) *beaconNodes {
+	nodes := make([]*beaconNode, len(conns))
+	for i, conn := range conns {
+		nodes[i] = &beaconNode{
+			endpoint:        endpoints[i],
+			validatorClient: ethpb.NewBeaconNodeValidatorClient(conn),
+			beaconClient:    ethpb.NewBeaconChainClient(conn),
+			nodeClient:      ethpb.NewNodeClient(conn),
+		}
+	}
+	return &beaconNodes{
+		nodes:  nodes,
+		active: nodes[0],
+	}
+}
+
+// current returns the beacon node to which the requests are routed.
+func (b *beaconNodes) current() *beaconNode {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.active
+}
+
+// activeEndpoint returns the endpoint of the beacon node to which the requests are routed.
+func (b *beaconNodes) activeEndpoint() string {
+	return b.current().endpoint
+}
+
+// beaconEndpoint returns the endpoint of the beacon node to which the requests of the validator are
+// routed, if the validator is connected to beacon nodes.
+func (v *validator) beaconEndpoint() string {
+	if v.beaconNodes == nil {
+		return ""
+	}
+	return v.beaconNodes.activeEndpoint()
+}
+
+// run checks the health of the beacon nodes until the context is canceled. There is nothing to fail
+// over to with a single beacon node.
+func (b *beaconNodes) run(ctx context.Context) {
+	if len(b.nodes) < 2 {
+		return
+	}
+	interval := slotutil.DivideSlotBy(2 /* twice per slot */)
+	for {
+		b.checkHealth(ctx, interval)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth checks the health of every beacon node, and routes the requests to the healthiest node.
+// The active node is kept when no node is synced.
+func (b *beaconNodes) checkHealth(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	health := make([]*beaconNodeHealth, len(b.nodes))
+	var wg sync.WaitGroup
+	for i, node := range b.nodes {
+		wg.Add(1)
+		go func(i int, node *beaconNode) {
+			defer wg.Done()
+			h, err := node.health(ctx)
+			if err != nil {
+				log.WithError(err).WithField("endpoint", node.endpoint).Debug("Beacon node health check failed")
+				h = &beaconNodeHealth{}
+			}
+			health[i] = h
+		}(i, node)
+	}
+	wg.Wait()
+
+	active := b.current()
+	var activeHealth *beaconNodeHealth
+	var best *beaconNode
+	var bestHealth *beaconNodeHealth
+	for i, node := range b.nodes {
+		if node == active {
+			activeHealth = health[i]
+		}
+		if !health[i].synced {
+			continue
+		}
+		if bestHealth == nil || health[i].headSlot > bestHealth.headSlot {
+			best = node
+			bestHealth = health[i]
+		}
+	}
+	if best == nil {
+		log.WithField("endpoint", active.endpoint).Warn("No synced beacon node, keeping the active beacon node")
+		return
+	}
+	if best == active || (activeHealth.synced && activeHealth.headSlot+failoverHeadSlotTolerance > bestHealth.headSlot) {
+		return
+	}
+
+	b.lock.Lock()
+	b.active = best
+	b.lock.Unlock()
+	log.WithFields(logrus.Fields{
+		"from":       active.endpoint,
+		"to":         best.endpoint,
+		"fromSynced": activeHealth.synced,
+		"toHeadSlot": bestHealth.headSlot,
+	}).Warn("Failing over to another beacon node")
+}
+
+// health returns whether the beacon node is synced, and the slot of its head.
+func (n *beaconNode) health(ctx context.Context) (*beaconNodeHealth, error) {
+	s, err := n.nodeClient.GetSyncStatus(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync status")
+	}
+	if s.Syncing {
+		return &beaconNodeHealth{}, nil
+	}
+	head, err := n.beaconClient.GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get chain head")
+	}
+	return &beaconNodeHealth{synced: true, headSlot: head.HeadSlot}, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+)
+
+type beaconNodeMocks struct {
+	nodeClient   *mock.MockNodeClient
+	beaconClient *mock.MockBeaconChainClient
+}
+
+func setupBeaconNodes(t *testing.T, endpoints ...string) (*beaconNodes, []*beaconNodeMocks, func()) {
+	ctrl := gomock.NewController(t)
+	b := &beaconNodes{}
+	m := make([]*beaconNodeMocks, len(endpoints))
+	for i, endpoint := range endpoints {
+		m[i] = &beaconNodeMocks{
+			nodeClient:   mock.NewMockNodeClient(ctrl),
+			beaconClient: mock.NewMockBeaconChainClient(ctrl),
+		}
+		b.nodes = append(b.nodes, &beaconNode{
+			endpoint:     endpoint,
+			nodeClient:   m[i].nodeClient,
+			beaconClient: m[i].beaconClient,
+		})
+	}
+	b.active = b.nodes[0]
+	return b, m, ctrl.Finish
+}
+
+func (m *beaconNodeMocks) expectHealth(syncing bool, headSlot uint64) {
+	m.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: syncing}, nil)
+	if !syncing {
+		m.beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: headSlot}, nil)
+	}
+}
+
+func TestBeaconNodes_FailsOverFromUnavailableNode(t *testing.T) {
+	b, m, finish := setupBeaconNodes(t, "a", "b")
+	defer finish()
+
+	m[0].nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	m[1].expectHealth(false, 10)
+	b.checkHealth(context.Background(), time.Second)
+	assert.Equal(t, "b", b.activeEndpoint())
+}
+
+func TestBeaconNodes_FailsOverFromSyncingNode(t *testing.T) {
+	b, m, finish := setupBeaconNodes(t, "a", "b", "c")
+	defer finish()
+
+	m[0].expectHealth(true, 0)
+	m[1].expectHealth(false, 10)
+	m[2].expectHealth(false, 12)
+	b.checkHealth(context.Background(), time.Second)
+	assert.Equal(t, "c", b.activeEndpoint(), "Expected the synced node with the highest head")
+}
+
+func TestBeaconNodes_KeepsActiveNode(t *testing.T) {
+	b, m, finish := setupBeaconNodes(t, "a", "b")
+	defer finish()
+
+	// A head within the tolerance does not cause a failover.
+	m[0].expectHealth(false, 10)
+	m[1].expectHealth(false, 11)
+	b.checkHealth(context.Background(), time.Second)
+	assert.Equal(t, "a", b.activeEndpoint())
+
+	// Nor does having no synced node.
+	m[0].expectHealth(true, 0)
+	m[1].nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	b.checkHealth(context.Background(), time.Second)
+	assert.Equal(t, "a", b.activeEndpoint())
+
+	// A head far enough ahead does.
+	m[0].expectHealth(false, 10)
+	m[1].expectHealth(false, 12)
+	b.checkHealth(context.Background(), time.Second)
+	assert.Equal(t, "b", b.activeEndpoint())
+}
+
+func TestFailoverClients_RouteToActiveNode(t *testing.T) {
+	b, m, finish := setupBeaconNodes(t, "a", "b")
+	defer finish()
+
+	client := &failoverNodeClient{nodes: b}
+	m[0].nodeClient.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{}, nil)
+	_, err := client.GetGenesis(context.Background(), nil)
+	assert.NoError(t, err)
+
+	b.active = b.nodes[1]
+	m[1].nodeClient.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{}, nil)
+	_, err = client.GetGenesis(context.Background(), nil)
+	assert.NoError(t, err)
+}
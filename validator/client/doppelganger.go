@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// CheckDoppelganger watches the beacon chain for the configured number of epochs before the validator
+// signs anything, and returns an error if any of the validating keys is seen attesting or proposing
+// meanwhile. Such a key is being used by another validator client, and signing with both clients would
+// get the validator slashed.
+func (v *validator) CheckDoppelganger(ctx context.Context) error {
+	if v.doppelgangerEpochs == 0 {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelganger")
+	defer span.End()
+
+	var validatingKeys [][48]byte
+	var err error
+	if featureconfig.Get().EnableAccountsV2 {
+		validatingKeys, err = v.keyManagerV2.FetchValidatingPublicKeys(ctx)
+	} else {
+		validatingKeys, err = v.keyManager.FetchValidatingKeys()
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating keys")
+	}
+
+	// This client may have been signing for the current epoch before a restart, so the detection
+	// starts at the next epoch. The attestations of the last epoch may only be included in blocks of
+	// the epoch after it, which is watched too.
+	currentSlot := slotutil.SlotsSinceGenesis(time.Unix(int64(v.genesisTime), 0))
+	startEpoch := helpers.SlotToEpoch(currentSlot) + 1
+	endEpoch := startEpoch + v.doppelgangerEpochs
+	log.WithFields(logrus.Fields{
+		"startEpoch": startEpoch,
+		"endEpoch":   endEpoch,
+	}).Info("Watching the beacon chain for other instances of the validating keys before signing")
+
+	for epoch := startEpoch; epoch < endEpoch; epoch++ {
+		if err := v.waitForEpochEnd(ctx, epoch); err != nil {
+			return err
+		}
+		if err := v.checkDoppelgangerAtEpoch(ctx, validatingKeys, startEpoch, epoch); err != nil {
+			return err
+		}
+		log.WithField("epoch", epoch).Info("No other instances of the validating keys seen")
+	}
+	if err := v.waitForEpochEnd(ctx, endEpoch); err != nil {
+		return err
+	}
+	if err := v.checkLateAttestations(ctx, validatingKeys, startEpoch, endEpoch); err != nil {
+		return err
+	}
+	log.WithField("epoch", endEpoch).Info("No other instances of the validating keys seen")
+	return nil
+}
+
+// waitForEpochEnd waits for the start of the epoch after the given epoch, when the attestations of
+// the epoch are known to the beacon state.
+func (v *validator) waitForEpochEnd(ctx context.Context, epoch uint64) error {
+	for {
+		select {
+		case slot := <-v.NextSlot():
+			if slot >= helpers.StartSlot(epoch+1) {
+				return nil
+			}
+		case <-ctx.Done():
+			return errors.New("context has been canceled, exiting doppelganger detection")
+		}
+	}
+}
+
+// checkDoppelgangerAtEpoch returns an error if any of the validating keys attested with a target of
+// the epoch, or is included in an attestation or proposed a block during the epoch. Attestations with
+// a target before the start epoch of the detection may have been signed by this client and are ignored.
+func (v *validator) checkDoppelgangerAtEpoch(ctx context.Context, validatingKeys [][48]byte, startEpoch uint64, epoch uint64) error {
+	// The previous epoch attesters at the start of the next epoch are the attesters with a target of
+	// the epoch.
+	votes, err := v.beaconClient.GetIndividualVotes(ctx, &ethpb.IndividualVotesRequest{
+		Epoch:      epoch + 1,
+		PublicKeys: bytesutil.FromBytes48Array(validatingKeys),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not get individual votes of epoch %d", epoch)
+	}
+	pubKeysByIndex := make(map[uint64][]byte, len(votes.IndividualVotes))
+	for _, vote := range votes.IndividualVotes {
+		// Unknown public keys have no index.
+		if vote.ValidatorIndex == ^uint64(0) {
+			continue
+		}
+		if vote.IsPreviousEpochAttester {
+			return doppelgangerErr(vote.PublicKey, "attesting", epoch)
+		}
+		pubKeysByIndex[vote.ValidatorIndex] = vote.PublicKey
+	}
+	if len(pubKeysByIndex) == 0 {
+		return nil
+	}
+
+	if err := v.checkIncludedAttestations(ctx, pubKeysByIndex, startEpoch, epoch); err != nil {
+		return err
+	}
+
+	pageToken := ""
+	for {
+		res, err := v.beaconClient.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+			QueryFilter: &ethpb.ListBlocksRequest_Epoch{Epoch: epoch},
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not list blocks of epoch %d", epoch)
+		}
+		for _, container := range res.BlockContainers {
+			if pubKey, ok := pubKeysByIndex[container.Block.Block.ProposerIndex]; ok {
+				return doppelgangerErr(pubKey, "proposing", epoch)
+			}
+		}
+		if res.NextPageToken == "" || len(res.BlockContainers) == 0 {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	return nil
+}
+
+// checkLateAttestations returns an error if any of the validating keys is included in an attestation
+// during the epoch after the last epoch of the detection, which may include attestations with a target
+// of the last epoch. This client is not signing during that epoch, so every attestation is checked.
+func (v *validator) checkLateAttestations(ctx context.Context, validatingKeys [][48]byte, startEpoch uint64, epoch uint64) error {
+	votes, err := v.beaconClient.GetIndividualVotes(ctx, &ethpb.IndividualVotesRequest{
+		Epoch:      epoch,
+		PublicKeys: bytesutil.FromBytes48Array(validatingKeys),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not get individual votes of epoch %d", epoch)
+	}
+	pubKeysByIndex := make(map[uint64][]byte, len(votes.IndividualVotes))
+	for _, vote := range votes.IndividualVotes {
+		// Unknown public keys have no index.
+		if vote.ValidatorIndex == ^uint64(0) {
+			continue
+		}
+		pubKeysByIndex[vote.ValidatorIndex] = vote.PublicKey
+	}
+	if len(pubKeysByIndex) == 0 {
+		return nil
+	}
+	return v.checkIncludedAttestations(ctx, pubKeysByIndex, startEpoch, epoch)
+}
+
+// checkIncludedAttestations returns an error if any of the validators is included in an attestation
+// of the blocks of the epoch, with a target from the start epoch of the detection.
+func (v *validator) checkIncludedAttestations(ctx context.Context, pubKeysByIndex map[uint64][]byte, startEpoch uint64, epoch uint64) error {
+	pageToken := ""
+	for {
+		res, err := v.beaconClient.ListIndexedAttestations(ctx, &ethpb.ListIndexedAttestationsRequest{
+			QueryFilter: &ethpb.ListIndexedAttestationsRequest_Epoch{Epoch: epoch},
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not list indexed attestations of epoch %d", epoch)
+		}
+		for _, att := range res.IndexedAttestations {
+			if att.Data.Target.Epoch < startEpoch {
+				continue
+			}
+			for _, index := range att.AttestingIndices {
+				if pubKey, ok := pubKeysByIndex[index]; ok {
+					return doppelgangerErr(pubKey, "attesting", att.Data.Target.Epoch)
+				}
+			}
+		}
+		// Empty results have a page token of "0".
+		if res.NextPageToken == "" || len(res.IndexedAttestations) == 0 {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	return nil
+}
+
+func doppelgangerErr(pubKey []byte, action string, epoch uint64) error {
+	return fmt.Errorf(
+		"validator %#x was seen %s in epoch %d while this client was not signing, the key is likely used "+
+			"by another validator client which must be stopped before starting this one",
+		pubKey,
+		action,
+		epoch,
+	)
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func doppelgangerSetup(t *testing.T) (*validator, *mock.MockBeaconChainClient, [48]byte, func()) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := &validator{
+		keyManager:         testKeyManager,
+		beaconClient:       client,
+		doppelgangerEpochs: 2,
+	}
+	keys, err := testKeyManager.FetchValidatingKeys()
+	require.NoError(t, err)
+	client.EXPECT().GetIndividualVotes(gomock.Any(), gomock.Any()).Return(&ethpb.IndividualVotesRespond{
+		IndividualVotes: []*ethpb.IndividualVotesRespond_IndividualVote{
+			{PublicKey: keys[0][:], ValidatorIndex: 5},
+		},
+	}, nil)
+	return v, client, keys[0], ctrl.Finish
+}
+
+func TestCheckDoppelganger_Disabled(t *testing.T) {
+	v := &validator{}
+	require.NoError(t, v.CheckDoppelganger(context.Background()))
+}
+
+func TestCheckDoppelgangerAtEpoch_NoneSeen(t *testing.T) {
+	v, client, pubKey, finish := doppelgangerSetup(t)
+	defer finish()
+
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			// Attestations of other validators.
+			attestationWithIndices(9, 10, 3, 4),
+			// Attestations signed before the detection started.
+			attestationWithIndices(8, 9, 5),
+		},
+	}, nil)
+	client.EXPECT().ListBlocks(gomock.Any(), gomock.Any()).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{
+			{Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{ProposerIndex: 6}}},
+		},
+		NextPageToken: "0",
+	}, nil)
+	require.NoError(t, v.checkDoppelgangerAtEpoch(context.Background(), [][48]byte{pubKey}, 10, 10))
+}
+
+func TestCheckDoppelgangerAtEpoch_Attester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	v := &validator{beaconClient: client}
+	var pubKey [48]byte
+	client.EXPECT().GetIndividualVotes(gomock.Any(), gomock.Any()).Return(&ethpb.IndividualVotesRespond{
+		IndividualVotes: []*ethpb.IndividualVotesRespond_IndividualVote{
+			{PublicKey: pubKey[:], ValidatorIndex: 5, IsPreviousEpochAttester: true},
+		},
+	}, nil)
+	err := v.checkDoppelgangerAtEpoch(context.Background(), [][48]byte{pubKey}, 10, 10)
+	require.ErrorContains(t, "seen attesting in epoch 10", err)
+}
+
+func TestCheckDoppelgangerAtEpoch_IncludedAttestation(t *testing.T) {
+	v, client, pubKey, finish := doppelgangerSetup(t)
+	defer finish()
+
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			attestationWithIndices(9, 10, 4, 5),
+		},
+	}, nil)
+	err := v.checkDoppelgangerAtEpoch(context.Background(), [][48]byte{pubKey}, 10, 10)
+	require.ErrorContains(t, "seen attesting in epoch 10", err)
+}
+
+func TestCheckDoppelgangerAtEpoch_Proposer(t *testing.T) {
+	v, client, pubKey, finish := doppelgangerSetup(t)
+	defer finish()
+
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{}, nil)
+	client.EXPECT().ListBlocks(gomock.Any(), gomock.Any()).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{
+			{Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{ProposerIndex: 5}}},
+		},
+	}, nil)
+	err := v.checkDoppelgangerAtEpoch(context.Background(), [][48]byte{pubKey}, 10, 10)
+	require.ErrorContains(t, "seen proposing in epoch 10", err)
+}
+
+func TestCheckLateAttestations_IncludedAttestation(t *testing.T) {
+	v, client, pubKey, finish := doppelgangerSetup(t)
+	defer finish()
+
+	// An attestation with a target of the last epoch of the detection, included in the epoch after it.
+	client.EXPECT().ListIndexedAttestations(gomock.Any(), gomock.Any()).Return(&ethpb.ListIndexedAttestationsResponse{
+		IndexedAttestations: []*ethpb.IndexedAttestation{
+			attestationWithIndices(10, 11, 5),
+		},
+	}, nil)
+	err := v.checkLateAttestations(context.Background(), [][48]byte{pubKey}, 10, 12)
+	require.ErrorContains(t, "seen attesting in epoch 11", err)
+}
+
+func attestationWithIndices(source uint64, target uint64, indices ...uint64) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		},
+	}
+}
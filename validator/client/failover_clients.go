@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+var _ = ethpb.BeaconNodeValidatorClient(&failoverValidatorClient{})
+var _ = ethpb.BeaconChainClient(&failoverBeaconChainClient{})
+var _ = ethpb.NodeClient(&failoverNodeClient{})
+
+// failoverValidatorClient routes the BeaconNodeValidatorClient requests to the active beacon node.
+type failoverValidatorClient struct {
+	nodes *beaconNodes
+}
+
+// failoverBeaconChainClient routes the BeaconChainClient requests to the active beacon node.
+type failoverBeaconChainClient struct {
+	nodes *beaconNodes
+}
+
+// failoverNodeClient routes the NodeClient requests to the active beacon node.
+type failoverNodeClient struct {
+	nodes *beaconNodes
+}
+
+// GetDuties calls GetDuties on the active beacon node.
+func (c *failoverValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+	return c.nodes.current().validatorClient.GetDuties(ctx, in, opts...)
+}
+
+// StreamDuties calls StreamDuties on the active beacon node.
+func (c *failoverValidatorClient) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	return c.nodes.current().validatorClient.StreamDuties(ctx, in, opts...)
+}
+
+// DomainData calls DomainData on the active beacon node.
+func (c *failoverValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest, opts ...grpc.CallOption) (*ethpb.DomainResponse, error) {
+	return c.nodes.current().validatorClient.DomainData(ctx, in, opts...)
+}
+
+// WaitForChainStart calls WaitForChainStart on the active beacon node.
+func (c *failoverValidatorClient) WaitForChainStart(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	return c.nodes.current().validatorClient.WaitForChainStart(ctx, in, opts...)
+}
+
+// WaitForSynced calls WaitForSynced on the active beacon node.
+func (c *failoverValidatorClient) WaitForSynced(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForSyncedClient, error) {
+	return c.nodes.current().validatorClient.WaitForSynced(ctx, in, opts...)
+}
+
+// WaitForActivation calls WaitForActivation on the active beacon node.
+func (c *failoverValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return c.nodes.current().validatorClient.WaitForActivation(ctx, in, opts...)
+}
+
+// ValidatorIndex calls ValidatorIndex on the active beacon node.
+func (c *failoverValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, opts ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error) {
+	return c.nodes.current().validatorClient.ValidatorIndex(ctx, in, opts...)
+}
+
+// ValidatorStatus calls ValidatorStatus on the active beacon node.
+func (c *failoverValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.ValidatorStatusResponse, error) {
+	return c.nodes.current().validatorClient.ValidatorStatus(ctx, in, opts...)
+}
+
+// MultipleValidatorStatus calls MultipleValidatorStatus on the active beacon node.
+func (c *failoverValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return c.nodes.current().validatorClient.MultipleValidatorStatus(ctx, in, opts...)
+}
+
+// GetBlock calls GetBlock on the active beacon node.
+func (c *failoverValidatorClient) GetBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.BeaconBlock, error) {
+	return c.nodes.current().validatorClient.GetBlock(ctx, in, opts...)
+}
+
+// ProposeBlock calls ProposeBlock on the active beacon node.
+func (c *failoverValidatorClient) ProposeBlock(ctx context.Context, in *ethpb.SignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	return c.nodes.current().validatorClient.ProposeBlock(ctx, in, opts...)
+}
+
+// GetAttestationData calls GetAttestationData on the active beacon node.
+func (c *failoverValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest, opts ...grpc.CallOption) (*ethpb.AttestationData, error) {
+	return c.nodes.current().validatorClient.GetAttestationData(ctx, in, opts...)
+}
+
+// ProposeAttestation calls ProposeAttestation on the active beacon node.
+func (c *failoverValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation, opts ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	return c.nodes.current().validatorClient.ProposeAttestation(ctx, in, opts...)
+}
+
+// SubmitAggregateSelectionProof calls SubmitAggregateSelectionProof on the active beacon node.
+func (c *failoverValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, opts ...grpc.CallOption) (*ethpb.AggregateSelectionResponse, error) {
+	return c.nodes.current().validatorClient.SubmitAggregateSelectionProof(ctx, in, opts...)
+}
+
+// SubmitSignedAggregateSelectionProof calls SubmitSignedAggregateSelectionProof on the active beacon node.
+func (c *failoverValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, opts ...grpc.CallOption) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return c.nodes.current().validatorClient.SubmitSignedAggregateSelectionProof(ctx, in, opts...)
+}
+
+// ProposeExit calls ProposeExit on the active beacon node.
+func (c *failoverValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, opts ...grpc.CallOption) (*ptypes.Empty, error) {
+	return c.nodes.current().validatorClient.ProposeExit(ctx, in, opts...)
+}
+
+// SubscribeCommitteeSubnets calls SubscribeCommitteeSubnets on the active beacon node.
+func (c *failoverValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, opts ...grpc.CallOption) (*ptypes.Empty, error) {
+	return c.nodes.current().validatorClient.SubscribeCommitteeSubnets(ctx, in, opts...)
+}
+
+// ListAttestations calls ListAttestations on the active beacon node.
+func (c *failoverBeaconChainClient) ListAttestations(ctx context.Context, in *ethpb.ListAttestationsRequest, opts ...grpc.CallOption) (*ethpb.ListAttestationsResponse, error) {
+	return c.nodes.current().beaconClient.ListAttestations(ctx, in, opts...)
+}
+
+// ListIndexedAttestations calls ListIndexedAttestations on the active beacon node.
+func (c *failoverBeaconChainClient) ListIndexedAttestations(ctx context.Context, in *ethpb.ListIndexedAttestationsRequest, opts ...grpc.CallOption) (*ethpb.ListIndexedAttestationsResponse, error) {
+	return c.nodes.current().beaconClient.ListIndexedAttestations(ctx, in, opts...)
+}
+
+// StreamAttestations calls StreamAttestations on the active beacon node.
+func (c *failoverBeaconChainClient) StreamAttestations(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconChain_StreamAttestationsClient, error) {
+	return c.nodes.current().beaconClient.StreamAttestations(ctx, in, opts...)
+}
+
+// StreamIndexedAttestations calls StreamIndexedAttestations on the active beacon node.
+func (c *failoverBeaconChainClient) StreamIndexedAttestations(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconChain_StreamIndexedAttestationsClient, error) {
+	return c.nodes.current().beaconClient.StreamIndexedAttestations(ctx, in, opts...)
+}
+
+// AttestationPool calls AttestationPool on the active beacon node.
+func (c *failoverBeaconChainClient) AttestationPool(ctx context.Context, in *ethpb.AttestationPoolRequest, opts ...grpc.CallOption) (*ethpb.AttestationPoolResponse, error) {
+	return c.nodes.current().beaconClient.AttestationPool(ctx, in, opts...)
+}
+
+// ListBlocks calls ListBlocks on the active beacon node.
+func (c *failoverBeaconChainClient) ListBlocks(ctx context.Context, in *ethpb.ListBlocksRequest, opts ...grpc.CallOption) (*ethpb.ListBlocksResponse, error) {
+	return c.nodes.current().beaconClient.ListBlocks(ctx, in, opts...)
+}
+
+// StreamBlocks calls StreamBlocks on the active beacon node.
+func (c *failoverBeaconChainClient) StreamBlocks(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconChain_StreamBlocksClient, error) {
+	return c.nodes.current().beaconClient.StreamBlocks(ctx, in, opts...)
+}
+
+// StreamChainHead calls StreamChainHead on the active beacon node.
+func (c *failoverBeaconChainClient) StreamChainHead(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (ethpb.BeaconChain_StreamChainHeadClient, error) {
+	return c.nodes.current().beaconClient.StreamChainHead(ctx, in, opts...)
+}
+
+// GetChainHead calls GetChainHead on the active beacon node.
+func (c *failoverBeaconChainClient) GetChainHead(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	return c.nodes.current().beaconClient.GetChainHead(ctx, in, opts...)
+}
+
+// ListBeaconCommittees calls ListBeaconCommittees on the active beacon node.
+func (c *failoverBeaconChainClient) ListBeaconCommittees(ctx context.Context, in *ethpb.ListCommitteesRequest, opts ...grpc.CallOption) (*ethpb.BeaconCommittees, error) {
+	return c.nodes.current().beaconClient.ListBeaconCommittees(ctx, in, opts...)
+}
+
+// ListValidatorBalances calls ListValidatorBalances on the active beacon node.
+func (c *failoverBeaconChainClient) ListValidatorBalances(ctx context.Context, in *ethpb.ListValidatorBalancesRequest, opts ...grpc.CallOption) (*ethpb.ValidatorBalances, error) {
+	return c.nodes.current().beaconClient.ListValidatorBalances(ctx, in, opts...)
+}
+
+// ListValidators calls ListValidators on the active beacon node.
+func (c *failoverBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest, opts ...grpc.CallOption) (*ethpb.Validators, error) {
+	return c.nodes.current().beaconClient.ListValidators(ctx, in, opts...)
+}
+
+// GetValidator calls GetValidator on the active beacon node.
+func (c *failoverBeaconChainClient) GetValidator(ctx context.Context, in *ethpb.GetValidatorRequest, opts ...grpc.CallOption) (*ethpb.Validator, error) {
+	return c.nodes.current().beaconClient.GetValidator(ctx, in, opts...)
+}
+
+// GetValidatorActiveSetChanges calls GetValidatorActiveSetChanges on the active beacon node.
+func (c *failoverBeaconChainClient) GetValidatorActiveSetChanges(ctx context.Context, in *ethpb.GetValidatorActiveSetChangesRequest, opts ...grpc.CallOption) (*ethpb.ActiveSetChanges, error) {
+	return c.nodes.current().beaconClient.GetValidatorActiveSetChanges(ctx, in, opts...)
+}
+
+// GetValidatorQueue calls GetValidatorQueue on the active beacon node.
+func (c *failoverBeaconChainClient) GetValidatorQueue(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ValidatorQueue, error) {
+	return c.nodes.current().beaconClient.GetValidatorQueue(ctx, in, opts...)
+}
+
+// GetValidatorPerformance calls GetValidatorPerformance on the active beacon node.
+func (c *failoverBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest, opts ...grpc.CallOption) (*ethpb.ValidatorPerformanceResponse, error) {
+	return c.nodes.current().beaconClient.GetValidatorPerformance(ctx, in, opts...)
+}
+
+// ListValidatorAssignments calls ListValidatorAssignments on the active beacon node.
+func (c *failoverBeaconChainClient) ListValidatorAssignments(ctx context.Context, in *ethpb.ListValidatorAssignmentsRequest, opts ...grpc.CallOption) (*ethpb.ValidatorAssignments, error) {
+	return c.nodes.current().beaconClient.ListValidatorAssignments(ctx, in, opts...)
+}
+
+// GetValidatorParticipation calls GetValidatorParticipation on the active beacon node.
+func (c *failoverBeaconChainClient) GetValidatorParticipation(ctx context.Context, in *ethpb.GetValidatorParticipationRequest, opts ...grpc.CallOption) (*ethpb.ValidatorParticipationResponse, error) {
+	return c.nodes.current().beaconClient.GetValidatorParticipation(ctx, in, opts...)
+}
+
+// GetBeaconConfig calls GetBeaconConfig on the active beacon node.
+func (c *failoverBeaconChainClient) GetBeaconConfig(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.BeaconConfig, error) {
+	return c.nodes.current().beaconClient.GetBeaconConfig(ctx, in, opts...)
+}
+
+// StreamValidatorsInfo calls StreamValidatorsInfo on the active beacon node.
+func (c *failoverBeaconChainClient) StreamValidatorsInfo(ctx context.Context, opts ...grpc.CallOption) (ethpb.BeaconChain_StreamValidatorsInfoClient, error) {
+	return c.nodes.current().beaconClient.StreamValidatorsInfo(ctx, opts...)
+}
+
+// SubmitAttesterSlashing calls SubmitAttesterSlashing on the active beacon node.
+func (c *failoverBeaconChainClient) SubmitAttesterSlashing(ctx context.Context, in *ethpb.AttesterSlashing, opts ...grpc.CallOption) (*ethpb.SubmitSlashingResponse, error) {
+	return c.nodes.current().beaconClient.SubmitAttesterSlashing(ctx, in, opts...)
+}
+
+// SubmitProposerSlashing calls SubmitProposerSlashing on the active beacon node.
+func (c *failoverBeaconChainClient) SubmitProposerSlashing(ctx context.Context, in *ethpb.ProposerSlashing, opts ...grpc.CallOption) (*ethpb.SubmitSlashingResponse, error) {
+	return c.nodes.current().beaconClient.SubmitProposerSlashing(ctx, in, opts...)
+}
+
+// GetIndividualVotes calls GetIndividualVotes on the active beacon node.
+func (c *failoverBeaconChainClient) GetIndividualVotes(ctx context.Context, in *ethpb.IndividualVotesRequest, opts ...grpc.CallOption) (*ethpb.IndividualVotesRespond, error) {
+	return c.nodes.current().beaconClient.GetIndividualVotes(ctx, in, opts...)
+}
+
+// GetSyncStatus calls GetSyncStatus on the active beacon node.
+func (c *failoverNodeClient) GetSyncStatus(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	return c.nodes.current().nodeClient.GetSyncStatus(ctx, in, opts...)
+}
+
+// GetGenesis calls GetGenesis on the active beacon node.
+func (c *failoverNodeClient) GetGenesis(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.Genesis, error) {
+	return c.nodes.current().nodeClient.GetGenesis(ctx, in, opts...)
+}
+
+// GetVersion calls GetVersion on the active beacon node.
+func (c *failoverNodeClient) GetVersion(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.Version, error) {
+	return c.nodes.current().nodeClient.GetVersion(ctx, in, opts...)
+}
+
+// ListImplementedServices calls ListImplementedServices on the active beacon node.
+func (c *failoverNodeClient) ListImplementedServices(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.ImplementedServices, error) {
+	return c.nodes.current().nodeClient.ListImplementedServices(ctx, in, opts...)
+}
+
+// GetHost calls GetHost on the active beacon node.
+func (c *failoverNodeClient) GetHost(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.HostData, error) {
+	return c.nodes.current().nodeClient.GetHost(ctx, in, opts...)
+}
+
+// GetPeer calls GetPeer on the active beacon node.
+func (c *failoverNodeClient) GetPeer(ctx context.Context, in *ethpb.PeerRequest, opts ...grpc.CallOption) (*ethpb.Peer, error) {
+	return c.nodes.current().nodeClient.GetPeer(ctx, in, opts...)
+}
+
+// ListPeers calls ListPeers on the active beacon node.
+func (c *failoverNodeClient) ListPeers(ctx context.Context, in *ptypes.Empty, opts ...grpc.CallOption) (*ethpb.Peers, error) {
+	return c.nodes.current().nodeClient.ListPeers(ctx, in, opts...)
+}
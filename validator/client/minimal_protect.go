@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var failedPreBlockSignMinimalErr = "attempted to sign a block at or below the highest signed slot, rejected by minimal slashing protection"
+var failedPreAttSignMinimalErr = "attempted to sign an attestation below the highest signed epochs, rejected by minimal slashing protection"
+
+// checkHighestSignedProposal rejects blocks which are not above the highest signed slot of the
+// validator. The highest signed slot is only kept by the minimal slashing protection, it is also
+// checked with the full protection so that blocks signed with either protection are never signed again.
+func (v *validator) checkHighestSignedProposal(ctx context.Context, pubKey [48]byte, slot uint64) error {
+	highest, ok, err := v.db.HighestSignedProposal(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get highest signed proposal")
+	}
+	if ok && slot <= highest {
+		return errors.New(failedPreBlockSignMinimalErr)
+	}
+	return nil
+}
+
+// checkHighestSignedAttestation rejects attestations with a source below the highest signed source,
+// or with a target which is not above the highest signed target of the validator. Such attestations
+// can neither be double votes nor surround votes of the signed attestations.
+func (v *validator) checkHighestSignedAttestation(ctx context.Context, pubKey [48]byte, source uint64, target uint64) error {
+	highestSource, highestTarget, ok, err := v.db.HighestSignedAttestation(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get highest signed attestation")
+	}
+	if ok && (source < highestSource || target <= highestTarget) {
+		return fmt.Errorf(
+			"%s: source %d and target %d, highest signed source %d and target %d",
+			failedPreAttSignMinimalErr,
+			source,
+			target,
+			highestSource,
+			highestTarget,
+		)
+	}
+	return nil
+}
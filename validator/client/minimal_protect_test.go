@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func attestationWithEpochs(source uint64, target uint64) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: []byte("great block"),
+			Source:          &ethpb.Checkpoint{Epoch: source, Root: []byte("good source")},
+			Target:          &ethpb.Checkpoint{Epoch: target, Root: []byte("good target")},
+		},
+	}
+}
+
+func TestBlockSignValidation_MinimalProtection(t *testing.T) {
+	config := &featureconfig.Flags{
+		LocalProtection:           true,
+		MinimalSlashingProtection: true,
+	}
+	reset := featureconfig.InitWithReset(config)
+	defer reset()
+	validator, _, finish := setup(t)
+	defer finish()
+	ctx := context.Background()
+
+	require.NoError(t, validator.preBlockSignValidations(ctx, validatorPubKey, &ethpb.BeaconBlock{Slot: 10}))
+	require.NoError(t, validator.postBlockSignUpdate(ctx, validatorPubKey, &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 10}}))
+
+	err := validator.preBlockSignValidations(ctx, validatorPubKey, &ethpb.BeaconBlock{Slot: 10})
+	require.ErrorContains(t, failedPreBlockSignMinimalErr, err)
+	err = validator.preBlockSignValidations(ctx, validatorPubKey, &ethpb.BeaconBlock{Slot: 9})
+	require.ErrorContains(t, failedPreBlockSignMinimalErr, err)
+	require.NoError(t, validator.preBlockSignValidations(ctx, validatorPubKey, &ethpb.BeaconBlock{Slot: 11}))
+}
+
+func TestAttSignValidation_MinimalProtection(t *testing.T) {
+	config := &featureconfig.Flags{
+		LocalProtection:           true,
+		MinimalSlashingProtection: true,
+	}
+	reset := featureconfig.InitWithReset(config)
+	defer reset()
+	validator, _, finish := setup(t)
+	defer finish()
+	ctx := context.Background()
+
+	require.NoError(t, validator.postAttSignUpdate(ctx, attestationWithEpochs(4, 10), validatorPubKey))
+
+	tests := []struct {
+		source uint64
+		target uint64
+		err    bool
+	}{
+		{source: 4, target: 10, err: true}, // Double vote.
+		{source: 3, target: 11, err: true}, // Surrounding vote.
+		{source: 5, target: 9, err: true},  // Surrounded vote.
+		{source: 4, target: 11, err: false},
+		{source: 10, target: 12, err: false},
+	}
+	for _, tt := range tests {
+		err := validator.preAttSignValidations(ctx, attestationWithEpochs(tt.source, tt.target), validatorPubKey)
+		if tt.err {
+			require.ErrorContains(t, failedPreAttSignMinimalErr, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestBlockSignValidation_FullProtectionChecksHighestSigned(t *testing.T) {
+	config := &featureconfig.Flags{
+		LocalProtection: true,
+	}
+	reset := featureconfig.InitWithReset(config)
+	defer reset()
+	validator, _, finish := setup(t)
+	defer finish()
+	ctx := context.Background()
+
+	// Blocks signed with the minimal protection are not signed again with the full protection.
+	require.NoError(t, validator.db.SaveHighestSignedProposal(ctx, validatorPubKey, 10))
+	err := validator.preBlockSignValidations(ctx, validatorPubKey, &ethpb.BeaconBlock{Slot: 10})
+	require.ErrorContains(t, failedPreBlockSignMinimalErr, err)
+}
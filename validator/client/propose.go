@@ -51,7 +51,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot uint64, pubKey [48]by
 	b, err := v.validatorClient.GetBlock(ctx, &ethpb.BlockRequest{
 		Slot:         slot,
 		RandaoReveal: randaoReveal,
-		Graffiti:     v.graffiti,
+		Graffiti:     v.getGraffiti(pubKey),
 	})
 	if err != nil {
 		log.WithField("blockSlot", slot).WithError(err).Error("Failed to request block from beacon node")
@@ -203,3 +203,25 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch uint64
 	}
 	return sig.Marshal(), nil
 }
+
+// getGraffiti returns the graffiti of the next block proposed by the validator, which is taken from
+// the graffiti file if it has graffiti for the validator, and from the graffiti flag otherwise.
+func (v *validator) getGraffiti(pubKey [48]byte) []byte {
+	if v.graffitiProvider == nil {
+		return v.graffiti
+	}
+	// The validator index is only used by the templates of the graffiti file.
+	var index uint64
+	if duty, err := v.duty(pubKey); err == nil {
+		index = duty.ValidatorIndex
+	}
+	g, err := v.graffitiProvider.Graffiti(pubKey, index)
+	if err != nil {
+		log.WithError(err).Error("Could not get graffiti from graffiti file, using the graffiti flag")
+		return v.graffiti
+	}
+	if g == nil {
+		return v.graffiti
+	}
+	return g
+}
@@ -20,6 +20,15 @@ func (v *validator) preBlockSignValidations(ctx context.Context, pubKey [48]byte
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	epoch := helpers.SlotToEpoch(block.Slot)
 	if featureconfig.Get().LocalProtection {
+		if err := v.checkHighestSignedProposal(ctx, pubKey, block.Slot); err != nil {
+			if v.emitAccountMetrics {
+				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return err
+		}
+	}
+
+	if featureconfig.Get().LocalProtection && !featureconfig.Get().MinimalSlashingProtection {
 		slotBits, err := v.db.ProposalHistoryForEpoch(ctx, pubKey[:], epoch)
 		if err != nil {
 			if v.emitAccountMetrics {
@@ -69,7 +78,14 @@ func (v *validator) postBlockSignUpdate(ctx context.Context, pubKey [48]byte, bl
 		}
 	}
 
-	if featureconfig.Get().LocalProtection {
+	if featureconfig.Get().LocalProtection && featureconfig.Get().MinimalSlashingProtection {
+		if err := v.db.SaveHighestSignedProposal(ctx, pubKey, block.Block.Slot); err != nil {
+			if v.emitAccountMetrics {
+				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return errors.Wrap(err, "failed to save highest signed proposal")
+		}
+	} else if featureconfig.Get().LocalProtection {
 		slotBits, err := v.db.ProposalHistoryForEpoch(ctx, pubKey[:], epoch)
 		if err != nil {
 			if v.emitAccountMetrics {
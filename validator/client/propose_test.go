@@ -3,6 +3,10 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -16,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	testing2 "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -360,3 +365,30 @@ func TestProposeBlock_BroadcastsBlock_WithGraffiti(t *testing.T) {
 	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
 	assert.Equal(t, string(validator.graffiti), string(sentBlock.Block.Body.Graffiti))
 }
+
+func TestGetGraffiti_FromGraffitiFile(t *testing.T) {
+	validator, _, finish := setup(t)
+	defer finish()
+	validator.graffiti = []byte("flag")
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: validatorPubKey[:], ValidatorIndex: 5},
+		},
+	}
+
+	dir, err := ioutil.TempDir(testutil.TempDir(), "graffiti")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "graffiti.yaml")
+	content := fmt.Sprintf("validators:\n  \"%#x\": [\"first {{.Index}}\", \"second\"]\n", validatorPubKey)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	validator.graffitiProvider, err = graffiti.NewProvider(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "first 5", string(validator.getGraffiti(validatorPubKey)))
+	assert.Equal(t, "second", string(validator.getGraffiti(validatorPubKey)))
+	// Validators without graffiti in the file use the graffiti flag.
+	assert.Equal(t, "flag", string(validator.getGraffiti([48]byte{1})))
+}
@@ -22,6 +22,7 @@ type Validator interface {
 	WaitForSync(ctx context.Context) error
 	WaitForSynced(ctx context.Context) error
 	WaitForActivation(ctx context.Context) error
+	CheckDoppelganger(ctx context.Context) error
 	SlasherReady(ctx context.Context) error
 	CanonicalHeadSlot(ctx context.Context) (uint64, error)
 	NextSlot() <-chan uint64
@@ -44,10 +45,11 @@ type Validator interface {
 // Order of operations:
 // 1 - Initialize validator data
 // 2 - Wait for validator activation
-// 3 - Wait for the next slot start
-// 4 - Update assignments
-// 5 - Determine role at current slot
-// 6 - Perform assigned role, if any
+// 3 - Watch for other instances of the validator keys, if enabled
+// 4 - Wait for the next slot start
+// 5 - Update assignments
+// 6 - Determine role at current slot
+// 7 - Perform assigned role, if any
 func run(ctx context.Context, v Validator) {
 	defer v.Done()
 	if featureconfig.Get().SlasherProtection {
@@ -70,6 +72,9 @@ func run(ctx context.Context, v Validator) {
 	if err := v.WaitForActivation(ctx); err != nil {
 		log.Fatalf("Could not wait for validator activation: %v", err)
 	}
+	if err := v.CheckDoppelganger(ctx); err != nil {
+		log.Fatalf("Refusing to sign, doppelganger detection failed: %v", err)
+	}
 	headSlot, err := v.CanonicalHeadSlot(ctx)
 	if err != nil {
 		log.Fatalf("Could not get current canonical head slot: %v", err)
@@ -104,7 +109,7 @@ func run(ctx context.Context, v Validator) {
 				continue
 			}
 
-			if featureconfig.Get().LocalProtection {
+			if featureconfig.Get().LocalProtection && !featureconfig.Get().MinimalSlashingProtection {
 				if err := v.UpdateProtections(ctx, slot); err != nil {
 					log.WithError(err).Error("Could not update validator protection")
 					continue
@@ -147,7 +152,7 @@ func run(ctx context.Context, v Validator) {
 			go func() {
 				wg.Wait()
 				v.LogAttestationsSubmitted()
-				if featureconfig.Get().LocalProtection {
+				if featureconfig.Get().LocalProtection && !featureconfig.Get().MinimalSlashingProtection {
 					if err := v.SaveProtections(ctx); err != nil {
 						log.WithError(err).Error("Could not save validator protection")
 					}
@@ -48,6 +48,12 @@ func TestCancelledContext_WaitsForActivation(t *testing.T) {
 	assert.Equal(t, true, v.WaitForActivationCalled, "Expected WaitForActivation() to be called")
 }
 
+func TestCancelledContext_ChecksDoppelganger(t *testing.T) {
+	v := &fakeValidator{}
+	run(cancelledContext(), v)
+	assert.Equal(t, true, v.CheckDoppelgangerCalled, "Expected CheckDoppelganger() to be called")
+}
+
 func TestCancelledContext_ChecksSlasherReady(t *testing.T) {
 	v := &fakeValidator{}
 	cfg := &featureconfig.Flags{
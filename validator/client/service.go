@@ -12,7 +12,6 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
-	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/bls"
@@ -21,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	keymanager "github.com/prysmaticlabs/prysm/validator/keymanager/v1"
 	v2 "github.com/prysmaticlabs/prysm/validator/keymanager/v2"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
@@ -40,7 +40,8 @@ type ValidatorService struct {
 	cancel               context.CancelFunc
 	validator            Validator
 	graffiti             []byte
-	conn                 *grpc.ClientConn
+	graffitiFile         string
+	conns                []*grpc.ClientConn
 	endpoint             string
 	withCert             string
 	dataDir              string
@@ -54,6 +55,7 @@ type ValidatorService struct {
 	grpcRetryDelay       time.Duration
 	grpcHeaders          []string
 	protector            slashingprotection.Protector
+	doppelgangerEpochs   uint64
 }
 
 // Config for the validator service.
@@ -62,6 +64,7 @@ type Config struct {
 	DataDir                    string
 	CertFlag                   string
 	GraffitiFlag               string
+	GraffitiFile               string
 	ValidatingPubKeys          [][48]byte
 	KeyManager                 keymanager.KeyManager
 	KeyManagerV2               v2.IKeymanager
@@ -72,6 +75,7 @@ type Config struct {
 	GrpcRetryDelay             time.Duration
 	GrpcHeadersFlag            string
 	Protector                  slashingprotection.Protector
+	DoppelgangerEpochs         uint64
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -85,6 +89,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		withCert:             cfg.CertFlag,
 		dataDir:              cfg.DataDir,
 		graffiti:             []byte(cfg.GraffitiFlag),
+		graffitiFile:         cfg.GraffitiFile,
 		keyManager:           cfg.KeyManager,
 		keyManagerV2:         cfg.KeyManagerV2,
 		validatingPubKeys:    cfg.ValidatingPubKeys,
@@ -95,6 +100,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		grpcRetryDelay:       cfg.GrpcRetryDelay,
 		grpcHeaders:          strings.Split(cfg.GrpcHeadersFlag, ","),
 		protector:            cfg.Protector,
+		doppelgangerEpochs:   cfg.DoppelgangerEpochs,
 	}, nil
 }
 
@@ -117,10 +123,17 @@ func (v *ValidatorService) Start() {
 	if dialOpts == nil {
 		return
 	}
-	conn, err := grpc.DialContext(v.ctx, v.endpoint, dialOpts...)
-	if err != nil {
-		log.Errorf("Could not dial endpoint: %s, %v", v.endpoint, err)
-		return
+	// The endpoint is a comma separated list of beacon nodes to fail over between.
+	endpoints := strings.Split(v.endpoint, ",")
+	conns := make([]*grpc.ClientConn, 0, len(endpoints))
+	for i := range endpoints {
+		endpoints[i] = strings.TrimSpace(endpoints[i])
+		conn, err := grpc.DialContext(v.ctx, endpoints[i], dialOpts...)
+		if err != nil {
+			log.Errorf("Could not dial endpoint: %s, %v", endpoints[i], err)
+			return
+		}
+		conns = append(conns, conn)
 	}
 	if v.withCert != "" {
 		log.Info("Established secure gRPC connection")
@@ -131,8 +144,31 @@ func (v *ValidatorService) Start() {
 		log.Errorf("Could not initialize db: %v", err)
 		return
 	}
+	if featureconfig.Get().LocalProtection && featureconfig.Get().MinimalSlashingProtection {
+		if err := valDB.MigrateToMinimalProtection(v.ctx); err != nil {
+			log.Errorf("Could not migrate to minimal slashing protection: %v", err)
+			return
+		}
+	} else if featureconfig.Get().LocalProtection {
+		// The history signed from now on has to be migrated again when switching to minimal protection.
+		if err := valDB.ResetMinimalProtectionMigration(v.ctx); err != nil {
+			log.Errorf("Could not reset the migration to minimal slashing protection: %v", err)
+			return
+		}
+	}
 
-	v.conn = conn
+	var graffitiProvider *graffiti.Provider
+	if v.graffitiFile != "" {
+		graffitiProvider, err = graffiti.NewProvider(v.graffitiFile)
+		if err != nil {
+			log.Errorf("Could not load graffiti file: %v", err)
+			return
+		}
+	}
+
+	v.conns = conns
+	nodes := newBeaconNodes(endpoints, conns)
+	go nodes.run(v.ctx)
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1920, // number of keys to track.
 		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
@@ -150,12 +186,14 @@ func (v *ValidatorService) Start() {
 
 	v.validator = &validator{
 		db:                             valDB,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
-		node:                           ethpb.NewNodeClient(v.conn),
+		validatorClient:                &failoverValidatorClient{nodes: nodes},
+		beaconClient:                   &failoverBeaconChainClient{nodes: nodes},
+		node:                           &failoverNodeClient{nodes: nodes},
+		beaconNodes:                    nodes,
 		keyManager:                     v.keyManager,
 		keyManagerV2:                   v.keyManagerV2,
 		graffiti:                       v.graffiti,
+		graffitiProvider:               graffitiProvider,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		startBalances:                  make(map[[48]byte]uint64),
@@ -165,6 +203,7 @@ func (v *ValidatorService) Start() {
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
 		protector:                      v.protector,
 		voteStats:                      voteStats{startEpoch: ^uint64(0)},
+		doppelgangerEpochs:             v.doppelgangerEpochs,
 	}
 	go run(v.ctx, v.validator)
 }
@@ -173,17 +212,20 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.Close()
+	var err error
+	for _, conn := range v.conns {
+		if closeErr := conn.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
-	return nil
+	return err
 }
 
 // Status ...
 //
 // WIP - not done.
 func (v *ValidatorService) Status() error {
-	if v.conn == nil {
+	if len(v.conns) == 0 {
 		return errors.New("no connection to beacon RPC")
 	}
 	return nil
@@ -27,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	vdb "github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	keymanager "github.com/prysmaticlabs/prysm/validator/keymanager/v1"
 	v2keymanager "github.com/prysmaticlabs/prysm/validator/keymanager/v2"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
@@ -51,6 +52,7 @@ type validator struct {
 	validatorClient                    ethpb.BeaconNodeValidatorClient
 	beaconClient                       ethpb.BeaconChainClient
 	graffiti                           []byte
+	graffitiProvider                   *graffiti.Provider
 	node                               ethpb.NodeClient
 	keyManager                         keymanager.KeyManager
 	keyManagerV2                       v2keymanager.IKeymanager
@@ -68,6 +70,9 @@ type validator struct {
 	attesterHistoryByPubKey            map[[48]byte]*slashpb.AttestationHistory
 	attesterHistoryByPubKeyLock        sync.RWMutex
 	protector                          slashingprotection.Protector
+	doppelgangerEpochs                 uint64
+	beaconNodes                        *beaconNodes
+	dutiesEndpoint                     string
 }
 
 // Done cleans up the validator.
@@ -100,6 +105,10 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 		v.genesisTime = chainStartRes.GenesisTime
 	}
 
+	if err := v.saveGenesisValidatorsRoot(ctx); err != nil {
+		return err
+	}
+
 	// Once the ChainStart log is received, we update the genesis time of the validator client
 	// and begin a slot ticker used to track the current slot the beacon node is in.
 	v.ticker = slotutil.GetSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
@@ -107,6 +116,23 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 	return nil
 }
 
+// saveGenesisValidatorsRoot saves the genesis validators root of the chain of the beacon node to the
+// slashing protection database. The validator refuses to start if the database holds the slashing
+// protection data of another chain, such as data imported from an interchange file of another network.
+func (v *validator) saveGenesisValidatorsRoot(ctx context.Context) error {
+	if v.db == nil {
+		return nil
+	}
+	genesis, err := v.node.GetGenesis(ctx, &ptypes.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis of the beacon node")
+	}
+	if err := v.db.SaveGenesisValidatorsRoot(ctx, genesis.GenesisValidatorsRoot); err != nil {
+		return errors.Wrap(err, "slashing protection data is of another chain than the beacon node")
+	}
+	return nil
+}
+
 // WaitForSync checks whether the beacon node has sync to the latest head.
 func (v *validator) WaitForSync(ctx context.Context) error {
 	ctx, span := trace.StartSpan(ctx, "validator.WaitForSync")
@@ -321,7 +347,10 @@ func (v *validator) SlotDeadline(slot uint64) time.Time {
 // list of upcoming assignments needs to be updated. For example, at the
 // beginning of a new epoch.
 func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
-	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil {
+	// Duties are fetched again after failing over to another beacon node, so that it subscribes
+	// to the subnets of the validators.
+	endpoint := v.beaconEndpoint()
+	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil && v.dutiesEndpoint == endpoint {
 		// Do nothing if not epoch start AND assignments already exist.
 		return nil
 	}
@@ -355,6 +384,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	}
 
 	v.duties = resp
+	v.dutiesEndpoint = endpoint
 	v.logDuties(slot, v.duties.Duties)
 	subscribeSlots := make([]uint64, 0, len(validatingKeys))
 	subscribeCommitteeIDs := make([]uint64, 0, len(validatingKeys))
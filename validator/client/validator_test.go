@@ -131,6 +131,47 @@ func TestWaitForChainStart_StreamSetupFails(t *testing.T) {
 	assert.ErrorContains(t, want, err)
 }
 
+func TestWaitForChainStart_GenesisValidatorsRootMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	n := mock.NewMockNodeClient(ctrl)
+	db := dbTest.SetupDB(t, [][48]byte{})
+
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: client,
+		node:            n,
+		db:              db,
+	}
+	clientStream := mock.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
+	client.EXPECT().WaitForChainStart(
+		gomock.Any(),
+		&ptypes.Empty{},
+	).Return(clientStream, nil).Times(2)
+	clientStream.EXPECT().Recv().Return(
+		&ethpb.ChainStartResponse{
+			Started:     true,
+			GenesisTime: uint64(time.Unix(1, 0).Unix()),
+		},
+		nil,
+	).Times(2)
+
+	// The genesis validators root of the beacon node is saved on the first start.
+	root := bytesutil.PadTo([]byte("root"), 32)
+	n.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{GenesisValidatorsRoot: root}, nil)
+	require.NoError(t, v.WaitForChainStart(context.Background()))
+	saved, err := db.GenesisValidatorsRoot(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, root, saved)
+
+	// A beacon node of another chain is refused.
+	otherRoot := bytesutil.PadTo([]byte("other root"), 32)
+	n.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{GenesisValidatorsRoot: otherRoot}, nil)
+	err = v.WaitForChainStart(context.Background())
+	assert.ErrorContains(t, "slashing protection data is of another chain than the beacon node", err)
+}
+
 func TestWaitForChainStart_ReceiveErrorFromStream(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -14,10 +14,22 @@ type ValidatorDB interface {
 	io.Closer
 	DatabasePath() string
 	ClearDB() error
+	ProtectedPublicKeys(ctx context.Context) ([][48]byte, error)
+	// Genesis related methods.
+	GenesisValidatorsRoot(ctx context.Context) ([]byte, error)
+	SaveGenesisValidatorsRoot(ctx context.Context, root []byte) error
 	// Proposer protection related methods.
 	ProposalHistoryForEpoch(ctx context.Context, publicKey []byte, epoch uint64) (bitfield.Bitlist, error)
 	SaveProposalHistoryForEpoch(ctx context.Context, publicKey []byte, epoch uint64, history bitfield.Bitlist) error
+	ProposedSlots(ctx context.Context, publicKey []byte) ([]uint64, error)
 	// Attester protection related methods.
 	AttestationHistoryForPubKeys(ctx context.Context, publicKeys [][48]byte) (map[[48]byte]*slashpb.AttestationHistory, error)
 	SaveAttestationHistoryForPubKeys(ctx context.Context, historyByPubKey map[[48]byte]*slashpb.AttestationHistory) error
+	// Minimal slashing protection related methods.
+	HighestSignedProposal(ctx context.Context, pubKey [48]byte) (uint64, bool, error)
+	SaveHighestSignedProposal(ctx context.Context, pubKey [48]byte, slot uint64) error
+	HighestSignedAttestation(ctx context.Context, pubKey [48]byte) (uint64, uint64, bool, error)
+	SaveHighestSignedAttestation(ctx context.Context, pubKey [48]byte, source uint64, target uint64) error
+	MigrateToMinimalProtection(ctx context.Context) error
+	ResetMinimalProtectionMigration(ctx context.Context) error
 }
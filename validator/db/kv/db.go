@@ -2,12 +2,16 @@
 package kv
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
 )
 
 var databaseFileName = "validator.db"
@@ -76,6 +80,10 @@ func NewKVStore(dirPath string, pubKeys [][48]byte) (*Store, error) {
 			tx,
 			historicProposalsBucket,
 			historicAttestationsBucket,
+			genesisInfoBucket,
+			highestSignedProposalBucket,
+			highestSignedAttestationBucket,
+			migrationsBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -115,3 +123,46 @@ func (store *Store) Size() (int64, error) {
 	})
 	return size, err
 }
+
+// ProtectedPublicKeys returns the validator public keys which have slashing protection data in the
+// database, in ascending order.
+func (store *Store) ProtectedPublicKeys(ctx context.Context) ([][48]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.ProtectedPublicKeys")
+	defer span.End()
+
+	seen := make(map[[48]byte]bool)
+	var pubKeys [][48]byte
+	err := store.view(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{
+			historicProposalsBucket,
+			historicAttestationsBucket,
+			highestSignedProposalBucket,
+			highestSignedAttestationBucket,
+		} {
+			bucket := tx.Bucket(name)
+			// Databases opened without creating the buckets may not have the minimal protection buckets.
+			if bucket == nil {
+				continue
+			}
+			if err := bucket.ForEach(func(k, _ []byte) error {
+				if len(k) != 48 {
+					return nil
+				}
+				var pubKey [48]byte
+				copy(pubKey[:], k)
+				if !seen[pubKey] {
+					seen[pubKey] = true
+					pubKeys = append(pubKeys, pubKey)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	return pubKeys, err
+}
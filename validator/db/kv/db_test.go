@@ -1,11 +1,13 @@
 package kv
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/rand"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
@@ -35,3 +37,18 @@ func tempdir() string {
 	}
 	return d
 }
+
+func TestStore_ProtectedPublicKeys(t *testing.T) {
+	ctx := context.Background()
+	// Public keys which are only initialized or only have an attestation history.
+	db := setupDB(t, [][48]byte{{3}, {1}})
+	history := map[[48]byte]*slashpb.AttestationHistory{
+		{2}: {TargetToSource: map[uint64]uint64{1: 0}, LatestEpochWritten: 1},
+		{1}: {TargetToSource: map[uint64]uint64{1: 0}, LatestEpochWritten: 1},
+	}
+	require.NoError(t, db.SaveAttestationHistoryForPubKeys(ctx, history))
+
+	pubKeys, err := db.ProtectedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][48]byte{{1}, {2}, {3}}, pubKeys)
+}
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// GenesisValidatorsRoot returns the genesis validators root of the chain of the slashing protection
+// data. Returns nil if the genesis validators root is unknown.
+func (store *Store) GenesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.GenesisValidatorsRoot")
+	defer span.End()
+
+	var root []byte
+	err := store.view(func(tx *bolt.Tx) error {
+		// Databases opened without creating the buckets may not have the genesis bucket.
+		bucket := tx.Bucket(genesisInfoBucket)
+		if bucket == nil {
+			return nil
+		}
+		enc := bucket.Get(genesisValidatorsRootKey)
+		if enc != nil {
+			root = make([]byte, len(enc))
+			copy(root, enc)
+		}
+		return nil
+	})
+	return root, err
+}
+
+// SaveGenesisValidatorsRoot saves the genesis validators root of the chain of the slashing protection
+// data. Saving a root which differs from the saved one fails, as the slashing protection data of
+// different chains must not be mixed.
+func (store *Store) SaveGenesisValidatorsRoot(ctx context.Context, root []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveGenesisValidatorsRoot")
+	defer span.End()
+
+	return store.update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(genesisInfoBucket)
+		if err != nil {
+			return err
+		}
+		saved := bucket.Get(genesisValidatorsRootKey)
+		if saved != nil {
+			if !bytes.Equal(saved, root) {
+				return fmt.Errorf("genesis validators root %#x differs from the saved root %#x", root, saved)
+			}
+			return nil
+		}
+		return bucket.Put(genesisValidatorsRootKey, root)
+	})
+}
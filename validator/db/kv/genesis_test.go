@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGenesisValidatorsRoot_Unknown(t *testing.T) {
+	db := setupDB(t, [][48]byte{})
+
+	root, err := db.GenesisValidatorsRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, true, root == nil, "Expected an unknown genesis validators root")
+}
+
+func TestSaveGenesisValidatorsRoot(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][48]byte{})
+	root := [32]byte{1, 2, 3}
+
+	require.NoError(t, db.SaveGenesisValidatorsRoot(ctx, root[:]))
+	saved, err := db.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, root[:], saved)
+
+	// Saving the same root again is fine, a different root is rejected.
+	require.NoError(t, db.SaveGenesisValidatorsRoot(ctx, root[:]))
+	other := [32]byte{4, 5, 6}
+	err = db.SaveGenesisValidatorsRoot(ctx, other[:])
+	require.ErrorContains(t, "differs from the saved root", err)
+	saved, err = db.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, root[:], saved)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -61,6 +62,48 @@ func (store *Store) SaveProposalHistoryForEpoch(ctx context.Context, pubKey []by
 	return err
 }
 
+// ProposedSlots returns the slots of the blocks proposed by the requested validator public key, in
+// ascending order, as recorded in its proposal history.
+func (store *Store) ProposedSlots(ctx context.Context, publicKey []byte) ([]uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.ProposedSlots")
+	defer span.End()
+
+	var slots []uint64
+	err := store.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historicProposalsBucket)
+		valBucket := bucket.Bucket(publicKey)
+		if valBucket == nil {
+			return nil
+		}
+		var err error
+		slots, err = proposedSlots(valBucket)
+		return err
+	})
+	return slots, err
+}
+
+// proposedSlots returns the slots marked in the proposal history bucket of a validator, in ascending order.
+func proposedSlots(valBucket *bolt.Bucket) ([]uint64, error) {
+	var slots []uint64
+	if err := valBucket.ForEach(func(k, v []byte) error {
+		epoch := binary.LittleEndian.Uint64(k)
+		slotBits := bitfield.Bitlist(v)
+		for i := uint64(0); i < slotBits.Len(); i++ {
+			if slotBits.BitAt(i) {
+				slots = append(slots, epoch*params.BeaconConfig().SlotsPerEpoch+i)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// Epochs are keyed in little endian, which does not sort them.
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] < slots[j]
+	})
+	return slots, nil
+}
+
 func pruneProposalHistory(valBucket *bolt.Bucket, newestEpoch uint64) error {
 	c := valBucket.Cursor()
 	for k, _ := c.First(); k != nil; k, _ = c.First() {
@@ -194,3 +194,31 @@ func TestPruneProposalHistory_OK(t *testing.T) {
 		}
 	}
 }
+
+func TestProposedSlots(t *testing.T) {
+	ctx := context.Background()
+	pubkey := [48]byte{7}
+	db := setupDB(t, [][48]byte{pubkey})
+
+	slots, err := db.ProposedSlots(ctx, pubkey[:])
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slots))
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	want := []uint64{3, slotsPerEpoch + 1, slotsPerEpoch + 5, 300 * slotsPerEpoch}
+	for _, slot := range want {
+		epoch := helpers.SlotToEpoch(slot)
+		slotBits, err := db.ProposalHistoryForEpoch(ctx, pubkey[:], epoch)
+		require.NoError(t, err)
+		slotBits.SetBitAt(slot%slotsPerEpoch, true)
+		require.NoError(t, db.SaveProposalHistoryForEpoch(ctx, pubkey[:], epoch, slotBits))
+	}
+	slots, err = db.ProposedSlots(ctx, pubkey[:])
+	require.NoError(t, err)
+	require.DeepEqual(t, want, slots)
+
+	// Unknown public keys have no proposals.
+	slots, err = db.ProposedSlots(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slots))
+}
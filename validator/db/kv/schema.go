@@ -5,4 +5,16 @@ var (
 	historicProposalsBucket = []byte("proposal-history-bucket")
 	// Validator slashing protection from slashable attestations.
 	historicAttestationsBucket = []byte("attestation-history-bucket")
+	// Chain of the slashing protection data.
+	genesisInfoBucket = []byte("genesis-info-bucket")
+	// Minimal slashing protection, the highest signed slot and epochs of validators.
+	highestSignedProposalBucket    = []byte("highest-signed-proposal-bucket")
+	highestSignedAttestationBucket = []byte("highest-signed-attestation-bucket")
+	// Completed database migrations.
+	migrationsBucket = []byte("migrations-bucket")
+)
+
+var (
+	genesisValidatorsRootKey      = []byte("genesis-validators-root")
+	minimalProtectionMigrationKey = []byte("minimal-protection")
 )
@@ -0,0 +1,192 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// HighestSignedProposal returns the slot of the highest block signed by the validator public key.
+// Returns false if no block has been signed.
+func (store *Store) HighestSignedProposal(ctx context.Context, pubKey [48]byte) (uint64, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.HighestSignedProposal")
+	defer span.End()
+
+	var slot uint64
+	var exists bool
+	err := store.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(highestSignedProposalBucket)
+		// Databases opened without creating the buckets may not have the minimal protection buckets.
+		if bucket == nil {
+			return nil
+		}
+		enc := bucket.Get(pubKey[:])
+		if len(enc) == 8 {
+			slot = binary.LittleEndian.Uint64(enc)
+			exists = true
+		}
+		return nil
+	})
+	return slot, exists, err
+}
+
+// SaveHighestSignedProposal raises the highest signed slot of the validator public key to the given
+// slot. A lower slot leaves it unchanged.
+func (store *Store) SaveHighestSignedProposal(ctx context.Context, pubKey [48]byte, slot uint64) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveHighestSignedProposal")
+	defer span.End()
+
+	// Batched, as the blocks of many validators may be signed concurrently.
+	return store.db.Batch(func(tx *bolt.Tx) error {
+		return raiseHighestSignedProposal(tx.Bucket(highestSignedProposalBucket), pubKey[:], slot)
+	})
+}
+
+// HighestSignedAttestation returns the highest source and target epochs of the attestations signed
+// by the validator public key, which may belong to different attestations. Returns false if no
+// attestation has been signed.
+func (store *Store) HighestSignedAttestation(ctx context.Context, pubKey [48]byte) (uint64, uint64, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.HighestSignedAttestation")
+	defer span.End()
+
+	var source, target uint64
+	var exists bool
+	err := store.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(highestSignedAttestationBucket)
+		if bucket == nil {
+			return nil
+		}
+		enc := bucket.Get(pubKey[:])
+		if len(enc) == 16 {
+			source = binary.LittleEndian.Uint64(enc[:8])
+			target = binary.LittleEndian.Uint64(enc[8:])
+			exists = true
+		}
+		return nil
+	})
+	return source, target, exists, err
+}
+
+// SaveHighestSignedAttestation raises the highest signed source and target epochs of the validator
+// public key to the given epochs. Lower epochs leave them unchanged.
+func (store *Store) SaveHighestSignedAttestation(ctx context.Context, pubKey [48]byte, source uint64, target uint64) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveHighestSignedAttestation")
+	defer span.End()
+
+	// Batched, as the attestations of many validators are signed concurrently.
+	return store.db.Batch(func(tx *bolt.Tx) error {
+		return raiseHighestSignedAttestation(tx.Bucket(highestSignedAttestationBucket), pubKey[:], source, target)
+	})
+}
+
+// MigrateToMinimalProtection sets the highest signed slot and epochs of every validator from its
+// proposal and attestation history. The migration only runs once, until it is reset.
+func (store *Store) MigrateToMinimalProtection(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.MigrateToMinimalProtection")
+	defer span.End()
+
+	return store.update(func(tx *bolt.Tx) error {
+		migrations := tx.Bucket(migrationsBucket)
+		if migrations.Get(minimalProtectionMigrationKey) != nil {
+			return nil
+		}
+		proposals := tx.Bucket(historicProposalsBucket)
+		if err := proposals.ForEach(func(pubKey, _ []byte) error {
+			valBucket := proposals.Bucket(pubKey)
+			if valBucket == nil {
+				return nil
+			}
+			slots, err := proposedSlots(valBucket)
+			if err != nil {
+				return err
+			}
+			if len(slots) == 0 {
+				return nil
+			}
+			return raiseHighestSignedProposal(tx.Bucket(highestSignedProposalBucket), pubKey, slots[len(slots)-1])
+		}); err != nil {
+			return errors.Wrap(err, "could not migrate proposal history")
+		}
+		if err := tx.Bucket(historicAttestationsBucket).ForEach(func(pubKey, enc []byte) error {
+			history := &slashpb.AttestationHistory{}
+			if err := proto.Unmarshal(enc, history); err != nil {
+				return errors.Wrapf(err, "could not unmarshal attestation history of public key %#x", pubKey)
+			}
+			source, target, ok := highestAttestedEpochs(history)
+			if !ok {
+				return nil
+			}
+			return raiseHighestSignedAttestation(tx.Bucket(highestSignedAttestationBucket), pubKey, source, target)
+		}); err != nil {
+			return errors.Wrap(err, "could not migrate attestation history")
+		}
+		return migrations.Put(minimalProtectionMigrationKey, []byte{1})
+	})
+}
+
+// ResetMinimalProtectionMigration allows the migration to minimal protection to run again, so that the
+// history signed with full protection in the meantime is migrated as well.
+func (store *Store) ResetMinimalProtectionMigration(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.ResetMinimalProtectionMigration")
+	defer span.End()
+
+	return store.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Delete(minimalProtectionMigrationKey)
+	})
+}
+
+func raiseHighestSignedProposal(bucket *bolt.Bucket, pubKey []byte, slot uint64) error {
+	enc := bucket.Get(pubKey)
+	if len(enc) == 8 && binary.LittleEndian.Uint64(enc) >= slot {
+		return nil
+	}
+	newEnc := make([]byte, 8)
+	binary.LittleEndian.PutUint64(newEnc, slot)
+	return bucket.Put(pubKey, newEnc)
+}
+
+func raiseHighestSignedAttestation(bucket *bolt.Bucket, pubKey []byte, source uint64, target uint64) error {
+	enc := bucket.Get(pubKey)
+	if len(enc) == 16 {
+		if highest := binary.LittleEndian.Uint64(enc[:8]); highest > source {
+			source = highest
+		}
+		if highest := binary.LittleEndian.Uint64(enc[8:]); highest > target {
+			target = highest
+		}
+	}
+	newEnc := make([]byte, 16)
+	binary.LittleEndian.PutUint64(newEnc[:8], source)
+	binary.LittleEndian.PutUint64(newEnc[8:], target)
+	return bucket.Put(pubKey, newEnc)
+}
+
+// highestAttestedEpochs returns the highest source and target epochs of the attestations in the
+// history, which keeps the targets of the last weak subjectivity period.
+func highestAttestedEpochs(history *slashpb.AttestationHistory) (uint64, uint64, bool) {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	lowest := uint64(0)
+	if history.LatestEpochWritten >= wsPeriod {
+		lowest = history.LatestEpochWritten - wsPeriod + 1
+	}
+	var source, target uint64
+	var exists bool
+	for i := lowest; i <= history.LatestEpochWritten; i++ {
+		s, ok := history.TargetToSource[i%wsPeriod]
+		if !ok || s == params.BeaconConfig().FarFutureEpoch {
+			continue
+		}
+		if s > source {
+			source = s
+		}
+		target = i
+		exists = true
+	}
+	return source, target, exists
+}
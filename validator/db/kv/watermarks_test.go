@@ -0,0 +1,93 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_HighestSignedProposal(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	db := setupDB(t, [][48]byte{pubKey})
+
+	_, ok, err := db.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, false, ok)
+
+	require.NoError(t, db.SaveHighestSignedProposal(ctx, pubKey, 5))
+	// A lower slot does not lower the highest signed slot.
+	require.NoError(t, db.SaveHighestSignedProposal(ctx, pubKey, 3))
+	slot, ok, err := db.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(5), slot)
+}
+
+func TestStore_HighestSignedAttestation(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	db := setupDB(t, [][48]byte{pubKey})
+
+	_, _, ok, err := db.HighestSignedAttestation(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, false, ok)
+
+	// The source and target epochs are raised separately.
+	require.NoError(t, db.SaveHighestSignedAttestation(ctx, pubKey, 2, 5))
+	require.NoError(t, db.SaveHighestSignedAttestation(ctx, pubKey, 3, 4))
+	source, target, ok, err := db.HighestSignedAttestation(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(3), source)
+	assert.Equal(t, uint64(5), target)
+}
+
+func TestStore_MigrateToMinimalProtection(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	db := setupDB(t, [][48]byte{pubKey})
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	slotBits, err := db.ProposalHistoryForEpoch(ctx, pubKey[:], 2)
+	require.NoError(t, err)
+	slotBits.SetBitAt(1, true)
+	require.NoError(t, db.SaveProposalHistoryForEpoch(ctx, pubKey[:], 2, slotBits))
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: farFuture, 1: 0, 2: farFuture, 3: 1},
+		LatestEpochWritten: 3,
+	}
+	require.NoError(t, db.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}))
+
+	require.NoError(t, db.MigrateToMinimalProtection(ctx))
+	slot, ok, err := db.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 2*params.BeaconConfig().SlotsPerEpoch+1, slot)
+	source, target, ok, err := db.HighestSignedAttestation(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(1), source)
+	assert.Equal(t, uint64(3), target)
+
+	// The migration only runs once.
+	history.TargetToSource[4] = 3
+	history.LatestEpochWritten = 4
+	require.NoError(t, db.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}))
+	require.NoError(t, db.MigrateToMinimalProtection(ctx))
+	_, target, _, err = db.HighestSignedAttestation(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), target)
+
+	// Until it is reset.
+	require.NoError(t, db.ResetMinimalProtectionMigration(ctx))
+	require.NoError(t, db.MigrateToMinimalProtection(ctx))
+	source, target, _, err = db.HighestSignedAttestation(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), source)
+	assert.Equal(t, uint64(4), target)
+}
@@ -30,10 +30,11 @@ var (
 			"of validating keys may wish to disable granular prometheus metrics as it increases " +
 			"the data cardinality.",
 	}
-	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
+	// BeaconRPCProviderFlag defines the beacon node RPC endpoints.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. A comma separated list of endpoints makes the validator " +
+			"use the synced beacon node with the highest head, and fail over to another one when it becomes unhealthy",
 		Value: "127.0.0.1:4000",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -62,6 +63,14 @@ var (
 		Name:  "graffiti",
 		Usage: "String to include in proposed blocks",
 	}
+	// GraffitiFileFlag defines the path of a YAML file with the graffiti of each validator.
+	GraffitiFileFlag = &cli.StringFlag{
+		Name: "graffiti-file",
+		Usage: "Path to a YAML file mapping validator public keys, or a default, to the graffiti of their proposed " +
+			"blocks. Lists of graffiti rotate on each proposal, {{.Index}} and {{.Version}} are replaced by the " +
+			"validator index and client version, and the file is reloaded when it changes. Validators without " +
+			"graffiti in the file use --graffiti",
+	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = &cli.UintFlag{
 		Name:  "grpc-retries",
@@ -217,6 +226,28 @@ var (
 		Usage: "Kind of keymanager, either direct, derived, or remote, specified during wallet creation",
 		Value: "",
 	}
+	// SlashingProtectionJSONFileFlag defines the path of a slashing protection interchange file,
+	// which is read by the import command and written by the export command.
+	SlashingProtectionJSONFileFlag = &cli.StringFlag{
+		Name:  "slashing-protection-json-file",
+		Usage: "Path to a slashing protection interchange file (EIP-3076)",
+	}
+	// GenesisValidatorsRootFlag defines the genesis validators root of the chain of the slashing
+	// protection data, as a hex string.
+	GenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "genesis-validators-root",
+		Usage: "Genesis validators root of the chain of the exported slashing protection data, needed when the validator database does not know it yet",
+	}
+	// DoppelgangerDetectionEpochsFlag defines the number of epochs to watch the beacon chain for
+	// other instances of the validating keys before signing.
+	DoppelgangerDetectionEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-detection-epochs",
+		Usage: "Number of epochs to watch the beacon chain for attestations and blocks of the validating keys " +
+			"after activation, before signing anything. The validator client exits if a key is seen live, " +
+			"as it is then likely running in another validator client. The attestations of the last epoch are " +
+			"also looked for in the blocks of the epoch after it, which delays signing by one more epoch. Set to 0 to disable",
+		Value: 0,
+	}
 )
 
 // Deprecated flags list.
@@ -0,0 +1,207 @@
+// Package graffiti provides the graffiti of the blocks proposed by each validator from a YAML file,
+// which is reloaded when it changes. The file maps validator public keys to graffiti, and may set
+// default graffiti for the other validators:
+//
+//	default: "Prysm {{.Version}}"
+//	validators:
+//	  "0xa2b5aaad...": "Customer A"
+//	  "0xb8cd9e06...": ["Customer B", "Customer B validator {{.Index}}"]
+//
+// A list of graffiti rotates on each block proposed by the validator, and an empty list uses the default
+// graffiti. Graffiti are Go templates executed with TemplateData, and are truncated to 32 bytes.
+package graffiti
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// Length of the graffiti field of a beacon block.
+const graffitiLength = 32
+
+// TemplateData is the data available to the graffiti templates.
+type TemplateData struct {
+	// Index of the proposing validator.
+	Index uint64
+	// Version of the validator client.
+	Version string
+}
+
+// Values are the graffiti of a validator, written in the file either as a single string or as a
+// list of strings.
+type Values []string
+
+// UnmarshalYAML accepts a single string as well as a list of strings.
+func (v *Values) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*v = Values{single}
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return errors.New("graffiti must be a string or a list of strings")
+	}
+	*v = list
+	return nil
+}
+
+// File is the content of a graffiti file.
+type File struct {
+	Default    Values            `yaml:"default"`
+	Validators map[string]Values `yaml:"validators"`
+}
+
+// config holds the parsed templates of a graffiti file.
+type config struct {
+	defaults   []*template.Template
+	validators map[[48]byte][]*template.Template
+}
+
+// Provider returns the graffiti of the proposed blocks from a graffiti file.
+type Provider struct {
+	path      string
+	lock      sync.Mutex
+	modTime   time.Time
+	config    *config
+	proposals map[[48]byte]uint64
+}
+
+// NewProvider loads the graffiti file at the given path.
+func NewProvider(path string) (*Provider, error) {
+	p := &Provider{
+		path:      path,
+		proposals: make(map[[48]byte]uint64),
+	}
+	if err := p.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Graffiti returns the graffiti of the next block proposed by the validator, or nil if the file has
+// neither graffiti for the validator nor default graffiti. The file is reloaded first if it changed.
+func (p *Provider) Graffiti(pubKey [48]byte, index uint64) ([]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.reloadIfChanged(); err != nil {
+		log.WithError(err).Error("Could not reload graffiti file, keeping the previous graffiti")
+	}
+	templates, ok := p.config.validators[pubKey]
+	if !ok || len(templates) == 0 {
+		templates = p.config.defaults
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	proposals := p.proposals[pubKey]
+	p.proposals[pubKey] = proposals + 1
+	return execute(templates[proposals%uint64(len(templates))], &TemplateData{
+		Index:   index,
+		Version: version.GetSemanticVersion(),
+	})
+}
+
+// reloadIfChanged loads the graffiti file if it was modified since it was last loaded.
+func (p *Provider) reloadIfChanged() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return errors.Wrap(err, "could not read graffiti file")
+	}
+	if p.config != nil && info.ModTime().Equal(p.modTime) {
+		return nil
+	}
+	// The modification time is updated even if the file is invalid, so that an invalid file is
+	// reported once per change.
+	p.modTime = info.ModTime()
+	cfg, err := loadConfig(p.path)
+	if err != nil {
+		return err
+	}
+	p.config = cfg
+	log.WithField("path", p.path).Info("Loaded graffiti file")
+	return nil
+}
+
+func loadConfig(path string) (*config, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read graffiti file")
+	}
+	file := &File{}
+	if err := yaml.UnmarshalStrict(enc, file); err != nil {
+		return nil, errors.Wrap(err, "could not parse graffiti file")
+	}
+	cfg := &config{
+		validators: make(map[[48]byte][]*template.Template, len(file.Validators)),
+	}
+	cfg.defaults, err = parseTemplates(file.Default)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid default graffiti")
+	}
+	for key, values := range file.Validators {
+		pk, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(pk) != 48 {
+			return nil, fmt.Errorf("%q is not a valid validator public key", key)
+		}
+		var pubKey [48]byte
+		copy(pubKey[:], pk)
+		cfg.validators[pubKey], err = parseTemplates(values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid graffiti of validator %s", key)
+		}
+	}
+	return cfg, nil
+}
+
+// parseTemplates parses the graffiti, which are executed once so that unknown fields are reported
+// when loading the file rather than when proposing.
+func parseTemplates(values Values) ([]*template.Template, error) {
+	templates := make([]*template.Template, len(values))
+	for i, value := range values {
+		tmpl, err := template.New("graffiti").Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := execute(tmpl, &TemplateData{}); err != nil {
+			return nil, err
+		}
+		templates[i] = tmpl
+	}
+	return templates, nil
+}
+
+func execute(tmpl *template.Template, data *TemplateData) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, errors.Wrap(err, "could not execute graffiti template")
+	}
+	graffiti := buf.Bytes()
+	if len(graffiti) > graffitiLength {
+		// Multi-byte characters are not cut in the middle.
+		n := graffitiLength
+		for n > 0 && !utf8.RuneStart(graffiti[n]) {
+			n--
+		}
+		log.WithFields(logrus.Fields{
+			"graffiti":  string(graffiti),
+			"truncated": string(graffiti[:n]),
+		}).Warn("Graffiti is longer than 32 bytes, truncating it")
+		graffiti = graffiti[:n]
+	}
+	return graffiti, nil
+}
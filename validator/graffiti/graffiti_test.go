@@ -0,0 +1,163 @@
+package graffiti
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir(testutil.TempDir(), "graffiti")
+	require.NoError(t, err)
+	return dir, func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}
+}
+
+func writeFile(t *testing.T, path string, content string, modTime time.Time) {
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	// Modification times may be too coarse to tell quick writes apart.
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestProvider_Graffiti(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	pubKey := [48]byte{1}
+	other := [48]byte{2}
+	writeFile(t, path, fmt.Sprintf(`
+default: "default"
+validators:
+  "%#x": ["first", "second {{.Index}}"]
+`, pubKey), time.Unix(1000, 0))
+
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+
+	// Graffiti lists rotate on each proposal.
+	for _, want := range []string{"first", "second 7", "first"} {
+		graffiti, err := p.Graffiti(pubKey, 7)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(graffiti))
+	}
+	graffiti, err := p.Graffiti(other, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "default", string(graffiti))
+}
+
+func TestProvider_Truncates(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	writeFile(t, path, `default: "this graffiti is longer than thirty two bytes"`, time.Unix(1000, 0))
+
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+	graffiti, err := p.Graffiti([48]byte{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "this graffiti is longer than thi", string(graffiti))
+}
+
+func TestProvider_TruncatesOnCharacterBoundary(t *testing.T) {
+	hook := logTest.NewGlobal()
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	// The 3 byte character at bytes 30 to 32 does not fit in the graffiti.
+	writeFile(t, path, `default: "this graffiti is about thirty €"`, time.Unix(1000, 0))
+
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+	graffiti, err := p.Graffiti([48]byte{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "this graffiti is about thirty ", string(graffiti))
+	assert.Equal(t, true, utf8.Valid(graffiti))
+	testutil.AssertLogsContain(t, hook, "Graffiti is longer than 32 bytes, truncating it")
+}
+
+func TestProvider_NoGraffiti(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	writeFile(t, path, fmt.Sprintf(`
+validators:
+  "%#x": "mine"
+`, [48]byte{1}), time.Unix(1000, 0))
+
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+	graffiti, err := p.Graffiti([48]byte{2}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(graffiti))
+}
+
+func TestProvider_EmptyListUsesDefault(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	pubKey := [48]byte{1}
+	writeFile(t, path, fmt.Sprintf(`
+default: "default"
+validators:
+  "%#x": []
+`, pubKey), time.Unix(1000, 0))
+
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+	graffiti, err := p.Graffiti(pubKey, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "default", string(graffiti))
+}
+
+func TestProvider_Reload(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "graffiti.yaml")
+	writeFile(t, path, `default: "before"`, time.Unix(1000, 0))
+	p, err := NewProvider(path)
+	require.NoError(t, err)
+
+	writeFile(t, path, `default: "after {{.Version}}"`, time.Unix(2000, 0))
+	graffiti, err := p.Graffiti([48]byte{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "after Unknown", string(graffiti))
+
+	// Invalid changes keep the previous graffiti.
+	writeFile(t, path, `default: "{{.Unknown}}"`, time.Unix(3000, 0))
+	graffiti, err = p.Graffiti([48]byte{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "after Unknown", string(graffiti))
+}
+
+func TestNewProvider_InvalidFile(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	_, err := NewProvider(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, "could not read graffiti file", err)
+
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{content: `default: {a: b}`, err: "graffiti must be a string or a list of strings"},
+		{content: `unknown: "field"`, err: "could not parse graffiti file"},
+		{content: `default: "{{.Unknown}}"`, err: "invalid default graffiti"},
+		{content: "validators:\n  \"0x1234\": \"short\"", err: "is not a valid validator public key"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, "graffiti.yaml")
+		writeFile(t, path, tt.content, time.Unix(1000, 0))
+		_, err := NewProvider(path)
+		assert.ErrorContains(t, tt.err, err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/client"
 	"github.com/prysmaticlabs/prysm/validator/flags"
 	"github.com/prysmaticlabs/prysm/validator/node"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/interchange"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
@@ -52,6 +53,7 @@ var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.CertFlag,
 	flags.GraffitiFlag,
+	flags.GraffitiFileFlag,
 	flags.KeystorePathFlag,
 	flags.SourceDirectories,
 	flags.SourceDirectory,
@@ -71,6 +73,7 @@ var appFlags = []cli.Flag{
 	flags.MonitoringPortFlag,
 	flags.SlasherRPCProviderFlag,
 	flags.SlasherCertFlag,
+	flags.DoppelgangerDetectionEpochsFlag,
 	flags.DeprecatedPasswordsDirFlag,
 	flags.WalletPasswordFileFlag,
 	flags.WalletDirFlag,
@@ -111,6 +114,7 @@ func main() {
 	app.Commands = []*cli.Command{
 		v2.WalletCommands,
 		v2.AccountCommands,
+		interchange.Commands,
 		{
 			Name:     "accounts",
 			Category: "accounts",
@@ -206,10 +210,17 @@ contract in order to activate the validator client`,
 							cliCtx.Uint(flags.GrpcRetriesFlag.Name),
 							cliCtx.Duration(flags.GrpcRetryDelayFlag.Name),
 							grpc.WithBlock())
-						endpoint := cliCtx.String(flags.BeaconRPCProviderFlag.Name)
-						conn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
-						if err != nil {
+						// Any of the beacon nodes can serve the status.
+						var conn *grpc.ClientConn
+						for _, endpoint := range strings.Split(cliCtx.String(flags.BeaconRPCProviderFlag.Name), ",") {
+							endpoint = strings.TrimSpace(endpoint)
+							conn, err = grpc.DialContext(ctx, endpoint, dialOpts...)
+							if err == nil {
+								break
+							}
 							log.WithError(err).Errorf("Failed to dial beacon node endpoint at %s", endpoint)
+						}
+						if err != nil {
 							return err
 						}
 						err = v1.RunStatusCommand(pubKeys, ethpb.NewBeaconNodeValidatorClient(conn))
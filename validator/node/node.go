@@ -230,12 +230,14 @@ func (s *ValidatorClient) registerClientService(
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
 		GraffitiFlag:               graffiti,
+		GraffitiFile:               s.cliCtx.String(flags.GraffitiFileFlag.Name),
 		ValidatingPubKeys:          validatingPubKeys,
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
 		GrpcHeadersFlag:            s.cliCtx.String(flags.GrpcHeadersFlag.Name),
 		Protector:                  protector,
+		DoppelgangerEpochs:         s.cliCtx.Uint64(flags.DoppelgangerDetectionEpochsFlag.Name),
 	})
 
 	if err != nil {
@@ -0,0 +1,152 @@
+package interchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/flags"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands for importing and exporting the slashing protection data of the validator database.
+var Commands = &cli.Command{
+	Name:     "slashing-protection",
+	Category: "slashing-protection",
+	Usage:    "defines commands for moving the slashing protection data of validators between clients or machines",
+	Subcommands: []*cli.Command{
+		{
+			Name: "export",
+			Description: `exports the slashing protection data of the validator database into a slashing protection
+interchange file (EIP-3076), which can be imported by any client. The validator client must not be running`,
+			Flags: []cli.Flag{
+				cmd.DataDirFlag,
+				flags.SlashingProtectionJSONFileFlag,
+				flags.GenesisValidatorsRootFlag,
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := ExportFromCLI(cliCtx); err != nil {
+					log.Fatalf("Could not export slashing protection data: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "import",
+			Description: `imports a slashing protection interchange file (EIP-3076) into the validator database. The
+imported data is merged with the data of the database, which is never loosened. The validator client must not be running`,
+			Flags: []cli.Flag{
+				cmd.DataDirFlag,
+				flags.SlashingProtectionJSONFileFlag,
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := ImportFromCLI(cliCtx); err != nil {
+					log.Fatalf("Could not import slashing protection data: %v", err)
+				}
+				return nil
+			},
+		},
+	},
+}
+
+// ExportFromCLI exports the slashing protection data of the validator database in the data directory
+// into the interchange file.
+func ExportFromCLI(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	path := cliCtx.String(flags.SlashingProtectionJSONFileFlag.Name)
+	if path == "" {
+		return fmt.Errorf("--%s is required", flags.SlashingProtectionJSONFileFlag.Name)
+	}
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	store, err := kv.GetKVStore(dataDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not open the validator database in %s", dataDir)
+	}
+	if store == nil {
+		return fmt.Errorf("no validator database found in %s", dataDir)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator database")
+		}
+	}()
+
+	if cliCtx.IsSet(flags.GenesisValidatorsRootFlag.Name) {
+		root, err := hexToBytes(cliCtx.String(flags.GenesisValidatorsRootFlag.Name), 32)
+		if err != nil {
+			return errors.Wrap(err, "invalid genesis validators root")
+		}
+		if err := store.SaveGenesisValidatorsRoot(ctx, root); err != nil {
+			return err
+		}
+	}
+	interchange, err := Export(ctx, store)
+	if err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode interchange data")
+	}
+	if err := ioutil.WriteFile(path, enc, params.BeaconIoConfig().ReadWritePermissions); err != nil {
+		return errors.Wrapf(err, "could not write %s", path)
+	}
+	log.WithFields(logrus.Fields{
+		"path":       path,
+		"validators": len(interchange.Data),
+	}).Info("Exported slashing protection data")
+	return nil
+}
+
+// ImportFromCLI imports the interchange file into the validator database in the data directory,
+// which is created when it does not exist yet.
+func ImportFromCLI(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	path := cliCtx.String(flags.SlashingProtectionJSONFileFlag.Name)
+	if path == "" {
+		return fmt.Errorf("--%s is required", flags.SlashingProtectionJSONFileFlag.Name)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close interchange file")
+		}
+	}()
+	interchange, err := Decode(f)
+	if err != nil {
+		return err
+	}
+	pubKeys, err := interchange.PubKeys()
+	if err != nil {
+		return err
+	}
+
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	store, err := kv.NewKVStore(dataDir, pubKeys)
+	if err != nil {
+		return errors.Wrapf(err, "could not open the validator database in %s", dataDir)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator database")
+		}
+	}()
+	if err := Import(ctx, store, interchange); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"path":       path,
+		"validators": len(pubKeys),
+	}).Info("Imported slashing protection data")
+	return nil
+}
@@ -0,0 +1,105 @@
+package interchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+// Export returns the slashing protection data of every validator public key of the database. The
+// genesis validators root of the chain must be known to the database, it is saved when the validator
+// client starts or when slashing protection data is imported.
+func Export(ctx context.Context, validatorDB db.Database) (*Interchange, error) {
+	root, err := validatorDB.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis validators root")
+	}
+	if root == nil {
+		return nil, errors.New("genesis validators root of the slashing protection data is unknown, " +
+			"start the validator client once or set the genesis validators root of the chain")
+	}
+	pubKeys, err := validatorDB.ProtectedPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get public keys")
+	}
+	histories, err := validatorDB.AttestationHistoryForPubKeys(ctx, pubKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation histories")
+	}
+
+	interchange := &Interchange{
+		Metadata: Metadata{
+			InterchangeFormatVersion: FormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", root),
+		},
+		Data: make([]*ProtectionData, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		data := &ProtectionData{
+			Pubkey:             fmt.Sprintf("%#x", pubKey),
+			SignedBlocks:       []*SignedBlock{},
+			SignedAttestations: []*SignedAttestation{},
+		}
+		slots, err := validatorDB.ProposedSlots(ctx, pubKey[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get proposal history of public key %#x", pubKey)
+		}
+		for _, slot := range slots {
+			data.SignedBlocks = append(data.SignedBlocks, &SignedBlock{Slot: uint64ToString(slot)})
+		}
+		history := histories[pubKey]
+		wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+		// Only the last weak subjectivity period of targets is kept in the history.
+		lowest := uint64(0)
+		if history.LatestEpochWritten >= wsPeriod {
+			lowest = history.LatestEpochWritten - wsPeriod + 1
+		}
+		for target := lowest; target <= history.LatestEpochWritten; target++ {
+			source, ok := history.TargetToSource[target%wsPeriod]
+			if !ok || source == params.BeaconConfig().FarFutureEpoch {
+				continue
+			}
+			data.SignedAttestations = append(data.SignedAttestations, &SignedAttestation{
+				SourceEpoch: uint64ToString(source),
+				TargetEpoch: uint64ToString(target),
+			})
+		}
+		if err := appendHighestSigned(ctx, validatorDB, pubKey, data, slots); err != nil {
+			return nil, err
+		}
+		interchange.Data = append(interchange.Data, data)
+	}
+	return interchange, nil
+}
+
+// appendHighestSigned adds the highest signed block and attestation of the minimal slashing
+// protection to the protection data, unless the history already lists them. The highest source and
+// target epochs may belong to different attestations, exporting them as a single attestation acts as
+// a watermark for the importing client.
+func appendHighestSigned(ctx context.Context, validatorDB db.Database, pubKey [48]byte, data *ProtectionData, slots []uint64) error {
+	slot, ok, err := validatorDB.HighestSignedProposal(ctx, pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not get highest signed proposal of public key %#x", pubKey)
+	}
+	if ok && (len(slots) == 0 || slots[len(slots)-1] < slot) {
+		data.SignedBlocks = append(data.SignedBlocks, &SignedBlock{Slot: uint64ToString(slot)})
+	}
+	source, target, ok, err := validatorDB.HighestSignedAttestation(ctx, pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not get highest signed attestation of public key %#x", pubKey)
+	}
+	if !ok {
+		return nil
+	}
+	att := &SignedAttestation{SourceEpoch: uint64ToString(source), TargetEpoch: uint64ToString(target)}
+	for _, a := range data.SignedAttestations {
+		if a.SourceEpoch == att.SourceEpoch && a.TargetEpoch == att.TargetEpoch {
+			return nil
+		}
+	}
+	data.SignedAttestations = append(data.SignedAttestations, att)
+	return nil
+}
@@ -0,0 +1,66 @@
+package interchange
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+func TestExport_UnknownGenesisValidatorsRoot(t *testing.T) {
+	validatorDB := dbTest.SetupDB(t, [][48]byte{{1}})
+
+	_, err := Export(context.Background(), validatorDB)
+	require.ErrorContains(t, "genesis validators root of the slashing protection data is unknown", err)
+}
+
+func TestExport_ImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][48]byte{{1}, {2}}
+	validatorDB := dbTest.SetupDB(t, pubKeys)
+	root := [32]byte{9}
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, root[:]))
+
+	slotBits, err := validatorDB.ProposalHistoryForEpoch(ctx, pubKeys[0][:], 1)
+	require.NoError(t, err)
+	slotBits.SetBitAt(3, true)
+	require.NoError(t, validatorDB.SaveProposalHistoryForEpoch(ctx, pubKeys[0][:], 1, slotBits))
+	history := &slashpb.AttestationHistory{TargetToSource: map[uint64]uint64{0: params.BeaconConfig().FarFutureEpoch}}
+	require.NoError(t, mergeAttestation(history, 0, 1))
+	require.NoError(t, mergeAttestation(history, 1, 3))
+	require.NoError(t, validatorDB.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKeys[1]: history}))
+
+	exported, err := Export(ctx, validatorDB)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, exported.Metadata.InterchangeFormatVersion)
+	assert.Equal(t, fmt.Sprintf("%#x", root), exported.Metadata.GenesisValidatorsRoot)
+	slot := params.BeaconConfig().SlotsPerEpoch + 3
+	want := []*ProtectionData{
+		{
+			Pubkey:             fmt.Sprintf("%#x", pubKeys[0]),
+			SignedBlocks:       []*SignedBlock{{Slot: fmt.Sprintf("%d", slot)}},
+			SignedAttestations: []*SignedAttestation{},
+		},
+		{
+			Pubkey:       fmt.Sprintf("%#x", pubKeys[1]),
+			SignedBlocks: []*SignedBlock{},
+			SignedAttestations: []*SignedAttestation{
+				{SourceEpoch: "0", TargetEpoch: "1"},
+				{SourceEpoch: "1", TargetEpoch: "3"},
+			},
+		},
+	}
+	require.DeepEqual(t, want, exported.Data)
+
+	// Importing the data into an empty database gives the same data back.
+	importedDB := dbTest.SetupDB(t, pubKeys)
+	require.NoError(t, Import(ctx, importedDB, exported))
+	reexported, err := Export(ctx, importedDB)
+	require.NoError(t, err)
+	require.DeepEqual(t, exported, reexported)
+}
@@ -0,0 +1,67 @@
+// Package interchange converts the slashing protection data of the validator database to and from
+// the slashing protection interchange format defined in EIP-3076, which allows to move validator
+// keys safely between clients or machines.
+package interchange
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatVersion of the interchange format which is imported and exported.
+const FormatVersion = "5"
+
+// Interchange is the slashing protection data of a set of validators of a chain.
+type Interchange struct {
+	Metadata Metadata          `json:"metadata"`
+	Data     []*ProtectionData `json:"data"`
+}
+
+// Metadata identifies the interchange format version and the chain of the slashing protection data.
+type Metadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// ProtectionData is the slashing protection data of a single validator public key.
+type ProtectionData struct {
+	Pubkey             string               `json:"pubkey"`
+	SignedBlocks       []*SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []*SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock is a block signed by a validator. Signing roots are optional, and are not exported
+// since the validator database does not keep them.
+type SignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is an attestation signed by a validator.
+type SignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+func uint64ToString(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
+
+func stringToUint64(s string) (uint64, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid decimal uint64", s)
+	}
+	return n, nil
+}
+
+func hexToBytes(s string, size int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != size {
+		return nil, fmt.Errorf("%q is not a valid %d bytes hex string", s, size)
+	}
+	return b, nil
+}
@@ -0,0 +1,239 @@
+package interchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+// attestation is the source and target epochs of a signed attestation.
+type attestation struct {
+	source uint64
+	target uint64
+}
+
+// records are the signed blocks and attestations of a validator public key.
+type records struct {
+	slots        []uint64
+	attestations []attestation
+}
+
+// Decode reads interchange data and checks its format version.
+func Decode(r io.Reader) (*Interchange, error) {
+	interchange := &Interchange{}
+	if err := json.NewDecoder(r).Decode(interchange); err != nil {
+		return nil, errors.Wrap(err, "could not decode interchange data")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != FormatVersion {
+		return nil, fmt.Errorf(
+			"unsupported interchange format version %q, expected %q",
+			interchange.Metadata.InterchangeFormatVersion,
+			FormatVersion,
+		)
+	}
+	return interchange, nil
+}
+
+// PubKeys returns the validator public keys of the interchange data, which must be initialized in
+// the validator database before importing.
+func (i *Interchange) PubKeys() ([][48]byte, error) {
+	recordsByPubKey, err := i.records()
+	if err != nil {
+		return nil, err
+	}
+	pubKeys := make([][48]byte, 0, len(recordsByPubKey))
+	for pubKey := range recordsByPubKey {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+// records parses the protection data by validator public key, merging the entries of public keys
+// which are listed more than once.
+func (i *Interchange) records() (map[[48]byte]*records, error) {
+	recordsByPubKey := make(map[[48]byte]*records, len(i.Data))
+	for _, data := range i.Data {
+		pk, err := hexToBytes(data.Pubkey, 48)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key")
+		}
+		var pubKey [48]byte
+		copy(pubKey[:], pk)
+		r, ok := recordsByPubKey[pubKey]
+		if !ok {
+			r = &records{}
+			recordsByPubKey[pubKey] = r
+		}
+		for _, block := range data.SignedBlocks {
+			slot, err := stringToUint64(block.Slot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid block slot of public key %s", data.Pubkey)
+			}
+			r.slots = append(r.slots, slot)
+		}
+		for _, att := range data.SignedAttestations {
+			source, err := stringToUint64(att.SourceEpoch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid attestation source epoch of public key %s", data.Pubkey)
+			}
+			target, err := stringToUint64(att.TargetEpoch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid attestation target epoch of public key %s", data.Pubkey)
+			}
+			if source > target {
+				return nil, fmt.Errorf("attestation of public key %s has source epoch %d after target epoch %d", data.Pubkey, source, target)
+			}
+			r.attestations = append(r.attestations, attestation{source: source, target: target})
+		}
+	}
+	return recordsByPubKey, nil
+}
+
+// Import merges the interchange data into the validator database. Imported blocks and attestations
+// are only ever added to the existing slashing protection data, so that nothing which was signed
+// before becomes signable again. The public keys of the interchange data must be initialized in the
+// database, and the database must not hold data of another chain.
+func Import(ctx context.Context, validatorDB db.Database, interchange *Interchange) error {
+	root, err := hexToBytes(interchange.Metadata.GenesisValidatorsRoot, 32)
+	if err != nil {
+		return errors.Wrap(err, "invalid genesis validators root")
+	}
+	// All of the data is parsed and merged with the existing attestations before anything is written.
+	recordsByPubKey, err := interchange.records()
+	if err != nil {
+		return err
+	}
+	pubKeys := make([][48]byte, 0, len(recordsByPubKey))
+	for pubKey := range recordsByPubKey {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	histories, err := validatorDB.AttestationHistoryForPubKeys(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation histories")
+	}
+	for pubKey, r := range recordsByPubKey {
+		// Attestations are marked from the oldest target, as the history only keeps the targets of
+		// the last weak subjectivity period.
+		sort.Slice(r.attestations, func(i, j int) bool {
+			return r.attestations[i].target < r.attestations[j].target
+		})
+		for _, att := range r.attestations {
+			if err := mergeAttestation(histories[pubKey], att.source, att.target); err != nil {
+				return errors.Wrapf(err, "could not import attestations of public key %#x", pubKey)
+			}
+		}
+	}
+	if err := validatorDB.SaveGenesisValidatorsRoot(ctx, root); err != nil {
+		return err
+	}
+
+	for pubKey, r := range recordsByPubKey {
+		if err := importSlots(ctx, validatorDB, pubKey, r.slots); err != nil {
+			return errors.Wrapf(err, "could not import blocks of public key %#x", pubKey)
+		}
+		if len(r.attestations) == 0 {
+			continue
+		}
+		// Validators using the minimal slashing protection only check the highest signed epochs.
+		var highestSource uint64
+		for _, att := range r.attestations {
+			if att.source > highestSource {
+				highestSource = att.source
+			}
+		}
+		highestTarget := r.attestations[len(r.attestations)-1].target
+		if err := validatorDB.SaveHighestSignedAttestation(ctx, pubKey, highestSource, highestTarget); err != nil {
+			return errors.Wrapf(err, "could not save highest signed attestation of public key %#x", pubKey)
+		}
+	}
+	if err := validatorDB.SaveAttestationHistoryForPubKeys(ctx, histories); err != nil {
+		return errors.Wrap(err, "could not save attestation histories")
+	}
+	return nil
+}
+
+// importSlots marks the slots as proposed in the proposal history of a public key.
+func importSlots(ctx context.Context, validatorDB db.Database, pubKey [48]byte, slots []uint64) error {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	slotsByEpoch := make(map[uint64][]uint64)
+	var epochs []uint64
+	for _, slot := range slots {
+		epoch := slot / slotsPerEpoch
+		if _, ok := slotsByEpoch[epoch]; !ok {
+			epochs = append(epochs, epoch)
+		}
+		slotsByEpoch[epoch] = append(slotsByEpoch[epoch], slot)
+	}
+	// Saving an epoch prunes the epochs older than a weak subjectivity period before it.
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+	for _, epoch := range epochs {
+		slotBits, err := validatorDB.ProposalHistoryForEpoch(ctx, pubKey[:], epoch)
+		if err != nil {
+			return err
+		}
+		for _, slot := range slotsByEpoch[epoch] {
+			slotBits.SetBitAt(slot%slotsPerEpoch, true)
+		}
+		if err := validatorDB.SaveProposalHistoryForEpoch(ctx, pubKey[:], epoch, slotBits); err != nil {
+			return err
+		}
+	}
+	if len(epochs) == 0 {
+		return nil
+	}
+	// Validators using the minimal slashing protection only check the highest signed slot.
+	highest := uint64(0)
+	for _, slot := range slots {
+		if slot > highest {
+			highest = slot
+		}
+	}
+	return validatorDB.SaveHighestSignedProposal(ctx, pubKey, highest)
+}
+
+// mergeAttestation marks the target epoch of an attestation as attested in the history, which keeps
+// the source epoch of each target of the last weak subjectivity period, using the same layout as the
+// local slashing protection of the validator client. As the history has a single source per target,
+// an attestation with another source than the one already marked for its target is rejected, such
+// attestations are a double vote.
+func mergeAttestation(history *slashpb.AttestationHistory, source uint64, target uint64) error {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	if history.TargetToSource == nil {
+		history.TargetToSource = map[uint64]uint64{0: farFuture}
+	}
+	// Targets older than the history are pruned.
+	if history.LatestEpochWritten >= wsPeriod && target <= history.LatestEpochWritten-wsPeriod {
+		return nil
+	}
+	if target > history.LatestEpochWritten {
+		maxToWrite := history.LatestEpochWritten + wsPeriod
+		for i := history.LatestEpochWritten + 1; i < target && i <= maxToWrite; i++ {
+			history.TargetToSource[i%wsPeriod] = farFuture
+		}
+		history.LatestEpochWritten = target
+		history.TargetToSource[target%wsPeriod] = source
+		return nil
+	}
+	existing, ok := history.TargetToSource[target%wsPeriod]
+	if ok && existing != farFuture && existing != source {
+		return fmt.Errorf(
+			"attestation with source epoch %d and target epoch %d conflicts with the signed attestation with source epoch %d, "+
+				"multiple sources for a target are not supported",
+			source,
+			target,
+			existing,
+		)
+	}
+	history.TargetToSource[target%wsPeriod] = source
+	return nil
+}
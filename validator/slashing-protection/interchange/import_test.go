@@ -0,0 +1,194 @@
+package interchange
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+const testRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+
+func TestDecode(t *testing.T) {
+	data := `{
+  "metadata": {"interchange_format_version": "5", "genesis_validators_root": "` + testRoot + `"},
+  "data": [
+    {
+      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+      "signed_blocks": [{"slot": "81952", "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}],
+      "signed_attestations": [{"source_epoch": "2290", "target_epoch": "3007"}]
+    }
+  ]
+}`
+	interchange, err := Decode(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, testRoot, interchange.Metadata.GenesisValidatorsRoot)
+	require.Equal(t, 1, len(interchange.Data))
+	assert.Equal(t, "81952", interchange.Data[0].SignedBlocks[0].Slot)
+	assert.Equal(t, "3007", interchange.Data[0].SignedAttestations[0].TargetEpoch)
+	pubKeys, err := interchange.PubKeys()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pubKeys))
+
+	_, err = Decode(strings.NewReader(`{"metadata": {"interchange_format_version": "4"}}`))
+	require.ErrorContains(t, "unsupported interchange format version", err)
+}
+
+func TestImport_MergesWithExistingData(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := dbTest.SetupDB(t, [][48]byte{pubKey})
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	// Existing data: a block at slot 10 and an attestation from 2 to 5.
+	slotBits, err := validatorDB.ProposalHistoryForEpoch(ctx, pubKey[:], 10/slotsPerEpoch)
+	require.NoError(t, err)
+	slotBits.SetBitAt(10%slotsPerEpoch, true)
+	require.NoError(t, validatorDB.SaveProposalHistoryForEpoch(ctx, pubKey[:], 10/slotsPerEpoch, slotBits))
+	history := &slashpb.AttestationHistory{TargetToSource: map[uint64]uint64{0: params.BeaconConfig().FarFutureEpoch}}
+	require.NoError(t, mergeAttestation(history, 2, 5))
+	require.NoError(t, validatorDB.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}))
+
+	interchange := &Interchange{
+		Metadata: Metadata{InterchangeFormatVersion: FormatVersion, GenesisValidatorsRoot: testRoot},
+		Data: []*ProtectionData{
+			{
+				Pubkey:             fmt.Sprintf("%#x", pubKey),
+				SignedBlocks:       []*SignedBlock{{Slot: "11"}},
+				SignedAttestations: []*SignedAttestation{{SourceEpoch: "2", TargetEpoch: "5"}},
+			},
+			// The same public key may be listed more than once.
+			{
+				Pubkey:             fmt.Sprintf("%#x", pubKey),
+				SignedAttestations: []*SignedAttestation{{SourceEpoch: "3", TargetEpoch: "6"}},
+			},
+		},
+	}
+	require.NoError(t, Import(ctx, validatorDB, interchange))
+
+	slots, err := validatorDB.ProposedSlots(ctx, pubKey[:])
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{10, 11}, slots)
+	histories, err := validatorDB.AttestationHistoryForPubKeys(ctx, [][48]byte{pubKey})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), histories[pubKey].TargetToSource[5])
+	assert.Equal(t, uint64(3), histories[pubKey].TargetToSource[6])
+	assert.Equal(t, uint64(6), histories[pubKey].LatestEpochWritten)
+	assert.Equal(t, params.BeaconConfig().FarFutureEpoch, histories[pubKey].TargetToSource[4])
+}
+
+func TestImport_MultipleSourcesOfTarget(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+
+	t.Run("in the interchange data", func(t *testing.T) {
+		validatorDB := dbTest.SetupDB(t, [][48]byte{pubKey})
+		interchange := &Interchange{
+			Metadata: Metadata{InterchangeFormatVersion: FormatVersion, GenesisValidatorsRoot: testRoot},
+			Data: []*ProtectionData{
+				{
+					Pubkey:       fmt.Sprintf("%#x", pubKey),
+					SignedBlocks: []*SignedBlock{{Slot: "11"}},
+					SignedAttestations: []*SignedAttestation{
+						{SourceEpoch: "1", TargetEpoch: "5"},
+						{SourceEpoch: "2", TargetEpoch: "5"},
+					},
+				},
+			},
+		}
+		require.ErrorContains(t, "multiple sources for a target are not supported", Import(ctx, validatorDB, interchange))
+		// Nothing is written when the attestations conflict.
+		slots, err := validatorDB.ProposedSlots(ctx, pubKey[:])
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(slots))
+		root, err := validatorDB.GenesisValidatorsRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, true, root == nil, "Expected no genesis validators root to be saved")
+	})
+
+	t.Run("in the existing data", func(t *testing.T) {
+		validatorDB := dbTest.SetupDB(t, [][48]byte{pubKey})
+		history := &slashpb.AttestationHistory{TargetToSource: map[uint64]uint64{0: params.BeaconConfig().FarFutureEpoch}}
+		require.NoError(t, mergeAttestation(history, 2, 5))
+		require.NoError(t, validatorDB.SaveAttestationHistoryForPubKeys(ctx, map[[48]byte]*slashpb.AttestationHistory{pubKey: history}))
+
+		interchange := &Interchange{
+			Metadata: Metadata{InterchangeFormatVersion: FormatVersion, GenesisValidatorsRoot: testRoot},
+			Data: []*ProtectionData{
+				{
+					Pubkey:             fmt.Sprintf("%#x", pubKey),
+					SignedAttestations: []*SignedAttestation{{SourceEpoch: "1", TargetEpoch: "5"}},
+				},
+			},
+		}
+		require.ErrorContains(t, "multiple sources for a target are not supported", Import(ctx, validatorDB, interchange))
+		histories, err := validatorDB.AttestationHistoryForPubKeys(ctx, [][48]byte{pubKey})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), histories[pubKey].TargetToSource[5])
+	})
+}
+
+func TestImport_GenesisValidatorsRootMismatch(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := dbTest.SetupDB(t, [][48]byte{pubKey})
+	root := [32]byte{1}
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, root[:]))
+
+	interchange := &Interchange{
+		Metadata: Metadata{InterchangeFormatVersion: FormatVersion, GenesisValidatorsRoot: testRoot},
+		Data: []*ProtectionData{
+			{Pubkey: fmt.Sprintf("%#x", pubKey), SignedBlocks: []*SignedBlock{{Slot: "11"}}},
+		},
+	}
+	require.ErrorContains(t, "differs from the saved root", Import(ctx, validatorDB, interchange))
+	slots, err := validatorDB.ProposedSlots(ctx, pubKey[:])
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slots))
+}
+
+func TestImport_InvalidData(t *testing.T) {
+	pubKey := fmt.Sprintf("%#x", [48]byte{1})
+	tests := []struct {
+		name string
+		data *ProtectionData
+		err  string
+	}{
+		{
+			name: "invalid public key",
+			data: &ProtectionData{Pubkey: "0x0102"},
+			err:  "invalid public key",
+		},
+		{
+			name: "invalid slot",
+			data: &ProtectionData{Pubkey: pubKey, SignedBlocks: []*SignedBlock{{Slot: "-1"}}},
+			err:  "invalid block slot",
+		},
+		{
+			name: "source after target",
+			data: &ProtectionData{Pubkey: pubKey, SignedAttestations: []*SignedAttestation{{SourceEpoch: "3", TargetEpoch: "2"}}},
+			err:  "after target epoch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			validatorDB := dbTest.SetupDB(t, [][48]byte{{1}})
+			interchange := &Interchange{
+				Metadata: Metadata{InterchangeFormatVersion: FormatVersion, GenesisValidatorsRoot: testRoot},
+				Data:     []*ProtectionData{tt.data},
+			}
+			require.ErrorContains(t, tt.err, Import(ctx, validatorDB, interchange))
+			// Nothing is written when the data is invalid.
+			root, err := validatorDB.GenesisValidatorsRoot(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, true, root == nil, "Expected no genesis validators root to be saved")
+		})
+	}
+}
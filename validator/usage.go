@@ -86,11 +86,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.DisablePenaltyRewardLogFlag,
 			flags.UnencryptedKeysFlag,
 			flags.GraffitiFlag,
+			flags.GraffitiFileFlag,
 			flags.GrpcRetriesFlag,
 			flags.GrpcRetryDelayFlag,
 			flags.GrpcHeadersFlag,
 			flags.SlasherRPCProviderFlag,
 			flags.SlasherCertFlag,
+			flags.DoppelgangerDetectionEpochsFlag,
 			flags.SourceDirectories,
 			flags.SourceDirectory,
 			flags.TargetDirectory,